@@ -4,14 +4,22 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/Tretorhate/university-management-system/internal/api/controllers"
 	"github.com/Tretorhate/university-management-system/internal/api/middleware"
 	"github.com/Tretorhate/university-management-system/internal/api/routes"
 	"github.com/Tretorhate/university-management-system/internal/config"
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/policy"
 	"github.com/Tretorhate/university-management-system/internal/repository"
 	"github.com/Tretorhate/university-management-system/internal/service"
 	"github.com/Tretorhate/university-management-system/pkg/auth"
+	"github.com/Tretorhate/university-management-system/pkg/grading"
+	"github.com/Tretorhate/university-management-system/pkg/mailer"
+	"github.com/Tretorhate/university-management-system/pkg/storage"
+	"github.com/Tretorhate/university-management-system/pkg/throttle"
 	"github.com/Tretorhate/university-management-system/pkg/validator"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -56,6 +64,15 @@ func main() {
 		}
 	}
 
+	// Load the grade scale used for letter grades and GPA
+	gradeScale := grading.DefaultScale()
+	if cfg.GradeScale != "" {
+		gradeScale, err = grading.ParseScale(cfg.GradeScale)
+		if err != nil {
+			log.Fatalf("Failed to parse grade scale: %v", err)
+		}
+	}
+
 	// Initialize repositories
 	baseRepo := repository.NewRepository(db)
 	userRepo := repository.NewUserRepository(baseRepo)
@@ -63,19 +80,155 @@ func main() {
 	teacherRepo := repository.NewTeacherRepository(baseRepo)
 	courseRepo := repository.NewCourseRepository(baseRepo)
 	enrollmentRepo := repository.NewEnrollmentRepository(baseRepo)
+	prerequisiteRepo := repository.NewCoursePrerequisiteRepository(baseRepo)
+	waitlistRepo := repository.NewWaitlistRepository(baseRepo)
+	termRepo := repository.NewTermRepository(baseRepo)
+	issuedDocumentRepo := repository.NewIssuedDocumentRepository(baseRepo)
+	invitationRepo := repository.NewInvitationRepository(baseRepo)
+	sessionRepo := repository.NewSessionRepository(baseRepo)
+	userTokenRepo := repository.NewUserTokenRepository(baseRepo)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(baseRepo)
+	securityEventRepo := repository.NewSecurityEventRepository(baseRepo)
+	roleRepo := repository.NewRoleRepository(baseRepo)
+	auditLogRepo := repository.NewAuditLogRepository(baseRepo)
+	gradeChangeRepo := repository.NewGradeChangeRepository(baseRepo)
+	assessmentRepo := repository.NewAssessmentRepository(baseRepo)
+	assignmentRepo := repository.NewAssignmentRepository(baseRepo)
+	attendanceRepo := repository.NewAttendanceRepository(baseRepo)
+	meetingRepo := repository.NewMeetingRepository(baseRepo)
+	calendarFeedRepo := repository.NewCalendarFeedRepository(baseRepo)
+	roomRepo := repository.NewRoomRepository(baseRepo)
+	scheduleJobRepo := repository.NewScheduleJobRepository(baseRepo)
+	teacherPreferenceRepo := repository.NewTeacherPreferenceRepository(baseRepo)
+	examRepo := repository.NewExamRepository(baseRepo)
+	programRepo := repository.NewProgramRepository(baseRepo)
+	departmentRepo := repository.NewDepartmentRepository(baseRepo)
+
+	// Token lifetimes
+	accessTTL, err := parseDuration(cfg.AccessTokenTTL)
+	if err != nil {
+		log.Fatalf("Invalid ACCESS_TOKEN_TTL: %v", err)
+	}
+	refreshTTL, err := parseDuration(cfg.RefreshTokenTTL)
+	if err != nil {
+		log.Fatalf("Invalid REFRESH_TOKEN_TTL: %v", err)
+	}
+
+	// Initialize mailer
+	mail, err := newMailer(&cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize mailer: %v", err)
+	}
+
+	// Initialize file storage
+	blobs, err := newBlobStore(&cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize file storage: %v", err)
+	}
+	uploadMaxSizeMB := cfg.UploadMaxSizeMB
+	if uploadMaxSizeMB <= 0 {
+		uploadMaxSizeMB = 20
+	}
+
+	absenceLimit := cfg.AttendanceAbsenceLimit
+	if absenceLimit == 0 {
+		absenceLimit = 3
+	}
+
+	scheduleJobTimeout, err := parseDuration(cfg.ScheduleJobTimeout)
+	if err != nil {
+		log.Fatalf("Invalid SCHEDULE_JOB_TIMEOUT: %v", err)
+	}
+	if scheduleJobTimeout <= 0 {
+		scheduleJobTimeout = 10 * time.Minute
+	}
+
+	examMaxPerDay := cfg.ExamMaxPerDay
+	if examMaxPerDay == 0 {
+		examMaxPerDay = 2
+	} else if examMaxPerDay < 0 {
+		examMaxPerDay = 0
+	}
 
 	// Initialize JWT service
-	jwtService := auth.NewJWTService(cfg.JWTSecret)
+	jwtService := auth.NewJWTService(cfg.JWTSecret, accessTTL)
 
 	// Initialize services
-	authService := service.NewAuthService(userRepo, jwtService)
-	studentService := service.NewStudentService(studentRepo, userRepo)
-	teacherService := service.NewTeacherService(teacherRepo, userRepo)
-	courseService := service.NewCourseService(courseRepo, teacherRepo)
-	enrollmentService := service.NewEnrollmentService(enrollmentRepo, studentRepo, courseRepo)
+	auditService := service.NewAuditService(auditLogRepo)
+	accountService := service.NewAccountService(userRepo, userTokenRepo, sessionRepo, auditService, mail, cfg.AppBaseURL)
+	twoFactorRoles := parseRoles(cfg.TwoFactorRequiredRoles)
+	totpIssuer := cfg.TOTPIssuer
+	if totpIssuer == "" {
+		totpIssuer = "University Management System"
+	}
+
+	// Failed login tracking, shared through Postgres when running several instances
+	var throttleStore throttle.Store
+	switch cfg.LoginThrottleStore {
+	case "", "memory":
+		throttleStore = throttle.NewMemoryStore()
+	case "postgres":
+		throttleStore = repository.NewLoginAttemptRepository(baseRepo)
+	default:
+		log.Fatalf("Unknown LOGIN_THROTTLE_STORE %q", cfg.LoginThrottleStore)
+	}
+	lockoutDuration, err := parseDuration(cfg.LoginLockoutDuration)
+	if err != nil {
+		log.Fatalf("Invalid LOGIN_LOCKOUT_DURATION: %v", err)
+	}
+	loginGuard := service.NewLoginGuard(
+		throttleStore,
+		service.DefaultAccountPolicy(cfg.LoginMaxFailures, lockoutDuration),
+		service.DefaultIPPolicy(cfg.LoginMaxFailures, lockoutDuration),
+		securityEventRepo,
+		userRepo,
+	)
+	twoFactorService := service.NewTwoFactorService(userRepo, recoveryCodeRepo, loginGuard, totpIssuer, twoFactorRoles)
+
+	authService := service.NewAuthService(userRepo, studentRepo, teacherRepo, invitationRepo, sessionRepo, auditService, accountService, twoFactorService, loginGuard, jwtService, refreshTTL, cfg.RequireEmailVerification)
+	studentService := service.NewStudentService(studentRepo, userRepo, sessionRepo, auditService)
+	teacherService := service.NewTeacherService(teacherRepo, departmentRepo, userRepo, sessionRepo, auditService)
+	courseService := service.NewCourseService(courseRepo, teacherRepo, termRepo, departmentRepo, auditService)
+	enrollmentService := service.NewEnrollmentService(enrollmentRepo, studentRepo, courseRepo, prerequisiteRepo, waitlistRepo, assessmentRepo, meetingRepo, auditService, gradeScale)
+	prerequisiteService := service.NewPrerequisiteService(prerequisiteRepo, courseRepo)
+	termService := service.NewTermService(termRepo, courseRepo, auditService)
+	transcriptService := service.NewTranscriptService(studentRepo, enrollmentRepo, gradeScale)
+	documentService := service.NewDocumentService(transcriptService, studentRepo, enrollmentRepo, issuedDocumentRepo, cfg.AppBaseURL)
+	roleService := service.NewRoleService(roleRepo, userRepo, auditService)
+	invitationService := service.NewInvitationService(invitationRepo, roleRepo, userRepo, mail, cfg.AppBaseURL)
+	gradingService := service.NewGradingService(gradeChangeRepo, enrollmentRepo, courseRepo, auditService)
+	gradebookService := service.NewGradebookService(assessmentRepo, courseRepo, enrollmentRepo, auditService)
+	assignmentService := service.NewAssignmentService(assignmentRepo, courseRepo, enrollmentRepo, blobs, auditService)
+	attendanceService := service.NewAttendanceService(attendanceRepo, courseRepo, enrollmentRepo, auditService, mail, absenceLimit)
+	timetableService := service.NewTimetableService(meetingRepo, roomRepo, courseRepo, enrollmentRepo, studentRepo, teacherRepo, auditService)
+	calendarService := service.NewCalendarService(calendarFeedRepo, userRepo, studentRepo, teacherRepo, enrollmentRepo, courseRepo, meetingRepo, assignmentRepo, cfg.AppBaseURL)
+	roomService := service.NewRoomService(roomRepo, meetingRepo, courseRepo, auditService)
+	schedulerService := service.NewSchedulerService(scheduleJobRepo, termRepo, courseRepo, meetingRepo, roomRepo, teacherPreferenceRepo, teacherRepo, auditService, scheduleJobTimeout)
+	examService := service.NewExamService(examRepo, courseRepo, termRepo, enrollmentRepo, studentRepo, auditService, examMaxPerDay)
+	departmentService := service.NewDepartmentService(departmentRepo, teacherRepo, courseRepo, auditService)
+	programService := service.NewProgramService(programRepo, courseRepo, departmentRepo, studentRepo, enrollmentRepo, auditService, gradeScale)
+
+	// Jobs cannot survive a restart, so fail the ones left behind
+	if failed, err := schedulerService.FailInterrupted(); err != nil {
+		log.Printf("Failed to clean up interrupted schedule jobs: %v", err)
+	} else if failed > 0 {
+		log.Printf("Marked %d interrupted schedule jobs as failed", failed)
+	}
+
+	// Create the first admin account on a fresh installation
+	if cfg.AdminEmail != "" && cfg.AdminPassword != "" {
+		created, err := authService.EnsureAdmin(cfg.AdminEmail, cfg.AdminPassword)
+		if err != nil {
+			log.Fatalf("Failed to create initial admin: %v", err)
+		}
+		if created {
+			log.Printf("Created initial admin account %s", cfg.AdminEmail)
+		}
+	}
 
 	// Initialize middleware
-	authMiddleware := middleware.NewAuthMiddleware(jwtService)
+	subjectResolver := policy.NewResolver(roleService, studentRepo, teacherRepo)
+	authMiddleware := middleware.NewAuthMiddleware(jwtService, sessionRepo, subjectResolver)
 
 	// Initialize controllers
 	authController := controllers.NewAuthController(authService)
@@ -83,9 +236,35 @@ func main() {
 	teacherController := controllers.NewTeacherController(teacherService)
 	courseController := controllers.NewCourseController(courseService)
 	enrollmentController := controllers.NewEnrollmentController(enrollmentService)
+	prerequisiteController := controllers.NewPrerequisiteController(prerequisiteService)
+	termController := controllers.NewTermController(termService)
+	transcriptController := controllers.NewTranscriptController(transcriptService)
+	documentController := controllers.NewDocumentController(documentService)
+	invitationController := controllers.NewInvitationController(invitationService)
+	accountController := controllers.NewAccountController(accountService)
+	twoFactorController := controllers.NewTwoFactorController(twoFactorService)
+	securityController := controllers.NewSecurityController(loginGuard)
+	roleController := controllers.NewRoleController(roleService)
+	auditController := controllers.NewAuditController(auditService)
+	gradingController := controllers.NewGradingController(gradingService)
+	gradebookController := controllers.NewGradebookController(gradebookService)
+	assignmentController := controllers.NewAssignmentController(assignmentService, int64(uploadMaxSizeMB)<<20)
+	attendanceController := controllers.NewAttendanceController(attendanceService)
+	timetableController := controllers.NewTimetableController(timetableService)
+	calendarController := controllers.NewCalendarController(calendarService)
+	roomController := controllers.NewRoomController(roomService)
+	schedulerController := controllers.NewSchedulerController(schedulerService)
+	examController := controllers.NewExamController(examService)
+	programController := controllers.NewProgramController(programService)
+	departmentController := controllers.NewDepartmentController(departmentService)
 
 	// Setup gin router
 	router := gin.Default()
+	// Client IPs key the login throttle, so forwarding headers are only honored
+	// from configured proxies
+	if err := router.SetTrustedProxies(parseList(cfg.TrustedProxies)); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// Setup routes
 	routes.SetupRoutes(
@@ -95,7 +274,28 @@ func main() {
 		studentController,
 		teacherController,
 		courseController,
+		prerequisiteController,
 		enrollmentController,
+		termController,
+		transcriptController,
+		documentController,
+		invitationController,
+		accountController,
+		twoFactorController,
+		securityController,
+		roleController,
+		auditController,
+		gradingController,
+		gradebookController,
+		assignmentController,
+		attendanceController,
+		timetableController,
+		calendarController,
+		roomController,
+		schedulerController,
+		examController,
+		programController,
+		departmentController,
 	)
 
 	// Start server
@@ -109,3 +309,59 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// parseDuration parses an optional duration setting; empty means use the default
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// newMailer builds the mailer selected by MAIL_DRIVER
+func newMailer(cfg *config.Config) (mailer.Mailer, error) {
+	switch cfg.MailDriver {
+	case "smtp":
+		return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	case "file":
+		return mailer.NewFileMailer(cfg.MailDir, cfg.MailFrom)
+	case "", "log":
+		return mailer.NewLogMailer(os.Stdout, cfg.MailFrom), nil
+	default:
+		return nil, fmt.Errorf("unknown MAIL_DRIVER %q", cfg.MailDriver)
+	}
+}
+
+func newBlobStore(cfg *config.Config) (storage.BlobStore, error) {
+	switch cfg.StorageDriver {
+	case "", "local":
+		dir := cfg.StorageDir
+		if dir == "" {
+			dir = "./tmp/uploads"
+		}
+		return storage.NewLocalStore(dir)
+	default:
+		return nil, fmt.Errorf("unknown STORAGE_DRIVER %q", cfg.StorageDriver)
+	}
+}
+
+// parseRoles parses a comma separated list of role names
+func parseRoles(value string) []domain.Role {
+	var roles []domain.Role
+	for _, name := range parseList(value) {
+		roles = append(roles, domain.Role(strings.ToUpper(name)))
+	}
+	return roles
+}
+
+// parseList splits a comma separated setting, ignoring blank items
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
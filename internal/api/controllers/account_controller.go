@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type AccountController struct {
+	accountService *service.AccountService
+}
+
+func NewAccountController(accountService *service.AccountService) *AccountController {
+	return &AccountController{accountService: accountService}
+}
+
+func (c *AccountController) ForgotPassword(ctx *gin.Context) {
+	var request dto.EmailRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	if err := c.accountService.ForgotPassword(&request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "If the email belongs to an account, a reset token has been sent"})
+}
+
+func (c *AccountController) ResetPassword(ctx *gin.Context) {
+	var request dto.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	if err := c.accountService.ResetPassword(middleware.CurrentActor(ctx), &request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Password reset successfully"})
+}
+
+// VerifyEmail accepts the token as JSON body or as the ?token= query parameter
+// of the link sent by email
+func (c *AccountController) VerifyEmail(ctx *gin.Context) {
+	request := dto.VerifyEmailRequest{Token: ctx.Query("token")}
+	if request.Token == "" {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.Error(errors.BadRequest("Invalid request body", err))
+			return
+		}
+	}
+
+	if err := c.accountService.VerifyEmail(middleware.CurrentActor(ctx), &request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Email verified successfully"})
+}
+
+func (c *AccountController) ResendVerification(ctx *gin.Context) {
+	var request dto.EmailRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	if err := c.accountService.ResendVerification(&request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "If the email belongs to an unverified account, a new link has been sent"})
+}
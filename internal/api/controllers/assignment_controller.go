@@ -0,0 +1,232 @@
+package controllers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type AssignmentController struct {
+	assignmentService *service.AssignmentService
+	maxUploadSize     int64
+}
+
+func NewAssignmentController(assignmentService *service.AssignmentService, maxUploadSize int64) *AssignmentController {
+	return &AssignmentController{assignmentService: assignmentService, maxUploadSize: maxUploadSize}
+}
+
+func (c *AssignmentController) Create(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.AssignmentCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	assignment, err := c.assignmentService.Create(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, assignment)
+}
+
+func (c *AssignmentController) GetByCourse(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	assignments, err := c.assignmentService.GetByCourse(uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, assignments)
+}
+
+func (c *AssignmentController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	assignment, err := c.assignmentService.GetByID(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, assignment)
+}
+
+func (c *AssignmentController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.AssignmentUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	assignment, err := c.assignmentService.Update(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, assignment)
+}
+
+func (c *AssignmentController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if err := c.assignmentService.Delete(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Assignment deleted successfully"})
+}
+
+// Submit accepts a multipart upload with the file in the "file" field
+func (c *AssignmentController) Submit(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	// Leave room for the multipart framing around the file
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, c.maxUploadSize+1<<20)
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(errors.BadRequest("A file is required in the \"file\" field", err))
+		return
+	}
+	if header.Size > c.maxUploadSize {
+		ctx.Error(errors.New(http.StatusRequestEntityTooLarge, "File is too large", nil).
+			WithDetails(map[string]interface{}{"maxBytes": c.maxUploadSize}))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.Error(errors.BadRequest("Failed to read uploaded file", err))
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	upload := dto.SubmissionUploadDTO{FileName: header.Filename, ContentType: contentType, Content: file}
+
+	submission, err := c.assignmentService.Submit(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &upload)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, submission)
+}
+
+func (c *AssignmentController) GetSubmissions(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	submissions, err := c.assignmentService.GetSubmissions(middleware.CurrentSubject(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, submissions)
+}
+
+func (c *AssignmentController) GetSubmission(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	submission, err := c.assignmentService.GetSubmission(middleware.CurrentSubject(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, submission)
+}
+
+func (c *AssignmentController) DownloadSubmission(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	submission, content, err := c.assignmentService.OpenSubmissionFile(middleware.CurrentSubject(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	defer content.Close()
+
+	// The file name and content type come from the uploader, so the name is
+	// encoded properly and browsers must not sniff the body into something else
+	ctx.DataFromReader(200, submission.Size, submission.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": submission.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (c *AssignmentController) Grade(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.SubmissionGradeDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	submission, err := c.assignmentService.Grade(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, submission)
+}
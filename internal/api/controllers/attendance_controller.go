@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type AttendanceController struct {
+	attendanceService *service.AttendanceService
+}
+
+func NewAttendanceController(attendanceService *service.AttendanceService) *AttendanceController {
+	return &AttendanceController{attendanceService: attendanceService}
+}
+
+func (c *AttendanceController) CreateSession(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.ClassSessionCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	session, err := c.attendanceService.CreateSession(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, session)
+}
+
+func (c *AttendanceController) GetSessions(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	sessions, err := c.attendanceService.GetSessions(uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, sessions)
+}
+
+func (c *AttendanceController) UpdateSession(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.ClassSessionUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	session, err := c.attendanceService.UpdateSession(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, session)
+}
+
+func (c *AttendanceController) DeleteSession(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if err := c.attendanceService.DeleteSession(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Session deleted successfully"})
+}
+
+func (c *AttendanceController) GetSessionAttendance(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	records, err := c.attendanceService.GetSessionAttendance(middleware.CurrentSubject(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, records)
+}
+
+func (c *AttendanceController) MarkAttendance(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.AttendanceMarkDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	records, err := c.attendanceService.MarkAttendance(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, records)
+}
+
+func (c *AttendanceController) GetEnrollmentAttendance(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	summary, err := c.attendanceService.GetEnrollmentAttendance(middleware.CurrentSubject(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, summary)
+}
+
+func (c *AttendanceController) GetCourseAttendance(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	summaries, err := c.attendanceService.GetCourseAttendance(middleware.CurrentSubject(ctx), uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, summaries)
+}
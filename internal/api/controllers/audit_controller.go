@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	auditService *service.AuditService
+}
+
+func NewAuditController(auditService *service.AuditService) *AuditController {
+	return &AuditController{auditService: auditService}
+}
+
+// GetAll lists audit entries, filterable by actorId, entityType, entityId,
+// action and an RFC 3339 time range, paged with limit and offset
+func (c *AuditController) GetAll(ctx *gin.Context) {
+	query := dto.AuditLogQueryDTO{
+		EntityType: ctx.Query("entityType"),
+		Action:     ctx.Query("action"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *uint
+	}{{"actorId", &query.ActorID}, {"entityId", &query.EntityID}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			ctx.Error(errors.BadRequest("Invalid "+param.name, err))
+			return
+		}
+		*param.target = uint(parsed)
+	}
+
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"limit", &query.Limit}, {"offset", &query.Offset}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			ctx.Error(errors.BadRequest("Invalid "+param.name, err))
+			return
+		}
+		*param.target = parsed
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &query.From}, {"to", &query.To}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ctx.Error(errors.BadRequest("Invalid "+param.name+" time, expected RFC 3339", err))
+			return
+		}
+		*param.target = &parsed
+	}
+
+	entries, err := c.auditService.GetAll(&query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, entries)
+}
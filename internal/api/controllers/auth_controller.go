@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
 	"github.com/Tretorhate/university-management-system/internal/dto"
 	"github.com/Tretorhate/university-management-system/internal/service"
 	"github.com/Tretorhate/university-management-system/pkg/errors"
@@ -22,7 +23,23 @@ func (c *AuthController) Register(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.authService.Register(&request)
+	response, err := c.authService.Register(middleware.CurrentActor(ctx), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, response)
+}
+
+func (c *AuthController) RegisterWithInvitation(ctx *gin.Context) {
+	var request dto.InvitationRegisterRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.authService.RegisterWithInvitation(middleware.CurrentActor(ctx), &request)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -38,7 +55,102 @@ func (c *AuthController) Login(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.authService.Login(&request)
+	response, err := c.authService.Login(&request, ctx.ClientIP())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *AuthController) Refresh(ctx *gin.Context) {
+	var request dto.RefreshRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.authService.Refresh(&request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *AuthController) Logout(ctx *gin.Context) {
+	var request dto.RefreshRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	if err := c.authService.Logout(&request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Logged out successfully"})
+}
+
+func (c *AuthController) ChangePassword(ctx *gin.Context) {
+	var request dto.ChangePasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.authService.ChangePassword(middleware.CurrentActor(ctx), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *AuthController) VerifyTwoFactor(ctx *gin.Context) {
+	var request dto.TwoFactorVerifyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.authService.VerifyTwoFactor(&request, ctx.ClientIP())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *AuthController) SetupTwoFactor(ctx *gin.Context) {
+	var request dto.TwoFactorChallengeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.authService.SetupTwoFactor(&request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *AuthController) ActivateTwoFactor(ctx *gin.Context) {
+	var request dto.TwoFactorActivateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.authService.ActivateTwoFactor(&request)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarController struct {
+	calendarService *service.CalendarService
+}
+
+func NewCalendarController(calendarService *service.CalendarService) *CalendarController {
+	return &CalendarController{calendarService: calendarService}
+}
+
+func (c *CalendarController) CreateFeed(ctx *gin.Context) {
+	feed, err := c.calendarService.CreateFeed(middleware.CurrentSubject(ctx))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, feed)
+}
+
+func (c *CalendarController) GetFeed(ctx *gin.Context) {
+	feed, err := c.calendarService.GetFeed(middleware.CurrentSubject(ctx).UserID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, feed)
+}
+
+func (c *CalendarController) RevokeFeed(ctx *gin.Context) {
+	if err := c.calendarService.RevokeFeed(middleware.CurrentSubject(ctx).UserID); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+// Feed serves the iCalendar file behind a feed token. It is public, the token
+// in the URL is the credential.
+func (c *CalendarController) Feed(ctx *gin.Context) {
+	content, err := c.calendarService.Feed(ctx.Param("token"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=300")
+	ctx.Header("Content-Disposition", `inline; filename="timetable.ics"`)
+	ctx.Data(200, "text/calendar; charset=utf-8", content)
+}
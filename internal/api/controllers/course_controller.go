@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
 	"github.com/Tretorhate/university-management-system/internal/dto"
 	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,8 +26,12 @@ func (c *CourseController) Create(ctx *gin.Context) {
         return
     }
 
-    response, err := c.courseService.Create(&request)
+    response, err := c.courseService.Create(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), &request)
     if err != nil {
+        if appErr, ok := errors.IsAppError(err); ok {
+            ctx.Error(appErr)
+            return
+        }
         ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
@@ -33,7 +39,17 @@ func (c *CourseController) Create(ctx *gin.Context) {
     ctx.JSON(http.StatusCreated, response)
 }
 func (c *CourseController) GetAll(ctx *gin.Context) {
-    courses, err := c.courseService.GetAll(ctx.Query("search"))
+    var termID uint64
+    if termParam := ctx.Query("termId"); termParam != "" {
+        id, err := strconv.ParseUint(termParam, 10, 32)
+        if err != nil {
+            ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid term ID format"})
+            return
+        }
+        termID = id
+    }
+
+    courses, err := c.courseService.GetAll(ctx.Query("search"), uint(termID))
     if err != nil {
         ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
         return
@@ -71,8 +87,12 @@ func (c *CourseController) Update(ctx *gin.Context) {
         return
     }
 
-    course, err := c.courseService.Update(uint(id), &request)
+    course, err := c.courseService.Update(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
     if err != nil {
+        if appErr, ok := errors.IsAppError(err); ok {
+            ctx.Error(appErr)
+            return
+        }
         ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
@@ -87,7 +107,7 @@ func (c *CourseController) Delete(ctx *gin.Context) {
         return
     }
 
-    if err := c.courseService.Delete(uint(id)); err != nil {
+    if err := c.courseService.Delete(middleware.CurrentActor(ctx), uint(id)); err != nil {
         ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
         return
     }
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type DepartmentController struct {
+	departmentService *service.DepartmentService
+}
+
+func NewDepartmentController(departmentService *service.DepartmentService) *DepartmentController {
+	return &DepartmentController{departmentService: departmentService}
+}
+
+func (c *DepartmentController) Create(ctx *gin.Context) {
+	var request dto.DepartmentCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	department, err := c.departmentService.Create(middleware.CurrentActor(ctx), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, department)
+}
+
+func (c *DepartmentController) GetAll(ctx *gin.Context) {
+	departments, err := c.departmentService.GetAll()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, departments)
+}
+
+func (c *DepartmentController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	department, err := c.departmentService.GetByID(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, department)
+}
+
+func (c *DepartmentController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.DepartmentUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	department, err := c.departmentService.Update(middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, department)
+}
+
+func (c *DepartmentController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if err := c.departmentService.Delete(middleware.CurrentActor(ctx), uint(id)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Department deleted successfully"})
+}
+
+func (c *DepartmentController) GetTeachers(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	teachers, err := c.departmentService.GetTeachers(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, teachers)
+}
+
+func (c *DepartmentController) GetCourses(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	courses, err := c.departmentService.GetCourses(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, courses)
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentController struct {
+	documentService *service.DocumentService
+}
+
+func NewDocumentController(documentService *service.DocumentService) *DocumentController {
+	return &DocumentController{documentService: documentService}
+}
+
+func (c *DocumentController) TranscriptPDF(ctx *gin.Context) {
+	c.servePDF(ctx, "transcript", c.documentService.IssueTranscript)
+}
+
+func (c *DocumentController) EnrollmentCertificatePDF(ctx *gin.Context) {
+	c.servePDF(ctx, "enrollment-certificate", c.documentService.IssueEnrollmentCertificate)
+}
+
+func (c *DocumentController) Verify(ctx *gin.Context) {
+	verification, err := c.documentService.Verify(ctx.Param("code"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, verification)
+}
+
+func (c *DocumentController) servePDF(ctx *gin.Context, name string, issue func(studentID, issuerID uint) ([]byte, error)) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	subject := middleware.CurrentSubject(ctx)
+	if !policy.CanViewStudent(subject, uint(id)) {
+		ctx.Error(errors.Forbidden("Students can only access their own documents", nil))
+		return
+	}
+
+	content, err := issue(uint(id), subject.UserID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%d.pdf", name, id))
+	ctx.Data(200, "application/pdf", content)
+}
@@ -3,7 +3,10 @@ package controllers
 import (
 	"strconv"
 
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/domain"
 	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
 	"github.com/Tretorhate/university-management-system/internal/service"
 	"github.com/Tretorhate/university-management-system/pkg/errors"
 	"github.com/gin-gonic/gin"
@@ -24,12 +27,18 @@ func (c *EnrollmentController) Create(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.enrollmentService.Create(&request)
+	response, waitlistEntry, err := c.enrollmentService.Create(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), &request)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
+	if waitlistEntry != nil {
+		// Course is full, the student has been queued instead
+		ctx.JSON(202, waitlistEntry)
+		return
+	}
+
 	ctx.JSON(201, response)
 }
 
@@ -37,8 +46,9 @@ func (c *EnrollmentController) GetAll(ctx *gin.Context) {
 	// Check for optional query parameters
 	studentID := ctx.Query("studentId")
 	courseID := ctx.Query("courseId")
+	termID := ctx.Query("termId")
 
-	var studentIDUint, courseIDUint uint
+	var studentIDUint, courseIDUint, termIDUint uint
 	var err error
 
 	if studentID != "" {
@@ -59,6 +69,26 @@ func (c *EnrollmentController) GetAll(ctx *gin.Context) {
 		courseIDUint = uint(id)
 	}
 
+	if termID != "" {
+		id, err := strconv.ParseUint(termID, 10, 32)
+		if err != nil {
+			ctx.Error(errors.BadRequest("Invalid term ID format", err))
+			return
+		}
+		termIDUint = uint(id)
+	}
+
+	// Students can only list their own enrollments
+	subject := middleware.CurrentSubject(ctx)
+	if !policy.CanListStudents(subject) {
+		if studentID != "" && !policy.CanViewStudent(subject, studentIDUint) {
+			ctx.Error(errors.Forbidden("You can only view your own enrollments", nil))
+			return
+		}
+		studentID = strconv.FormatUint(uint64(subject.StudentID), 10)
+		studentIDUint = subject.StudentID
+	}
+
 	var enrollments []dto.EnrollmentResponseDTO
 
 	if studentID != "" && courseID != "" {
@@ -66,13 +96,13 @@ func (c *EnrollmentController) GetAll(ctx *gin.Context) {
 		enrollments, err = c.enrollmentService.GetByStudentAndCourseID(studentIDUint, courseIDUint)
 	} else if studentID != "" {
 		// Only studentID is provided
-		enrollments, err = c.enrollmentService.GetByStudentID(studentIDUint)
+		enrollments, err = c.enrollmentService.GetByStudentID(studentIDUint, termIDUint)
 	} else if courseID != "" {
 		// Only courseID is provided
 		enrollments, err = c.enrollmentService.GetByCourseID(courseIDUint)
 	} else {
-		// No filters provided, get all enrollments
-		enrollments, err = c.enrollmentService.GetAll()
+		// No student or course filter provided, get all enrollments (optionally for a term)
+		enrollments, err = c.enrollmentService.GetAll(termIDUint)
 	}
 
 	if err != nil {
@@ -90,7 +120,7 @@ func (c *EnrollmentController) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	enrollment, err := c.enrollmentService.GetByID(uint(id))
+	enrollment, err := c.enrollmentService.GetByID(middleware.CurrentSubject(ctx), uint(id))
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -112,7 +142,7 @@ func (c *EnrollmentController) Update(ctx *gin.Context) {
 		return
 	}
 
-	enrollment, err := c.enrollmentService.Update(uint(id), &request)
+	enrollment, err := c.enrollmentService.Update(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
 	if err != nil {
 		ctx.Error(err)
 		return
@@ -128,10 +158,88 @@ func (c *EnrollmentController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.enrollmentService.Delete(uint(id)); err != nil {
+	enrollment, err := c.enrollmentService.Delete(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if enrollment.Status == string(domain.EnrollmentStatusWithdrawn) {
+		ctx.JSON(200, gin.H{"message": "Add/drop deadline has passed, enrollment recorded as a withdrawal", "enrollment": enrollment})
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Enrollment dropped successfully", "enrollment": enrollment})
+}
+
+func (c *EnrollmentController) LateDrop(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.LateDropDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	enrollment, err := c.enrollmentService.LateDrop(middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Late drop approved", "enrollment": enrollment})
+}
+
+func (c *EnrollmentController) GetWaitlist(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	entries, err := c.enrollmentService.GetWaitlist(middleware.CurrentSubject(ctx), uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, entries)
+}
+
+func (c *EnrollmentController) GetWaitlistPosition(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	// Users without access to waitlists can only look up their own position
+	subject := middleware.CurrentSubject(ctx)
+	if !subject.Can(domain.PermissionWaitlistRead) && !subject.Can(domain.PermissionEnrollmentManageOwn) && !subject.Can(domain.PermissionEnrollmentManageAll) {
+		entry, err := c.enrollmentService.GetOwnWaitlistPosition(uint(courseID), ctx.GetUint("userID"))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(200, entry)
+		return
+	}
+
+	studentID, err := strconv.ParseUint(ctx.Query("studentId"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid or missing student ID", err))
+		return
+	}
+
+	entry, err := c.enrollmentService.GetWaitlistPosition(uint(courseID), uint(studentID))
+	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	ctx.JSON(200, gin.H{"message": "Enrollment deleted successfully"})
+	ctx.JSON(200, entry)
 }
@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type ExamController struct {
+	examService *service.ExamService
+}
+
+func NewExamController(examService *service.ExamService) *ExamController {
+	return &ExamController{examService: examService}
+}
+
+func (c *ExamController) Create(ctx *gin.Context) {
+	var request dto.ExamCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	exam, err := c.examService.Create(middleware.CurrentActor(ctx), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, exam)
+}
+
+func (c *ExamController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	exam, err := c.examService.GetByID(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, exam)
+}
+
+func (c *ExamController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.ExamUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	exam, err := c.examService.Update(middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, exam)
+}
+
+func (c *ExamController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if err := c.examService.Delete(middleware.CurrentActor(ctx), uint(id)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Exam deleted successfully"})
+}
+
+func (c *ExamController) GetByCourse(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	exams, err := c.examService.GetByCourse(uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, exams)
+}
+
+func (c *ExamController) GetByTerm(ctx *gin.Context) {
+	termID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid term ID format", err))
+		return
+	}
+
+	exams, err := c.examService.GetByTerm(uint(termID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, exams)
+}
+
+func (c *ExamController) Plan(ctx *gin.Context) {
+	termID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid term ID format", err))
+		return
+	}
+
+	var request dto.ExamPlanRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	result, err := c.examService.Plan(middleware.CurrentActor(ctx), uint(termID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, result)
+}
+
+func (c *ExamController) GetConflicts(ctx *gin.Context) {
+	termID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid term ID format", err))
+		return
+	}
+
+	conflicts, err := c.examService.GetConflicts(uint(termID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, conflicts)
+}
+
+func (c *ExamController) GetStudentSchedule(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if !policy.CanViewStudent(middleware.CurrentSubject(ctx), uint(id)) {
+		ctx.Error(errors.Forbidden("Students can only access their own exam schedule", nil))
+		return
+	}
+
+	schedule, err := c.examService.GetStudentSchedule(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, schedule)
+}
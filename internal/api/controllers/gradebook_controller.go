@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type GradebookController struct {
+	gradebookService *service.GradebookService
+}
+
+func NewGradebookController(gradebookService *service.GradebookService) *GradebookController {
+	return &GradebookController{gradebookService: gradebookService}
+}
+
+func (c *GradebookController) GetScheme(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	scheme, err := c.gradebookService.GetScheme(uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, scheme)
+}
+
+func (c *GradebookController) UpdateScheme(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.GradingSchemeUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	scheme, err := c.gradebookService.UpdateScheme(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, scheme)
+}
+
+func (c *GradebookController) CreateComponent(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.AssessmentComponentCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	component, err := c.gradebookService.CreateComponent(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, component)
+}
+
+func (c *GradebookController) UpdateComponent(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	componentID, err := strconv.ParseUint(ctx.Param("componentId"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid component ID format", err))
+		return
+	}
+
+	var request dto.AssessmentComponentUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	component, err := c.gradebookService.UpdateComponent(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), uint(componentID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, component)
+}
+
+func (c *GradebookController) DeleteComponent(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	componentID, err := strconv.ParseUint(ctx.Param("componentId"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid component ID format", err))
+		return
+	}
+
+	if err := c.gradebookService.DeleteComponent(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), uint(componentID)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Assessment component deleted successfully"})
+}
+
+func (c *GradebookController) RecordScores(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.ScoresUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	gradebook, err := c.gradebookService.RecordScores(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gradebook)
+}
+
+func (c *GradebookController) GetGradebook(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	gradebook, err := c.gradebookService.GetGradebook(middleware.CurrentSubject(ctx), uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gradebook)
+}
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type GradingController struct {
+	gradingService *service.GradingService
+}
+
+func NewGradingController(gradingService *service.GradingService) *GradingController {
+	return &GradingController{gradingService: gradingService}
+}
+
+func (c *GradingController) FinalizeCourse(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	course, err := c.gradingService.FinalizeCourse(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Grades finalized", "course": course})
+}
+
+func (c *GradingController) RequestChange(ctx *gin.Context) {
+	enrollmentID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.GradeChangeRequestCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	change, err := c.gradingService.RequestChange(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(enrollmentID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, change)
+}
+
+// GetRequests lists grade change requests, filterable by status
+func (c *GradingController) GetRequests(ctx *gin.Context) {
+	requests, err := c.gradingService.GetRequests(middleware.CurrentSubject(ctx), ctx.Query("status"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, requests)
+}
+
+func (c *GradingController) Approve(ctx *gin.Context) {
+	c.review(ctx, c.gradingService.Approve)
+}
+
+func (c *GradingController) Reject(ctx *gin.Context) {
+	c.review(ctx, c.gradingService.Reject)
+}
+
+// review binds the optional review comment and applies the decision
+func (c *GradingController) review(ctx *gin.Context, decide func(subject policy.Subject, actor domain.Actor, id uint, req *dto.GradeChangeReviewDTO) (*dto.GradeChangeRequestResponseDTO, error)) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.GradeChangeReviewDTO
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.Error(errors.BadRequest("Invalid request body", err))
+			return
+		}
+	}
+
+	change, err := decide(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, change)
+}
+
+func (c *GradingController) GetHistory(ctx *gin.Context) {
+	enrollmentID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	history, err := c.gradingService.GetHistory(middleware.CurrentSubject(ctx), uint(enrollmentID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, history)
+}
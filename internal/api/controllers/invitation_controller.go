@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type InvitationController struct {
+	invitationService *service.InvitationService
+}
+
+func NewInvitationController(invitationService *service.InvitationService) *InvitationController {
+	return &InvitationController{invitationService: invitationService}
+}
+
+func (c *InvitationController) Create(ctx *gin.Context) {
+	var request dto.InvitationCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.invitationService.Create(ctx.GetUint("userID"), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, response)
+}
+
+func (c *InvitationController) GetAll(ctx *gin.Context) {
+	invitations, err := c.invitationService.GetAll()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, invitations)
+}
+
+func (c *InvitationController) Revoke(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	invitation, err := c.invitationService.Revoke(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, invitation)
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type PrerequisiteController struct {
+	prerequisiteService *service.PrerequisiteService
+}
+
+func NewPrerequisiteController(prerequisiteService *service.PrerequisiteService) *PrerequisiteController {
+	return &PrerequisiteController{prerequisiteService: prerequisiteService}
+}
+
+func (c *PrerequisiteController) GetAll(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	prerequisites, err := c.prerequisiteService.GetByCourseID(uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, prerequisites)
+}
+
+func (c *PrerequisiteController) Create(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.PrerequisiteCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.prerequisiteService.Add(uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, response)
+}
+
+func (c *PrerequisiteController) Delete(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	prerequisiteID, err := strconv.ParseUint(ctx.Param("prerequisiteId"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid prerequisite ID format", err))
+		return
+	}
+
+	if err := c.prerequisiteService.Remove(uint(courseID), uint(prerequisiteID)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Prerequisite removed successfully"})
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type RoleController struct {
+	roleService *service.RoleService
+}
+
+func NewRoleController(roleService *service.RoleService) *RoleController {
+	return &RoleController{roleService: roleService}
+}
+
+func (c *RoleController) Create(ctx *gin.Context) {
+	var request dto.RoleCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	role, err := c.roleService.Create(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, role)
+}
+
+func (c *RoleController) GetAll(ctx *gin.Context) {
+	roles, err := c.roleService.GetAll()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, roles)
+}
+
+func (c *RoleController) GetByName(ctx *gin.Context) {
+	role, err := c.roleService.GetByName(ctx.Param("name"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, role)
+}
+
+func (c *RoleController) Update(ctx *gin.Context) {
+	var request dto.RoleUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	role, err := c.roleService.Update(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), ctx.Param("name"), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, role)
+}
+
+func (c *RoleController) Delete(ctx *gin.Context) {
+	if err := c.roleService.Delete(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), ctx.Param("name")); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Role deleted successfully"})
+}
+
+func (c *RoleController) GetPermissions(ctx *gin.Context) {
+	ctx.JSON(200, c.roleService.GetPermissions())
+}
+
+func (c *RoleController) GetOwnPermissions(ctx *gin.Context) {
+	ctx.JSON(200, c.roleService.GetOwnPermissions(middleware.CurrentSubject(ctx)))
+}
+
+func (c *RoleController) AssignRole(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.UserRoleUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	user, err := c.roleService.AssignRole(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, user)
+}
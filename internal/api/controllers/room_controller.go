@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// bookingWindow is the period listed when no range is given for bookings
+const bookingWindow = 30 * 24 * time.Hour
+
+type RoomController struct {
+	roomService *service.RoomService
+}
+
+func NewRoomController(roomService *service.RoomService) *RoomController {
+	return &RoomController{roomService: roomService}
+}
+
+func (c *RoomController) Create(ctx *gin.Context) {
+	var request dto.RoomCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	room, err := c.roomService.Create(middleware.CurrentActor(ctx), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, room)
+}
+
+func (c *RoomController) GetAll(ctx *gin.Context) {
+	rooms, err := c.roomService.GetAll()
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, rooms)
+}
+
+func (c *RoomController) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	room, err := c.roomService.GetByID(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, room)
+}
+
+func (c *RoomController) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.RoomUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	room, err := c.roomService.Update(middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, room)
+}
+
+func (c *RoomController) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if err := c.roomService.Delete(middleware.CurrentActor(ctx), uint(id)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Room deleted successfully"})
+}
+
+// FindAvailable lists the rooms free on date (YYYY-MM-DD) between start and
+// end (HH:MM), optionally seating capacity people and offering every feature
+// of the comma-separated features list
+func (c *RoomController) FindAvailable(ctx *gin.Context) {
+	date, err := time.ParseInLocation(time.DateOnly, ctx.Query("date"), time.Local)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid date, expected YYYY-MM-DD", err))
+		return
+	}
+
+	query := dto.RoomAvailabilityQueryDTO{
+		Date:  date,
+		Start: ctx.Query("start"),
+		End:   ctx.Query("end"),
+	}
+	if value := ctx.Query("capacity"); value != "" {
+		if query.Capacity, err = strconv.Atoi(value); err != nil || query.Capacity < 0 {
+			ctx.Error(errors.BadRequest("Invalid capacity", err))
+			return
+		}
+	}
+	if value := ctx.Query("features"); value != "" {
+		query.Features = strings.Split(value, ",")
+	}
+
+	rooms, err := c.roomService.FindAvailable(&query)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, rooms)
+}
+
+func (c *RoomController) CreateBooking(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.RoomBookingCreateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	booking, err := c.roomService.CreateBooking(middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, booking)
+}
+
+// GetBookings lists the bookings of a room between the RFC 3339 times from and
+// to, by default the next 30 days
+func (c *RoomController) GetBookings(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	from := time.Now()
+	if value := ctx.Query("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			ctx.Error(errors.BadRequest("Invalid from time, expected RFC 3339", err))
+			return
+		}
+	}
+	to := from.Add(bookingWindow)
+	if value := ctx.Query("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			ctx.Error(errors.BadRequest("Invalid to time, expected RFC 3339", err))
+			return
+		}
+	}
+
+	bookings, err := c.roomService.GetBookings(uint(id), from, to)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, bookings)
+}
+
+func (c *RoomController) CancelBooking(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if err := c.roomService.CancelBooking(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id)); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Booking cancelled successfully"})
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type SchedulerController struct {
+	schedulerService *service.SchedulerService
+}
+
+func NewSchedulerController(schedulerService *service.SchedulerService) *SchedulerController {
+	return &SchedulerController{schedulerService: schedulerService}
+}
+
+// StartJob queues the timetable generator for a term and answers right away;
+// the job is polled through GetJob
+func (c *SchedulerController) StartJob(ctx *gin.Context) {
+	termID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid term ID format", err))
+		return
+	}
+
+	var request dto.ScheduleJobCreateDTO
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.Error(errors.BadRequest("Invalid request body", err))
+			return
+		}
+	}
+
+	job, err := c.schedulerService.StartJob(middleware.CurrentActor(ctx), uint(termID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(202, job)
+}
+
+func (c *SchedulerController) GetJobs(ctx *gin.Context) {
+	termID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid term ID format", err))
+		return
+	}
+
+	jobs, err := c.schedulerService.GetJobs(uint(termID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, jobs)
+}
+
+func (c *SchedulerController) GetJob(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	job, err := c.schedulerService.GetJob(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, job)
+}
+
+func (c *SchedulerController) GetPreview(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	preview, err := c.schedulerService.GetPreview(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, preview)
+}
+
+func (c *SchedulerController) Apply(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	job, err := c.schedulerService.Apply(middleware.CurrentActor(ctx), uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, job)
+}
+
+func (c *SchedulerController) GetPreferences(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	slots, err := c.schedulerService.GetPreferences(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, slots)
+}
+
+func (c *SchedulerController) UpdatePreferences(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	var request dto.TeacherPreferencesDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	slots, err := c.schedulerService.UpdatePreferences(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(id), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, slots)
+}
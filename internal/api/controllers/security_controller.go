@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type SecurityController struct {
+	loginGuard *service.LoginGuard
+}
+
+func NewSecurityController(loginGuard *service.LoginGuard) *SecurityController {
+	return &SecurityController{loginGuard: loginGuard}
+}
+
+func (c *SecurityController) Unlock(ctx *gin.Context) {
+	var request dto.UnlockRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	if err := c.loginGuard.Unlock(ctx.GetUint("userID"), &request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Unlocked successfully"})
+}
+
+// GetEvents lists security events, filterable by type and an RFC 3339 time range
+func (c *SecurityController) GetEvents(ctx *gin.Context) {
+	var from, to *time.Time
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &from}, {"to", &to}} {
+		value := ctx.Query(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			ctx.Error(errors.BadRequest("Invalid "+param.name+" time, expected RFC 3339", err))
+			return
+		}
+		*param.target = &parsed
+	}
+
+	events, err := c.loginGuard.GetEvents(ctx.Query("type"), from, to)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, events)
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
 	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
 	"github.com/Tretorhate/university-management-system/internal/service"
 	"github.com/gin-gonic/gin"
 )
@@ -24,7 +26,7 @@ func (c *StudentController) Create(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.studentService.Create(&request)
+	response, err := c.studentService.Create(middleware.CurrentActor(ctx), &request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -34,6 +36,11 @@ func (c *StudentController) Create(ctx *gin.Context) {
 }
 
 func (c *StudentController) GetAll(ctx *gin.Context) {
+	if !policy.CanListStudents(middleware.CurrentSubject(ctx)) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient permissions"})
+		return
+	}
+
 	students, err := c.studentService.GetAll()
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -50,6 +57,11 @@ func (c *StudentController) GetByID(ctx *gin.Context) {
 		return
 	}
 
+	if !policy.CanViewStudent(middleware.CurrentSubject(ctx), uint(id)) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Students can only view their own record"})
+		return
+	}
+
 	student, err := c.studentService.GetByID(uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Student not found"})
@@ -72,7 +84,7 @@ func (c *StudentController) Update(ctx *gin.Context) {
 		return
 	}
 
-	student, err := c.studentService.Update(uint(id), &request)
+	student, err := c.studentService.Update(middleware.CurrentActor(ctx), uint(id), &request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -88,7 +100,7 @@ func (c *StudentController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.studentService.Delete(uint(id)); err != nil {
+	if err := c.studentService.Delete(middleware.CurrentActor(ctx), uint(id)); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
 	"github.com/Tretorhate/university-management-system/internal/dto"
 	"github.com/Tretorhate/university-management-system/internal/service"
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,7 @@ func (c *TeacherController) Create(ctx *gin.Context) {
 		return
 	}
 
-	response, err := c.teacherService.Create(&request)
+	response, err := c.teacherService.Create(middleware.CurrentActor(ctx), &request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -73,7 +74,7 @@ func (c *TeacherController) Update(ctx *gin.Context) {
 		return
 	}
 
-	teacher, err := c.teacherService.Update(uint(id), &request)
+	teacher, err := c.teacherService.Update(middleware.CurrentActor(ctx), uint(id), &request)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -89,7 +90,7 @@ func (c *TeacherController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.teacherService.Delete(uint(id)); err != nil {
+	if err := c.teacherService.Delete(middleware.CurrentActor(ctx), uint(id)); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type TimetableController struct {
+	timetableService *service.TimetableService
+}
+
+func NewTimetableController(timetableService *service.TimetableService) *TimetableController {
+	return &TimetableController{timetableService: timetableService}
+}
+
+func (c *TimetableController) GetMeetings(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	meetings, err := c.timetableService.GetMeetings(uint(courseID))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, meetings)
+}
+
+func (c *TimetableController) UpdateMeetings(ctx *gin.Context) {
+	courseID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid course ID format", err))
+		return
+	}
+
+	var request dto.CourseMeetingsUpdateDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	meetings, err := c.timetableService.UpdateMeetings(middleware.CurrentSubject(ctx), middleware.CurrentActor(ctx), uint(courseID), &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, meetings)
+}
+
+func (c *TimetableController) GetStudentTimetable(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+	termID, err := termQuery(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	if !policy.CanViewStudent(middleware.CurrentSubject(ctx), uint(id)) {
+		ctx.Error(errors.Forbidden("Students can only access their own timetable", nil))
+		return
+	}
+
+	timetable, err := c.timetableService.GetStudentTimetable(uint(id), termID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, timetable)
+}
+
+func (c *TimetableController) GetTeacherTimetable(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+	termID, err := termQuery(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	timetable, err := c.timetableService.GetTeacherTimetable(uint(id), termID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, timetable)
+}
+
+// termQuery reads the optional termId query parameter, 0 when absent
+func termQuery(ctx *gin.Context) (uint, error) {
+	termID := ctx.Query("termId")
+	if termID == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(termID, 10, 32)
+	if err != nil {
+		return 0, errors.BadRequest("Invalid term ID format", err)
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"strconv"
+
+	"github.com/Tretorhate/university-management-system/internal/api/middleware"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+type TranscriptController struct {
+	transcriptService *service.TranscriptService
+}
+
+func NewTranscriptController(transcriptService *service.TranscriptService) *TranscriptController {
+	return &TranscriptController{transcriptService: transcriptService}
+}
+
+func (c *TranscriptController) GetTranscript(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.Error(errors.BadRequest("Invalid ID format", err))
+		return
+	}
+
+	if !policy.CanViewStudent(middleware.CurrentSubject(ctx), uint(id)) {
+		ctx.Error(errors.Forbidden("Students can only access their own transcript", nil))
+		return
+	}
+
+	transcript, err := c.transcriptService.GetTranscript(uint(id))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, transcript)
+}
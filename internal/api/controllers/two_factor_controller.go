@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/service"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// TwoFactorController lets a logged in user manage their own two-factor authentication
+type TwoFactorController struct {
+	twoFactorService *service.TwoFactorService
+}
+
+func NewTwoFactorController(twoFactorService *service.TwoFactorService) *TwoFactorController {
+	return &TwoFactorController{twoFactorService: twoFactorService}
+}
+
+func (c *TwoFactorController) Setup(ctx *gin.Context) {
+	response, err := c.twoFactorService.Setup(ctx.GetUint("userID"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *TwoFactorController) Activate(ctx *gin.Context) {
+	var request dto.TwoFactorActivateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	response, err := c.twoFactorService.Activate(ctx.GetUint("userID"), request.Code)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, response)
+}
+
+func (c *TwoFactorController) Disable(ctx *gin.Context) {
+	var request dto.TwoFactorDisableRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.Error(errors.BadRequest("Invalid request body", err))
+		return
+	}
+
+	if err := c.twoFactorService.Disable(ctx.GetUint("userID"), ctx.ClientIP(), &request); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, gin.H{"message": "Two-factor authentication disabled"})
+}
@@ -19,9 +19,13 @@ func ErrorHandler() gin.HandlerFunc {
 			
 			// Check if it's an AppError wow
 			if appErr, ok := errors.IsAppError(err); ok {
-				c.JSON(appErr.Code, gin.H{
+				response := gin.H{
 					"error": appErr.Message,
-				})
+				}
+				if appErr.Details != nil {
+					response["details"] = appErr.Details
+				}
+				c.JSON(appErr.Code, response)
 				return
 			}
 
@@ -5,16 +5,28 @@ import (
 	"strings"
 
 	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/policy"
 	"github.com/Tretorhate/university-management-system/pkg/auth"
 	"github.com/gin-gonic/gin"
 )
 
+// SessionChecker reports whether the session an access token was issued for is still active
+type SessionChecker interface {
+	IsActive(sessionID string, userID uint) (bool, error)
+}
+
 type AuthMiddleware struct {
-	jwtService *auth.JWTService
+	jwtService      *auth.JWTService
+	sessions        SessionChecker
+	subjectResolver *policy.Resolver
 }
 
-func NewAuthMiddleware(jwtService *auth.JWTService) *AuthMiddleware {
-	return &AuthMiddleware{jwtService: jwtService}
+func NewAuthMiddleware(jwtService *auth.JWTService, sessions SessionChecker, subjectResolver *policy.Resolver) *AuthMiddleware {
+	return &AuthMiddleware{
+		jwtService:      jwtService,
+		sessions:        sessions,
+		subjectResolver: subjectResolver,
+	}
 }
 
 func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
@@ -37,37 +49,52 @@ func (m *AuthMiddleware) AuthRequired() gin.HandlerFunc {
 			return
 		}
 
+		// Reject tokens of sessions that were logged out or revoked
+		active, err := m.sessions.IsActive(claims.SessionID, claims.UserID)
+		if err != nil || !active {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Session has expired or was revoked"})
+			return
+		}
+
 		// Set user information in context
 		c.Set("userID", claims.UserID)
 		c.Set("userEmail", claims.Email)
 		c.Set("userRole", claims.Role)
+		c.Set("sessionID", claims.SessionID)
+
+		// Resolve the permissions of the role and the linked student or teacher
+		// profile for ownership checks. Permissions are looked up on every request
+		// so role changes apply without reissuing tokens.
+		c.Set("subject", m.subjectResolver.Resolve(claims.UserID, claims.Role))
 
 		c.Next()
 	}
 }
 
-func (m *AuthMiddleware) RoleRequired(roles ...domain.Role) gin.HandlerFunc {
+// RequirePermission allows the request if the user's role grants any of the
+// permissions. Ownership is checked by the policies in the services.
+func (m *AuthMiddleware) RequirePermission(permissions ...domain.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := c.Get("userRole")
-		if !exists {
+		if _, exists := c.Get("subject"); !exists {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 			return
 		}
 
-		role := userRole.(domain.Role)
-		authorized := false
-		for _, r := range roles {
-			if role == r {
-				authorized = true
-				break
+		subject := CurrentSubject(c)
+		for _, permission := range permissions {
+			if subject.Can(permission) {
+				c.Next()
+				return
 			}
 		}
 
-		if !authorized {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient permissions"})
-			return
-		}
-
-		c.Next()
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Forbidden: insufficient permissions"})
 	}
 }
+
+// CurrentSubject returns the policy subject of the authenticated user
+func CurrentSubject(c *gin.Context) policy.Subject {
+	subject, _ := c.Get("subject")
+	s, _ := subject.(policy.Subject)
+	return s
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/gin-gonic/gin"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID tags every request with an ID, taken from the X-Request-ID header
+// when a proxy already set a sensible one, and echoes it in the response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Set("requestID", requestID)
+		c.Header(requestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// CurrentActor describes who is making the request for the audit log. On
+// public routes the user is unknown and UserID is zero.
+func CurrentActor(c *gin.Context) domain.Actor {
+	actor := domain.Actor{
+		UserID:    c.GetUint("userID"),
+		RequestID: c.GetString("requestID"),
+		IP:        c.ClientIP(),
+	}
+	if role, ok := c.Get("userRole"); ok {
+		actor.Role, _ = role.(domain.Role)
+	}
+	return actor
+}
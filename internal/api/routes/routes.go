@@ -14,60 +14,307 @@ func SetupRoutes(
 	studentController *controllers.StudentController,
 	teacherController *controllers.TeacherController,
 	courseController *controllers.CourseController,
+	prerequisiteController *controllers.PrerequisiteController,
 	enrollmentController *controllers.EnrollmentController,
+	termController *controllers.TermController,
+	transcriptController *controllers.TranscriptController,
+	documentController *controllers.DocumentController,
+	invitationController *controllers.InvitationController,
+	accountController *controllers.AccountController,
+	twoFactorController *controllers.TwoFactorController,
+	securityController *controllers.SecurityController,
+	roleController *controllers.RoleController,
+	auditController *controllers.AuditController,
+	gradingController *controllers.GradingController,
+	gradebookController *controllers.GradebookController,
+	assignmentController *controllers.AssignmentController,
+	attendanceController *controllers.AttendanceController,
+	timetableController *controllers.TimetableController,
+	calendarController *controllers.CalendarController,
+	roomController *controllers.RoomController,
+	schedulerController *controllers.SchedulerController,
+	examController *controllers.ExamController,
+	programController *controllers.ProgramController,
+	departmentController *controllers.DepartmentController,
 ) {
 	// Global middleware
+	r.Use(middleware.RequestID())
 	r.Use(middleware.ErrorHandler())
 
 	// Public routes
 	authRoutes := r.Group("/auth")
 	{
 		authRoutes.POST("/register", authController.Register)
+		authRoutes.POST("/register/invite", authController.RegisterWithInvitation)
 		authRoutes.POST("/login", authController.Login)
+		authRoutes.POST("/2fa/verify", authController.VerifyTwoFactor)
+		authRoutes.POST("/2fa/setup", authController.SetupTwoFactor)
+		authRoutes.POST("/2fa/activate", authController.ActivateTwoFactor)
+		authRoutes.POST("/refresh", authController.Refresh)
+		authRoutes.POST("/logout", authController.Logout)
+		authRoutes.POST("/forgot-password", accountController.ForgotPassword)
+		authRoutes.POST("/reset-password", accountController.ResetPassword)
+		authRoutes.GET("/verify-email", accountController.VerifyEmail)
+		authRoutes.POST("/verify-email", accountController.VerifyEmail)
+		authRoutes.POST("/verify-email/resend", accountController.ResendVerification)
 	}
 
+	// Public verification of issued documents
+	r.GET("/verify/:code", documentController.Verify)
+
+	// Calendar subscriptions, authenticated by the token in the URL
+	r.GET("/calendar/:token/feed.ics", calendarController.Feed)
+
 	// Protected routes
 	api := r.Group("/api")
 	api.Use(authMiddleware.AuthRequired())
 	{
+		// Current user routes
+		me := api.Group("/me")
+		{
+			me.PUT("/password", authController.ChangePassword)
+			me.POST("/2fa/setup", twoFactorController.Setup)
+			me.POST("/2fa/activate", twoFactorController.Activate)
+			me.POST("/2fa/disable", twoFactorController.Disable)
+			me.GET("/permissions", roleController.GetOwnPermissions)
+			me.GET("/calendar-feed", calendarController.GetFeed)
+			me.POST("/calendar-feed", calendarController.CreateFeed)
+			me.DELETE("/calendar-feed", calendarController.RevokeFeed)
+		}
+
+		// Roles routes
+		roles := api.Group("/roles")
+		roles.Use(authMiddleware.RequirePermission(domain.PermissionRoleManage))
+		{
+			roles.POST("", roleController.Create)
+			roles.GET("", roleController.GetAll)
+			roles.GET("/:name", roleController.GetByName)
+			roles.PUT("/:name", roleController.Update)
+			roles.DELETE("/:name", roleController.Delete)
+		}
+		api.GET("/permissions", authMiddleware.RequirePermission(domain.PermissionRoleManage), roleController.GetPermissions)
+
+		// User role assignment
+		api.PUT("/users/:id/role", authMiddleware.RequirePermission(domain.PermissionUserAssignRole), roleController.AssignRole)
+
+		// Audit log
+		api.GET("/audit", authMiddleware.RequirePermission(domain.PermissionAuditRead), auditController.GetAll)
+
+		// Invitations routes
+		invitations := api.Group("/invitations")
+		invitations.Use(authMiddleware.RequirePermission(domain.PermissionInvitationManage))
+		{
+			invitations.POST("", invitationController.Create)
+			invitations.GET("", invitationController.GetAll)
+			invitations.DELETE("/:id", invitationController.Revoke)
+		}
+
+		// Security routes
+		security := api.Group("/security")
+		security.Use(authMiddleware.RequirePermission(domain.PermissionSecurityManage))
+		{
+			security.POST("/unlock", securityController.Unlock)
+			security.GET("/events", securityController.GetEvents)
+		}
+
 		// Students routes
 		students := api.Group("/students")
 		{
-			students.POST("", authMiddleware.RoleRequired(domain.RoleAdmin), studentController.Create)
+			students.POST("", authMiddleware.RequirePermission(domain.PermissionStudentCreate), studentController.Create)
 			students.GET("", studentController.GetAll)
 			students.GET("/:id", studentController.GetByID)
-			students.PUT("/:id", authMiddleware.RoleRequired(domain.RoleAdmin), studentController.Update)
-			students.DELETE("/:id", authMiddleware.RoleRequired(domain.RoleAdmin), studentController.Delete)
+			students.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionStudentUpdate), studentController.Update)
+			students.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionStudentDelete), studentController.Delete)
+			students.GET("/:id/transcript", transcriptController.GetTranscript)
+			students.GET("/:id/transcript.pdf", documentController.TranscriptPDF)
+			students.GET("/:id/enrollment-certificate.pdf", documentController.EnrollmentCertificatePDF)
+			students.GET("/:id/timetable", timetableController.GetStudentTimetable)
+			students.GET("/:id/exams", examController.GetStudentSchedule)
+			students.PUT("/:id/program", authMiddleware.RequirePermission(domain.PermissionStudentUpdate), programController.AssignStudent)
+			students.GET("/:id/degree-audit", programController.GetDegreeAudit)
 		}
 
 		// Teachers routes
 		teachers := api.Group("/teachers")
 		{
-			teachers.POST("", authMiddleware.RoleRequired(domain.RoleAdmin), teacherController.Create)
+			teachers.POST("", authMiddleware.RequirePermission(domain.PermissionTeacherCreate), teacherController.Create)
 			teachers.GET("", teacherController.GetAll)
 			teachers.GET("/:id", teacherController.GetByID)
-			teachers.PUT("/:id", authMiddleware.RoleRequired(domain.RoleAdmin), teacherController.Update)
-			teachers.DELETE("/:id", authMiddleware.RoleRequired(domain.RoleAdmin), teacherController.Delete)
+			teachers.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionTeacherUpdate), teacherController.Update)
+			teachers.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionTeacherDelete), teacherController.Delete)
+			teachers.GET("/:id/timetable", timetableController.GetTeacherTimetable)
+			teachers.GET("/:id/preferred-slots", schedulerController.GetPreferences)
+			teachers.PUT("/:id/preferred-slots", schedulerController.UpdatePreferences)
+		}
+
+		// Departments routes
+		departments := api.Group("/departments")
+		{
+			departments.POST("", authMiddleware.RequirePermission(domain.PermissionDepartmentManage), departmentController.Create)
+			departments.GET("", departmentController.GetAll)
+			departments.GET("/:id", departmentController.GetByID)
+			departments.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionDepartmentManage), departmentController.Update)
+			departments.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionDepartmentManage), departmentController.Delete)
+			departments.GET("/:id/teachers", departmentController.GetTeachers)
+			departments.GET("/:id/courses", departmentController.GetCourses)
+		}
+
+		// Degree programs routes
+		programs := api.Group("/programs")
+		{
+			programs.POST("", authMiddleware.RequirePermission(domain.PermissionProgramManage), programController.Create)
+			programs.GET("", programController.GetAll)
+			programs.GET("/:id", programController.GetByID)
+			programs.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionProgramManage), programController.Update)
+			programs.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionProgramManage), programController.Delete)
+		}
+
+		// Terms routes
+		terms := api.Group("/terms")
+		{
+			terms.POST("", authMiddleware.RequirePermission(domain.PermissionTermManage), termController.Create)
+			terms.GET("", termController.GetAll)
+			terms.GET("/:id", termController.GetByID)
+			terms.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionTermManage), termController.Update)
+			terms.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionTermManage), termController.Delete)
+			terms.POST("/:id/match-courses", authMiddleware.RequirePermission(domain.PermissionTermManage), termController.MatchCourses)
+			terms.GET("/:id/schedule-jobs", authMiddleware.RequirePermission(domain.PermissionScheduleGenerate), schedulerController.GetJobs)
+			terms.POST("/:id/schedule-jobs", authMiddleware.RequirePermission(domain.PermissionScheduleGenerate), schedulerController.StartJob)
+			terms.GET("/:id/exams", examController.GetByTerm)
+			terms.POST("/:id/exam-plan", authMiddleware.RequirePermission(domain.PermissionExamManage), examController.Plan)
+			terms.GET("/:id/exam-conflicts", authMiddleware.RequirePermission(domain.PermissionExamManage), examController.GetConflicts)
 		}
 
 		// Courses routes
 		courses := api.Group("/courses")
 		{
-			courses.POST("", authMiddleware.RoleRequired(domain.RoleAdmin, domain.RoleTeacher), courseController.Create)
+			courses.POST("", authMiddleware.RequirePermission(domain.PermissionCourseCreate, domain.PermissionCourseUpdateAll), courseController.Create)
 			courses.GET("", courseController.GetAll)
 			courses.GET("/:id", courseController.GetByID)
-			courses.PUT("/:id", authMiddleware.RoleRequired(domain.RoleAdmin, domain.RoleTeacher), courseController.Update)
-			courses.DELETE("/:id", authMiddleware.RoleRequired(domain.RoleAdmin), courseController.Delete)
+			courses.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionCourseUpdateOwn, domain.PermissionCourseUpdateAll), courseController.Update)
+			courses.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionCourseDelete), courseController.Delete)
+
+			// Course prerequisites
+			courses.GET("/:id/prerequisites", prerequisiteController.GetAll)
+			courses.POST("/:id/prerequisites", authMiddleware.RequirePermission(domain.PermissionPrerequisiteManage), prerequisiteController.Create)
+			courses.DELETE("/:id/prerequisites/:prerequisiteId", authMiddleware.RequirePermission(domain.PermissionPrerequisiteManage), prerequisiteController.Delete)
+
+			// Course waitlist
+			courses.GET("/:id/waitlist", authMiddleware.RequirePermission(domain.PermissionWaitlistRead, domain.PermissionEnrollmentManageOwn, domain.PermissionEnrollmentManageAll), enrollmentController.GetWaitlist)
+			courses.GET("/:id/waitlist/position", enrollmentController.GetWaitlistPosition)
+
+			// Course grades
+			courses.POST("/:id/finalize-grades", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradingController.FinalizeCourse)
+
+			// Course assessments and gradebook
+			courses.GET("/:id/grading-scheme", gradebookController.GetScheme)
+			courses.PUT("/:id/grading-scheme", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradebookController.UpdateScheme)
+			courses.POST("/:id/components", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradebookController.CreateComponent)
+			courses.PUT("/:id/components/:componentId", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradebookController.UpdateComponent)
+			courses.DELETE("/:id/components/:componentId", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradebookController.DeleteComponent)
+			courses.PUT("/:id/scores", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradebookController.RecordScores)
+			courses.GET("/:id/gradebook", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradebookController.GetGradebook)
+
+			// Course assignments
+			courses.GET("/:id/assignments", assignmentController.GetByCourse)
+			courses.POST("/:id/assignments", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), assignmentController.Create)
+
+			// Weekly meeting pattern
+			courses.GET("/:id/meetings", timetableController.GetMeetings)
+			courses.PUT("/:id/meetings", authMiddleware.RequirePermission(domain.PermissionCourseUpdateOwn, domain.PermissionCourseUpdateAll), timetableController.UpdateMeetings)
+
+			// Course exams
+			courses.GET("/:id/exams", examController.GetByCourse)
+
+			// Class sessions and attendance
+			courses.GET("/:id/sessions", attendanceController.GetSessions)
+			courses.POST("/:id/sessions", authMiddleware.RequirePermission(domain.PermissionAttendanceWrite, domain.PermissionAttendanceWriteAll), attendanceController.CreateSession)
+			courses.GET("/:id/attendance", authMiddleware.RequirePermission(domain.PermissionAttendanceWrite, domain.PermissionAttendanceWriteAll), attendanceController.GetCourseAttendance)
 		}
 
 		// Enrollments routes
 		enrollments := api.Group("/enrollments")
 		{
-			enrollments.POST("", authMiddleware.RoleRequired(domain.RoleAdmin, domain.RoleTeacher), enrollmentController.Create)
+			enrollments.POST("", authMiddleware.RequirePermission(domain.PermissionEnrollmentManageOwn, domain.PermissionEnrollmentManageAll), enrollmentController.Create)
 			enrollments.GET("", enrollmentController.GetAll)
 			enrollments.GET("/:id", enrollmentController.GetByID)
-			enrollments.PUT("/:id", authMiddleware.RoleRequired(domain.RoleAdmin, domain.RoleTeacher), enrollmentController.Update)
-			enrollments.DELETE("/:id", authMiddleware.RoleRequired(domain.RoleAdmin, domain.RoleTeacher), enrollmentController.Delete)
+			enrollments.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), enrollmentController.Update)
+			enrollments.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionEnrollmentManageOwn, domain.PermissionEnrollmentManageAll), enrollmentController.Delete)
+			enrollments.POST("/:id/late-drop", authMiddleware.RequirePermission(domain.PermissionEnrollmentLateDrop), enrollmentController.LateDrop)
+			enrollments.GET("/:id/grade-history", gradingController.GetHistory)
+			enrollments.POST("/:id/grade-change-requests", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), gradingController.RequestChange)
+			enrollments.GET("/:id/attendance", attendanceController.GetEnrollmentAttendance)
+		}
+
+		// Assignments routes
+		assignments := api.Group("/assignments")
+		{
+			assignments.GET("/:id", assignmentController.GetByID)
+			assignments.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), assignmentController.Update)
+			assignments.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), assignmentController.Delete)
+			assignments.POST("/:id/submissions", assignmentController.Submit)
+			assignments.GET("/:id/submissions", assignmentController.GetSubmissions)
+		}
+
+		// Submissions routes
+		submissions := api.Group("/submissions")
+		{
+			submissions.GET("/:id", assignmentController.GetSubmission)
+			submissions.GET("/:id/file", assignmentController.DownloadSubmission)
+			submissions.PUT("/:id/grade", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll), assignmentController.Grade)
+		}
+
+		// Class sessions routes
+		sessions := api.Group("/sessions")
+		{
+			sessions.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionAttendanceWrite, domain.PermissionAttendanceWriteAll), attendanceController.UpdateSession)
+			sessions.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionAttendanceWrite, domain.PermissionAttendanceWriteAll), attendanceController.DeleteSession)
+			sessions.GET("/:id/attendance", authMiddleware.RequirePermission(domain.PermissionAttendanceWrite, domain.PermissionAttendanceWriteAll), attendanceController.GetSessionAttendance)
+			sessions.PUT("/:id/attendance", authMiddleware.RequirePermission(domain.PermissionAttendanceWrite, domain.PermissionAttendanceWriteAll), attendanceController.MarkAttendance)
+		}
+
+		// Rooms routes
+		rooms := api.Group("/rooms")
+		{
+			rooms.GET("", roomController.GetAll)
+			rooms.POST("", authMiddleware.RequirePermission(domain.PermissionRoomManage), roomController.Create)
+			rooms.GET("/available", roomController.FindAvailable)
+			rooms.GET("/:id", roomController.GetByID)
+			rooms.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionRoomManage), roomController.Update)
+			rooms.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionRoomManage), roomController.Delete)
+			rooms.GET("/:id/bookings", roomController.GetBookings)
+			rooms.POST("/:id/bookings", authMiddleware.RequirePermission(domain.PermissionRoomBook, domain.PermissionRoomManage), roomController.CreateBooking)
+		}
+
+		// Room bookings routes
+		roomBookings := api.Group("/room-bookings")
+		{
+			roomBookings.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionRoomBook, domain.PermissionRoomManage), roomController.CancelBooking)
+		}
+
+		// Timetable generator jobs routes
+		scheduleJobs := api.Group("/schedule-jobs")
+		{
+			scheduleJobs.GET("/:id", authMiddleware.RequirePermission(domain.PermissionScheduleGenerate), schedulerController.GetJob)
+			scheduleJobs.GET("/:id/preview", authMiddleware.RequirePermission(domain.PermissionScheduleGenerate), schedulerController.GetPreview)
+			scheduleJobs.POST("/:id/apply", authMiddleware.RequirePermission(domain.PermissionScheduleGenerate), schedulerController.Apply)
+		}
+
+		// Exams routes
+		exams := api.Group("/exams")
+		{
+			exams.POST("", authMiddleware.RequirePermission(domain.PermissionExamManage), examController.Create)
+			exams.GET("/:id", examController.GetByID)
+			exams.PUT("/:id", authMiddleware.RequirePermission(domain.PermissionExamManage), examController.Update)
+			exams.DELETE("/:id", authMiddleware.RequirePermission(domain.PermissionExamManage), examController.Delete)
+		}
+
+		// Grade change requests routes
+		gradeChanges := api.Group("/grade-change-requests")
+		{
+			gradeChanges.GET("", authMiddleware.RequirePermission(domain.PermissionGradeWrite, domain.PermissionGradeWriteAll, domain.PermissionGradeApproveChange, domain.PermissionGradeApproveChangeAll), gradingController.GetRequests)
+			gradeChanges.POST("/:id/approve", authMiddleware.RequirePermission(domain.PermissionGradeApproveChange, domain.PermissionGradeApproveChangeAll), gradingController.Approve)
+			gradeChanges.POST("/:id/reject", authMiddleware.RequirePermission(domain.PermissionGradeApproveChange, domain.PermissionGradeApproveChangeAll), gradingController.Reject)
 		}
 	}
 }
@@ -7,13 +7,53 @@ import (
 )
 
 type Config struct {
-	DBHost     string `mapstructure:"DB_HOST"`
-	DBPort     string `mapstructure:"DB_PORT"`
-	DBUser     string `mapstructure:"DB_USER"`
-	DBPassword string `mapstructure:"DB_PASSWORD"`
-	DBName     string `mapstructure:"DB_NAME"`
-	ServerPort string `mapstructure:"SERVER_PORT"`
-	JWTSecret  string `mapstructure:"JWT_SECRET"`
+	DBHost          string `mapstructure:"DB_HOST"`
+	DBPort          string `mapstructure:"DB_PORT"`
+	DBUser          string `mapstructure:"DB_USER"`
+	DBPassword      string `mapstructure:"DB_PASSWORD"`
+	DBName          string `mapstructure:"DB_NAME"`
+	ServerPort      string `mapstructure:"SERVER_PORT"`
+	TrustedProxies  string `mapstructure:"TRUSTED_PROXIES"` // Comma separated proxy IPs or CIDRs allowed to set X-Forwarded-For, none by default
+	JWTSecret       string `mapstructure:"JWT_SECRET"`
+	AppBaseURL      string `mapstructure:"APP_BASE_URL"` // Public URL of the API, used in generated links
+	GradeScale      string `mapstructure:"GRADE_SCALE"`  // Optional, e.g. "A:90:4.0,B:80:3.0,C:70:2.0,D:60:1.0,F:0:0"
+	AdminEmail      string `mapstructure:"ADMIN_EMAIL"`  // Initial admin, created only when no admin exists
+	AdminPassword   string `mapstructure:"ADMIN_PASSWORD"`
+	AccessTokenTTL  string `mapstructure:"ACCESS_TOKEN_TTL"`  // Go duration, defaults to 15m
+	RefreshTokenTTL string `mapstructure:"REFRESH_TOKEN_TTL"` // Go duration, defaults to 720h
+
+	// Outgoing email. MAIL_DRIVER is "smtp", "file" (writes .eml files to MAIL_DIR) or "log" (default)
+	MailDriver   string `mapstructure:"MAIL_DRIVER"`
+	MailFrom     string `mapstructure:"MAIL_FROM"`
+	MailDir      string `mapstructure:"MAIL_DIR"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     string `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+
+	RequireEmailVerification bool `mapstructure:"REQUIRE_EMAIL_VERIFICATION"` // Block login until the email is confirmed
+
+	// Two-factor authentication. TWO_FACTOR_REQUIRED_ROLES is a comma separated list, e.g. "ADMIN,TEACHER"
+	TOTPIssuer             string `mapstructure:"TOTP_ISSUER"`
+	TwoFactorRequiredRoles string `mapstructure:"TWO_FACTOR_REQUIRED_ROLES"`
+
+	// Failed login throttling. LOGIN_THROTTLE_STORE is "memory" (default, single instance) or "postgres"
+	LoginThrottleStore   string `mapstructure:"LOGIN_THROTTLE_STORE"`
+	LoginMaxFailures     int    `mapstructure:"LOGIN_MAX_FAILURES"`     // Failures before an account is locked, defaults to 10
+	LoginLockoutDuration string `mapstructure:"LOGIN_LOCKOUT_DURATION"` // Go duration, defaults to 15m
+
+	// Uploaded files. STORAGE_DRIVER is "local" (default), which keeps files in STORAGE_DIR
+	StorageDriver   string `mapstructure:"STORAGE_DRIVER"`
+	StorageDir      string `mapstructure:"STORAGE_DIR"`
+	UploadMaxSizeMB int    `mapstructure:"UPLOAD_MAX_SIZE_MB"` // Largest accepted upload, defaults to 20
+
+	// Absences per course after which the student and the teacher are alerted, defaults to 3. A negative value disables alerts
+	AttendanceAbsenceLimit int `mapstructure:"ATTENDANCE_ABSENCE_LIMIT"`
+
+	ScheduleJobTimeout string `mapstructure:"SCHEDULE_JOB_TIMEOUT"` // Go duration a timetable generator job may run, defaults to 10m
+
+	// Exams a student may sit on one day when planning an exam period, defaults to 2. A negative value removes the limit
+	ExamMaxPerDay int `mapstructure:"EXAM_MAX_PER_DAY"`
 }
 
 func LoadConfig() (config Config, err error) {
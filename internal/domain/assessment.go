@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+)
+
+// AssessmentComponent is a weighted part of a course grade, such as a midterm
+// or a series of labs. A component has one or more equally weighted items.
+type AssessmentComponent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CourseID   uint      `gorm:"not null" json:"courseId"`
+	Name       string    `gorm:"not null" json:"name"`
+	Weight     float64   `gorm:"type:double precision;not null" json:"weight"` // Percent of the course grade
+	MaxScore   float64   `gorm:"type:double precision;not null;default:100" json:"maxScore"`
+	ItemCount  int       `gorm:"not null;default:1" json:"itemCount"`
+	DropLowest int       `gorm:"not null;default:0" json:"dropLowest"` // Lowest item scores that do not count
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// AssessmentScore is the score of one student on one item of a component
+type AssessmentScore struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ComponentID  uint      `gorm:"not null" json:"componentId"`
+	EnrollmentID uint      `gorm:"not null" json:"enrollmentId"`
+	Item         int       `gorm:"not null" json:"item"` // 1-based item number within the component
+	Score        float64   `gorm:"type:double precision;not null" json:"score"`
+	GradedBy     uint      `gorm:"not null" json:"gradedBy"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
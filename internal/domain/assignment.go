@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LatePolicy string
+
+const (
+	LatePolicyReject  LatePolicy = "REJECT"  // No submissions after the due date
+	LatePolicyAccept  LatePolicy = "ACCEPT"  // Late submissions without penalty
+	LatePolicyPenalty LatePolicy = "PENALTY" // LatePenaltyPercent deducted per started day late
+)
+
+type Assignment struct {
+	ID                 uint           `gorm:"primaryKey" json:"id"`
+	CourseID           uint           `gorm:"not null" json:"courseId"`
+	Course             Course         `gorm:"foreignKey:CourseID" json:"-"`
+	Title              string         `gorm:"not null" json:"title"`
+	Description        string         `gorm:"type:text" json:"description"`
+	DueAt              time.Time      `gorm:"not null" json:"dueAt"`
+	MaxPoints          float64        `gorm:"type:double precision;not null" json:"maxPoints"`
+	LatePolicy         LatePolicy     `gorm:"type:varchar(10);not null;default:REJECT" json:"latePolicy"`
+	LatePenaltyPercent float64        `gorm:"type:double precision;not null;default:0" json:"latePenaltyPercent"`
+	MaxLateDays        int            `gorm:"not null;default:0" json:"maxLateDays"` // 0 means no limit
+	CreatedBy          uint           `gorm:"not null" json:"createdBy"`
+	CreatedAt          time.Time      `json:"createdAt"`
+	UpdatedAt          time.Time      `json:"updatedAt"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// AcceptsLate reports whether a submission the given number of days late is accepted
+func (a *Assignment) AcceptsLate(daysLate int) bool {
+	if daysLate <= 0 {
+		return true
+	}
+	if a.LatePolicy == LatePolicyReject {
+		return false
+	}
+	return a.MaxLateDays == 0 || daysLate <= a.MaxLateDays
+}
+
+// Submission is the file a student handed in for an assignment. Resubmitting
+// before grading replaces the file.
+type Submission struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	AssignmentID   uint       `gorm:"not null" json:"assignmentId"`
+	Assignment     Assignment `gorm:"foreignKey:AssignmentID" json:"-"`
+	StudentID      uint       `gorm:"not null" json:"studentId"`
+	Student        Student    `gorm:"foreignKey:StudentID" json:"-"`
+	FileName       string     `gorm:"not null" json:"fileName"`
+	ContentType    string     `gorm:"not null" json:"contentType"`
+	Size           int64      `gorm:"not null" json:"size"`
+	StorageKey     string     `gorm:"not null" json:"-"`
+	SubmittedAt    time.Time  `gorm:"not null" json:"submittedAt"`
+	DaysLate       int        `gorm:"not null;default:0" json:"daysLate"`
+	PenaltyPercent float64    `gorm:"type:double precision;not null;default:0" json:"penaltyPercent"`
+	Score          *float64   `gorm:"type:double precision" json:"score"`      // Points awarded by the grader
+	FinalScore     *float64   `gorm:"type:double precision" json:"finalScore"` // Score after the late penalty
+	Feedback       string     `gorm:"type:text" json:"feedback"`
+	GradedBy       *uint      `json:"gradedBy"`
+	GradedAt       *time.Time `json:"gradedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+)
+
+// ClassSession is a single meeting of a course
+type ClassSession struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CourseID  uint      `gorm:"not null" json:"courseId"`
+	Course    Course    `gorm:"foreignKey:CourseID" json:"-"`
+	StartsAt  time.Time `gorm:"not null" json:"startsAt"`
+	EndsAt    time.Time `gorm:"not null" json:"endsAt"`
+	Room      string    `json:"room"`
+	Topic     string    `json:"topic"`
+	CreatedBy uint      `gorm:"not null" json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type AttendanceStatus string
+
+const (
+	AttendancePresent AttendanceStatus = "PRESENT"
+	AttendanceAbsent  AttendanceStatus = "ABSENT"
+	AttendanceLate    AttendanceStatus = "LATE"    // Counts as attended
+	AttendanceExcused AttendanceStatus = "EXCUSED" // Does not count towards the attendance percentage
+)
+
+// AttendanceRecord is the attendance of an enrolled student at a session
+type AttendanceRecord struct {
+	ID           uint             `gorm:"primaryKey" json:"id"`
+	SessionID    uint             `gorm:"not null" json:"sessionId"`
+	Session      ClassSession     `gorm:"foreignKey:SessionID" json:"-"`
+	EnrollmentID uint             `gorm:"not null" json:"enrollmentId"`
+	Status       AttendanceStatus `gorm:"type:varchar(10);not null" json:"status"`
+	Note         string           `json:"note"`
+	MarkedBy     uint             `gorm:"not null" json:"markedBy"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	UpdatedAt    time.Time        `json:"updatedAt"`
+}
+
+// AttendanceAlert records that an enrollment crossed the absence limit, so the
+// alert is only sent once
+type AttendanceAlert struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	EnrollmentID uint      `gorm:"not null;unique" json:"enrollmentId"`
+	Absences     int       `gorm:"not null" json:"absences"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
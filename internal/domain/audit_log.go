@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate         AuditAction = "CREATE"
+	AuditActionUpdate         AuditAction = "UPDATE"
+	AuditActionDelete         AuditAction = "DELETE"
+	AuditActionPasswordChange AuditAction = "PASSWORD_CHANGE" // Passwords are never written to the log
+	AuditActionPasswordReset  AuditAction = "PASSWORD_RESET"
+)
+
+type AuditEntityType string
+
+const (
+	AuditEntityUser         AuditEntityType = "USER"
+	AuditEntityStudent      AuditEntityType = "STUDENT"
+	AuditEntityTeacher      AuditEntityType = "TEACHER"
+	AuditEntityCourse       AuditEntityType = "COURSE"
+	AuditEntityTerm         AuditEntityType = "TERM"
+	AuditEntityMeetings     AuditEntityType = "COURSE_MEETINGS"
+	AuditEntityEnrollment   AuditEntityType = "ENROLLMENT"
+	AuditEntityWaitlist     AuditEntityType = "WAITLIST_ENTRY"
+	AuditEntityGradeChange  AuditEntityType = "GRADE_CHANGE_REQUEST"
+	AuditEntityAssessment   AuditEntityType = "ASSESSMENT_COMPONENT"
+	AuditEntityScore        AuditEntityType = "ASSESSMENT_SCORE"
+	AuditEntityAssignment   AuditEntityType = "ASSIGNMENT"
+	AuditEntitySubmission   AuditEntityType = "SUBMISSION"
+	AuditEntityClassSession AuditEntityType = "CLASS_SESSION"
+	AuditEntityAttendance   AuditEntityType = "ATTENDANCE"
+	AuditEntityRoom         AuditEntityType = "ROOM"
+	AuditEntityRoomBooking  AuditEntityType = "ROOM_BOOKING"
+	AuditEntityScheduleJob  AuditEntityType = "SCHEDULE_JOB"
+	AuditEntityPreferences  AuditEntityType = "TEACHER_PREFERENCES"
+	AuditEntityExam         AuditEntityType = "EXAM"
+	AuditEntityProgram      AuditEntityType = "PROGRAM"
+	AuditEntityDepartment   AuditEntityType = "DEPARTMENT"
+	AuditEntityRole         AuditEntityType = "ROLE" // Roles are keyed by name, so their entries carry entity ID 0
+)
+
+// Actor identifies who performed a change and from where. A zero UserID means
+// the system itself, e.g. during startup.
+type Actor struct {
+	UserID    uint
+	Role      Role
+	RequestID string
+	IP        string
+}
+
+// OrUser fills in the user for changes made before authentication, such as
+// signing up or resetting a password
+func (a Actor) OrUser(user *User) Actor {
+	if a.UserID == 0 {
+		a.UserID = user.ID
+		a.Role = user.Role
+	}
+	return a
+}
+
+// AuditLog is an append-only record of a single mutation. Before and After hold
+// JSON snapshots of the entity; Diff maps every changed field to its old and
+// new value.
+type AuditLog struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	ActorID    *uint           `json:"actorId"`
+	ActorRole  Role            `gorm:"type:varchar(50)" json:"actorRole"`
+	Action     AuditAction     `gorm:"type:varchar(30);not null" json:"action"`
+	EntityType AuditEntityType `gorm:"type:varchar(30);not null" json:"entityType"`
+	EntityID   uint            `gorm:"not null" json:"entityId"`
+	Before     json.RawMessage `gorm:"type:jsonb" json:"before"`
+	After      json.RawMessage `gorm:"type:jsonb" json:"after"`
+	Diff       json.RawMessage `gorm:"type:jsonb" json:"diff"`
+	RequestID  string          `gorm:"type:varchar(64)" json:"requestId"`
+	IP         string          `gorm:"type:varchar(64)" json:"ip"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
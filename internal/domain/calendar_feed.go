@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+)
+
+// CalendarFeed lets calendar applications read a user's timetable without
+// signing in. Only the hash of the token in the feed URL is stored, and each
+// user has at most one feed; creating a new one revokes the old URL.
+type CalendarFeed struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;unique" json:"userId"`
+	TokenHash  string     `gorm:"type:varchar(64);unique;not null" json:"-"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
@@ -7,16 +7,41 @@ import (
 )
 
 type Course struct {
-	ID          uint           `gorm:"primarykey" json:"id"`
-	Code        string         `gorm:"unique;not null" json:"code"`
-	Name        string         `gorm:"not null" json:"name"`
-	Description string         `json:"description"`
-	Credits     int            `gorm:"not null" json:"credits"`
-	TeacherID   uint           `gorm:"not null" json:"teacherId"`
-	Teacher     Teacher        `gorm:"foreignKey:TeacherID" json:"teacher"`
-	StartDate   time.Time      `gorm:"not null" json:"startDate"`
-	EndDate     time.Time      `gorm:"not null" json:"endDate"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                uint                 `gorm:"primarykey" json:"id"`
+	Code              string               `gorm:"unique;not null" json:"code"`
+	Name              string               `gorm:"not null" json:"name"`
+	Description       string               `json:"description"`
+	Credits           int                  `gorm:"not null" json:"credits"`
+	Capacity          int                  `gorm:"not null;default:0" json:"capacity"` // 0 means unlimited
+	TeacherID         uint                 `gorm:"not null" json:"teacherId"`
+	Teacher           Teacher              `gorm:"foreignKey:TeacherID" json:"teacher"`
+	StartDate         time.Time            `gorm:"not null" json:"startDate"`
+	EndDate           time.Time            `gorm:"not null" json:"endDate"`
+	TermID            *uint                `json:"termId"`
+	DepartmentID      *uint                `json:"departmentId"`
+	Term              *Term                `gorm:"foreignKey:TermID" json:"term,omitempty"`
+	Department        *Department          `gorm:"foreignKey:DepartmentID" json:"-"`
+	Prerequisites     []CoursePrerequisite `gorm:"foreignKey:CourseID" json:"prerequisites,omitempty"`
+	GradesFinalizedAt *time.Time           `json:"gradesFinalizedAt"` // Afterwards grades only change through approved requests
+	GradesFinalizedBy *uint                `json:"gradesFinalizedBy"`
+	GradeRounding     string               `gorm:"type:varchar(10);not null;default:HALF_UP" json:"gradeRounding"` // Applied to grades computed from assessment scores
+	GradeDecimals     int                  `gorm:"not null;default:2" json:"gradeDecimals"`
+	CreatedAt         time.Time            `json:"createdAt"`
+	UpdatedAt         time.Time            `json:"updatedAt"`
+	DeletedAt         gorm.DeletedAt       `gorm:"index" json:"-"`
+}
+
+// IsFull reports whether a course with the given number of enrolled students
+// has no free seats left
+func (c *Course) IsFull(enrolled int) bool {
+	return c.Capacity > 0 && enrolled >= c.Capacity
+}
+
+// GradesLocked reports whether grades can no longer be edited directly, either
+// because they were finalized or because the term's grading deadline passed
+func (c *Course) GradesLocked(at time.Time) bool {
+	if c.GradesFinalizedAt != nil {
+		return true
+	}
+	return c.Term != nil && at.After(c.Term.GradingDeadline)
 }
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+// CourseMeeting is a weekly recurring meeting of a course, e.g. lectures on
+// Monday and Wednesday 09:00-10:30. A course may have several meetings.
+type CourseMeeting struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	CourseID    uint      `gorm:"not null;index" json:"courseId"`
+	Course      Course    `gorm:"foreignKey:CourseID" json:"-"`
+	Days        uint8     `gorm:"not null" json:"days"`        // Weekday bitmask, Monday is the lowest bit
+	StartMinute int       `gorm:"not null" json:"startMinute"` // Minutes from midnight
+	EndMinute   int       `gorm:"not null" json:"endMinute"`
+	RoomID      *uint     `json:"roomId"` // Booked room, checked for double bookings
+	Location    *Room     `gorm:"foreignKey:RoomID" json:"-"`
+	Room        string    `json:"room"` // Free-form location used when no room is booked
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// RoomLabel names where the meeting takes place
+func (m *CourseMeeting) RoomLabel() string {
+	if m.Location != nil {
+		return m.Location.Label()
+	}
+	return m.Room
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// DefaultPassingGrade is the minimum grade that satisfies a prerequisite
+// when no explicit minimum grade is configured for it.
+const DefaultPassingGrade = 50.0
+
+type CoursePrerequisite struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	CourseID       uint      `gorm:"not null" json:"courseId"`
+	PrerequisiteID uint      `gorm:"not null" json:"prerequisiteId"`
+	Prerequisite   Course    `gorm:"foreignKey:PrerequisiteID" json:"prerequisite"`
+	MinGrade       *float64  `gorm:"type:double precision" json:"minGrade"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// RequiredGrade returns the grade a student needs in the prerequisite course
+func (p *CoursePrerequisite) RequiredGrade() float64 {
+	if p.MinGrade != nil {
+		return *p.MinGrade
+	}
+	return DefaultPassingGrade
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Department is an academic department. Its Code is the prefix of the
+// employee IDs of its teachers (e.g. "CSC-00042") and its SubjectCode the
+// prefix of the codes of its courses (e.g. "COMP-101").
+type Department struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	Code          string    `gorm:"type:varchar(3);unique;not null" json:"code"`
+	SubjectCode   *string   `gorm:"type:varchar(4);unique" json:"subjectCode"` // Departments without one accept any course code
+	Name          string    `gorm:"type:varchar(100);not null" json:"name"`
+	HeadTeacherID *uint     `json:"headTeacherId"`
+	HeadTeacher   *Teacher  `gorm:"foreignKey:HeadTeacherID" json:"headTeacher,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// CodePrefix returns the part of an employee ID or course code before the dash
+func CodePrefix(code string) string {
+	prefix, _, _ := strings.Cut(code, "-")
+	return prefix
+}
+
+// MatchesEmployeeID reports whether the employee ID starts with the department code
+func (d *Department) MatchesEmployeeID(employeeID string) bool {
+	return CodePrefix(employeeID) == d.Code
+}
+
+// MatchesCourseCode reports whether the course code starts with the subject
+// code of the department
+func (d *Department) MatchesCourseCode(code string) bool {
+	return d.SubjectCode == nil || CodePrefix(code) == *d.SubjectCode
+}
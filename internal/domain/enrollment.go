@@ -6,15 +6,33 @@ import (
 	"gorm.io/gorm"
 )
 
+type EnrollmentStatus string
+
+const (
+	EnrollmentStatusEnrolled  EnrollmentStatus = "ENROLLED"
+	EnrollmentStatusDropped   EnrollmentStatus = "DROPPED"
+	EnrollmentStatusWithdrawn EnrollmentStatus = "WITHDRAWN"
+	EnrollmentStatusCompleted EnrollmentStatus = "COMPLETED"
+)
+
 type Enrollment struct {
-	ID         uint           `gorm:"primaryKey" json:"id"`
-	StudentID  uint           `gorm:"not null" json:"studentId"`
-	Student    Student        `gorm:"foreignKey:StudentID;references:ID" json:"student"`
-	CourseID   uint           `gorm:"not null" json:"courseId"`
-	Course     Course         `gorm:"foreignKey:CourseID;references:ID" json:"course"`
-	Grade      *float64       `gorm:"type:double precision" json:"grade"`
-	EnrollDate time.Time      `gorm:"not null" json:"enrollDate"`
-	CreatedAt  time.Time      `json:"createdAt"`
-	UpdatedAt  time.Time      `json:"updatedAt"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                 uint             `gorm:"primaryKey" json:"id"`
+	StudentID          uint             `gorm:"not null" json:"studentId"`
+	Student            Student          `gorm:"foreignKey:StudentID;references:ID" json:"student"`
+	CourseID           uint             `gorm:"not null" json:"courseId"`
+	Course             Course           `gorm:"foreignKey:CourseID;references:ID" json:"course"`
+	Status             EnrollmentStatus `gorm:"type:varchar(20);not null;default:ENROLLED" json:"status"`
+	Grade              *float64         `gorm:"type:double precision" json:"grade"`
+	EnrollDate         time.Time        `gorm:"not null" json:"enrollDate"`
+	DroppedAt          *time.Time       `json:"droppedAt"`                    // Set when the enrollment is dropped or withdrawn
+	LateDropApprovedBy *uint            `json:"lateDropApprovedBy,omitempty"` // User ID of the admin who approved a drop after the deadline
+	LateDropReason     string           `json:"lateDropReason,omitempty"`
+	CreatedAt          time.Time        `json:"createdAt"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
+	DeletedAt          gorm.DeletedAt   `gorm:"index" json:"-"`
+}
+
+// HoldsSeat reports whether the enrollment counts against the course capacity
+func (e *Enrollment) HoldsSeat() bool {
+	return e.Status == EnrollmentStatusEnrolled || e.Status == EnrollmentStatusCompleted
 }
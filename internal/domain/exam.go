@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+)
+
+// Exam is an exam of a course held outside the regular meetings, e.g. the
+// final exam. It is scheduled once StartsAt is set.
+type Exam struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	CourseID  uint       `gorm:"not null;index" json:"courseId"`
+	Course    Course     `gorm:"foreignKey:CourseID" json:"-"`
+	Title     string     `gorm:"type:varchar(200);not null" json:"title"`
+	Duration  int        `gorm:"not null" json:"duration"` // Minutes
+	StartsAt  *time.Time `json:"startsAt"`
+	Locked    bool       `gorm:"not null;default:false" json:"locked"` // The planner keeps the time as it is
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// EndsAt is when the exam ends, nil while it is not scheduled
+func (e *Exam) EndsAt() *time.Time {
+	if e.StartsAt == nil {
+		return nil
+	}
+	end := e.StartsAt.Add(time.Duration(e.Duration) * time.Minute)
+	return &end
+}
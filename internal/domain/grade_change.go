@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"time"
+)
+
+type GradeChangeStatus string
+
+const (
+	GradeChangePending  GradeChangeStatus = "PENDING"
+	GradeChangeApproved GradeChangeStatus = "APPROVED"
+	GradeChangeRejected GradeChangeStatus = "REJECTED"
+)
+
+// GradeChangeRequest asks a reviewer to change a grade after the grades of the
+// course were locked
+type GradeChangeRequest struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	EnrollmentID  uint              `gorm:"not null" json:"enrollmentId"`
+	Enrollment    Enrollment        `gorm:"foreignKey:EnrollmentID" json:"-"`
+	OldGrade      *float64          `gorm:"type:double precision" json:"oldGrade"`
+	NewGrade      float64           `gorm:"type:double precision;not null" json:"newGrade"`
+	Reason        string            `gorm:"type:text;not null" json:"reason"`
+	Status        GradeChangeStatus `gorm:"type:varchar(20);not null;default:PENDING" json:"status"`
+	RequestedBy   uint              `gorm:"not null" json:"requestedBy"`
+	ReviewedBy    *uint             `json:"reviewedBy"`
+	ReviewedAt    *time.Time        `json:"reviewedAt"`
+	ReviewComment string            `gorm:"type:text" json:"reviewComment"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+}
+
+type GradeChangeSource string
+
+const (
+	GradeSourceGrading       GradeChangeSource = "GRADING"        // Entered while grades were open
+	GradeSourceChangeRequest GradeChangeSource = "CHANGE_REQUEST" // Applied by an approved request
+)
+
+// GradeHistory records every change of an enrollment's grade
+type GradeHistory struct {
+	ID              uint              `gorm:"primaryKey" json:"id"`
+	EnrollmentID    uint              `gorm:"not null" json:"enrollmentId"`
+	OldGrade        *float64          `gorm:"type:double precision" json:"oldGrade"`
+	NewGrade        *float64          `gorm:"type:double precision" json:"newGrade"`
+	Source          GradeChangeSource `gorm:"type:varchar(20);not null" json:"source"`
+	ChangeRequestID *uint             `json:"changeRequestId"`
+	ChangedBy       uint              `gorm:"not null" json:"changedBy"`
+	CreatedAt       time.Time         `json:"createdAt"`
+}
+
+func (GradeHistory) TableName() string {
+	return "grade_history"
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "PENDING"
+	InvitationAccepted InvitationStatus = "ACCEPTED"
+	InvitationRevoked  InvitationStatus = "REVOKED"
+	InvitationExpired  InvitationStatus = "EXPIRED"
+)
+
+// Invitation lets an admin create a TEACHER or ADMIN account. The token itself
+// is only returned once, the database keeps its hash.
+type Invitation struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	TokenHash  string     `gorm:"type:varchar(64);unique;not null" json:"-"`
+	Email      string     `gorm:"not null" json:"email"`
+	Role       Role       `gorm:"not null" json:"role"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt"`
+	UserID     *uint      `json:"userId"` // Account created from the invitation
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedBy  uint       `gorm:"not null" json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+// Status reports the state of the invitation at the given time
+func (i *Invitation) Status(at time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case at.After(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
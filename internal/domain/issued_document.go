@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+type DocumentType string
+
+const (
+	DocumentTypeTranscript            DocumentType = "TRANSCRIPT"
+	DocumentTypeEnrollmentCertificate DocumentType = "ENROLLMENT_CERTIFICATE"
+)
+
+// IssuedDocument records an official document so its authenticity can be verified later
+type IssuedDocument struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Code        string       `gorm:"type:varchar(32);unique;not null" json:"code"`
+	Type        DocumentType `gorm:"type:varchar(50);not null" json:"type"`
+	StudentID   uint         `gorm:"not null" json:"studentId"`
+	Student     Student      `gorm:"foreignKey:StudentID;references:ID" json:"student"`
+	IssuedBy    uint         `gorm:"not null" json:"issuedBy"` // User ID of the requester
+	ContentHash string       `gorm:"type:varchar(64);not null" json:"contentHash"`
+	IssuedAt    time.Time    `gorm:"not null" json:"issuedAt"`
+}
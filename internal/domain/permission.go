@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+)
+
+// Permission names a single action. Roles are sets of permissions.
+type Permission string
+
+const (
+	PermissionStudentCreate  Permission = "student:create"
+	PermissionStudentReadAll Permission = "student:read-all"
+	PermissionStudentUpdate  Permission = "student:update"
+	PermissionStudentDelete  Permission = "student:delete"
+
+	PermissionTeacherCreate Permission = "teacher:create"
+	PermissionTeacherUpdate Permission = "teacher:update"
+	PermissionTeacherDelete Permission = "teacher:delete"
+
+	PermissionTermManage Permission = "term:manage"
+
+	PermissionCourseCreate    Permission = "course:create"     // Create courses taught by oneself
+	PermissionCourseUpdateOwn Permission = "course:update-own" // Update courses taught by oneself
+	PermissionCourseUpdateAll Permission = "course:update-all" // Create and update any course, including reassigning teachers
+	PermissionCourseDelete    Permission = "course:delete"
+
+	PermissionPrerequisiteManage Permission = "prerequisite:manage"
+
+	PermissionEnrollmentManageOwn Permission = "enrollment:manage-own" // Enroll and drop students in own courses
+	PermissionEnrollmentManageAll Permission = "enrollment:manage-all"
+	PermissionEnrollmentLateDrop  Permission = "enrollment:late-drop"
+	PermissionEnrollmentOverride  Permission = "enrollment:override-conflict" // Enroll students despite timetable conflicts
+	PermissionWaitlistRead        Permission = "waitlist:read"
+
+	PermissionGradeWrite            Permission = "grade:write"              // Grade enrollments in own courses
+	PermissionGradeWriteAll         Permission = "grade:write-all"          // Grade enrollments in any course
+	PermissionGradeApproveChange    Permission = "grade:approve-change"     // Review grade changes in the department one heads
+	PermissionGradeApproveChangeAll Permission = "grade:approve-change-all" // Review grade changes in any department
+
+	PermissionAttendanceWrite    Permission = "attendance:write"     // Schedule sessions and take attendance in own courses
+	PermissionAttendanceWriteAll Permission = "attendance:write-all" // Take attendance in any course
+
+	PermissionRoomManage Permission = "room:manage" // Manage rooms and cancel any booking
+	PermissionRoomBook   Permission = "room:book"   // Book rooms for exams and events
+
+	PermissionScheduleGenerate Permission = "schedule:generate" // Generate and apply term timetables
+	PermissionExamManage       Permission = "exam:manage"       // Create exams and plan exam periods
+
+	PermissionProgramManage    Permission = "program:manage"    // Define degree programs and their requirements
+	PermissionDepartmentManage Permission = "department:manage" // Manage departments and appoint their heads
+
+	PermissionInvitationManage Permission = "invitation:manage"
+	PermissionSecurityManage   Permission = "security:manage"
+	PermissionRoleManage       Permission = "role:manage"
+	PermissionUserAssignRole   Permission = "user:assign-role"
+	PermissionAuditRead        Permission = "audit:read"
+)
+
+// AllPermissions lists every permission known to the application
+var AllPermissions = []Permission{
+	PermissionStudentCreate, PermissionStudentReadAll, PermissionStudentUpdate, PermissionStudentDelete,
+	PermissionTeacherCreate, PermissionTeacherUpdate, PermissionTeacherDelete,
+	PermissionTermManage,
+	PermissionCourseCreate, PermissionCourseUpdateOwn, PermissionCourseUpdateAll, PermissionCourseDelete,
+	PermissionPrerequisiteManage,
+	PermissionEnrollmentManageOwn, PermissionEnrollmentManageAll, PermissionEnrollmentLateDrop, PermissionEnrollmentOverride, PermissionWaitlistRead,
+	PermissionGradeWrite, PermissionGradeWriteAll, PermissionGradeApproveChange, PermissionGradeApproveChangeAll,
+	PermissionAttendanceWrite, PermissionAttendanceWriteAll,
+	PermissionRoomManage, PermissionRoomBook,
+	PermissionScheduleGenerate, PermissionExamManage,
+	PermissionProgramManage, PermissionDepartmentManage,
+	PermissionInvitationManage, PermissionSecurityManage, PermissionRoleManage, PermissionUserAssignRole,
+	PermissionAuditRead,
+}
+
+// IsKnownPermission reports whether p is one of AllPermissions
+func IsKnownPermission(p Permission) bool {
+	for _, known := range AllPermissions {
+		if known == p {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleDefinition is a named set of permissions stored in the database. Users
+// reference it through User.Role.
+type RoleDefinition struct {
+	Name        Role             `gorm:"type:varchar(50);primaryKey" json:"name"`
+	Description string           `json:"description"`
+	BuiltIn     bool             `gorm:"not null;default:false" json:"builtIn"` // Built-in roles cannot be deleted
+	Permissions []RolePermission `gorm:"foreignKey:RoleName;references:Name" json:"permissions"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+func (RoleDefinition) TableName() string {
+	return "roles"
+}
+
+type RolePermission struct {
+	RoleName   Role       `gorm:"type:varchar(50);primaryKey" json:"roleName"`
+	Permission Permission `gorm:"type:varchar(50);primaryKey" json:"permission"`
+}
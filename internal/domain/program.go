@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+)
+
+type RequirementType string
+
+const (
+	RequirementTypeCore     RequirementType = "CORE"     // Every listed course must be passed
+	RequirementTypeElective RequirementType = "ELECTIVE" // MinCredits must be earned from the listed courses
+)
+
+// Program is a degree program as published in the catalog of one year, e.g.
+// "BSc Computer Science" of the 2024 catalog. Students follow the requirements
+// of the catalog year they are linked to, so later catalogs are new programs
+// with the same code.
+type Program struct {
+	ID           uint               `gorm:"primaryKey" json:"id"`
+	Code         string             `gorm:"type:varchar(20);not null" json:"code"`
+	Name         string             `gorm:"type:varchar(255);not null" json:"name"`
+	CatalogYear  int                `gorm:"not null" json:"catalogYear"`
+	TotalCredits int                `gorm:"not null" json:"totalCredits"` // Credits needed to graduate, from any course
+	DepartmentID *uint              `json:"departmentId"`
+	Groups       []RequirementGroup `gorm:"foreignKey:ProgramID" json:"groups"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+}
+
+// RequirementGroup is one requirement of a program, such as its core courses
+// or a number of credits from a list of electives
+type RequirementGroup struct {
+	ID         uint                `gorm:"primaryKey" json:"id"`
+	ProgramID  uint                `gorm:"not null" json:"programId"`
+	Name       string              `gorm:"type:varchar(100);not null" json:"name"`
+	Type       RequirementType     `gorm:"type:varchar(20);not null" json:"type"`
+	MinCredits int                 `gorm:"not null;default:0" json:"minCredits"` // Elective groups only
+	Position   int                 `gorm:"not null" json:"position"`
+	Courses    []RequirementCourse `gorm:"foreignKey:GroupID" json:"courses"`
+}
+
+type RequirementCourse struct {
+	GroupID  uint   `gorm:"primaryKey" json:"-"`
+	CourseID uint   `gorm:"primaryKey" json:"courseId"`
+	Course   Course `gorm:"foreignKey:CourseID" json:"course"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+)
+
+// RecoveryCode is a single-use backup code for logging in without the
+// authenticator app. Only its hash is stored.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null" json:"userId"`
+	CodeHash  string     `gorm:"type:varchar(64);not null" json:"-"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
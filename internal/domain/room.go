@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+)
+
+// Room is a bookable teaching space
+type Room struct {
+	ID        uint          `gorm:"primaryKey" json:"id"`
+	Building  string        `gorm:"type:varchar(100);not null" json:"building"`
+	Number    string        `gorm:"type:varchar(20);not null" json:"number"`
+	Capacity  int           `gorm:"not null" json:"capacity"`
+	Features  []RoomFeature `gorm:"foreignKey:RoomID" json:"features"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// Label names the room as shown in timetables, e.g. "Main 101"
+func (r *Room) Label() string {
+	return r.Building + " " + r.Number
+}
+
+// HasFeatures reports whether the room offers every given feature
+func (r *Room) HasFeatures(features []string) bool {
+	for _, feature := range features {
+		found := false
+		for _, offered := range r.Features {
+			if offered.Feature == feature {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// RoomFeature is equipment a room offers, such as "projector" or "lab"
+type RoomFeature struct {
+	RoomID  uint   `gorm:"primaryKey" json:"-"`
+	Feature string `gorm:"type:varchar(50);primaryKey" json:"feature"`
+}
+
+type BookingPurpose string
+
+const (
+	BookingPurposeExam  BookingPurpose = "EXAM"
+	BookingPurposeEvent BookingPurpose = "EVENT"
+	BookingPurposeOther BookingPurpose = "OTHER"
+)
+
+// RoomBooking reserves a room for a one-off exam or event
+type RoomBooking struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	RoomID    uint           `gorm:"not null;index" json:"roomId"`
+	Room      Room           `gorm:"foreignKey:RoomID" json:"-"`
+	Title     string         `gorm:"type:varchar(200);not null" json:"title"`
+	Purpose   BookingPurpose `gorm:"type:varchar(10);not null" json:"purpose"`
+	CourseID  *uint          `json:"courseId"` // Course the exam or event belongs to, if any
+	StartsAt  time.Time      `gorm:"not null" json:"startsAt"`
+	EndsAt    time.Time      `gorm:"not null" json:"endsAt"`
+	BookedBy  uint           `gorm:"not null" json:"bookedBy"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
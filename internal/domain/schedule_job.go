@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ScheduleJobStatus string
+
+const (
+	ScheduleJobPending   ScheduleJobStatus = "PENDING"
+	ScheduleJobRunning   ScheduleJobStatus = "RUNNING"
+	ScheduleJobSucceeded ScheduleJobStatus = "SUCCEEDED" // Proposal ready for preview
+	ScheduleJobFailed    ScheduleJobStatus = "FAILED"
+	ScheduleJobApplied   ScheduleJobStatus = "APPLIED" // Proposal written to the course meetings
+)
+
+// ScheduleJob is a background run of the timetable generator for the courses
+// of a term. The proposed timetable is kept in Result until it is applied.
+type ScheduleJob struct {
+	ID         uint              `gorm:"primaryKey" json:"id"`
+	TermID     uint              `gorm:"not null;index" json:"termId"`
+	Status     ScheduleJobStatus `gorm:"type:varchar(20);not null" json:"status"`
+	Progress   int               `gorm:"not null;default:0" json:"progress"` // Percent of courses processed
+	Options    json.RawMessage   `gorm:"type:jsonb" json:"options"`          // Request the job was started with
+	Result     json.RawMessage   `gorm:"type:jsonb" json:"result"`
+	Error      string            `gorm:"type:text" json:"error"`
+	CreatedBy  uint              `gorm:"not null" json:"createdBy"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	StartedAt  *time.Time        `json:"startedAt"`
+	FinishedAt *time.Time        `json:"finishedAt"`
+	AppliedAt  *time.Time        `json:"appliedAt"`
+}
+
+// IsActive reports whether the job has not finished yet
+func (j *ScheduleJob) IsActive() bool {
+	return j.Status == ScheduleJobPending || j.Status == ScheduleJobRunning
+}
+
+// TeacherPreferredSlot is a weekly time a teacher would like to teach at. The
+// timetable generator tries to place the teacher's courses within these.
+type TeacherPreferredSlot struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	TeacherID   uint      `gorm:"not null;index" json:"teacherId"`
+	Days        uint8     `gorm:"not null" json:"days"` // Weekday bitmask, Monday is the lowest bit
+	StartMinute int       `gorm:"not null" json:"startMinute"`
+	EndMinute   int       `gorm:"not null" json:"endMinute"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"time"
+)
+
+type SecurityEventType string
+
+const (
+	SecurityEventAccountLocked   SecurityEventType = "ACCOUNT_LOCKED"
+	SecurityEventIPLocked        SecurityEventType = "IP_LOCKED"
+	SecurityEventAccountUnlocked SecurityEventType = "ACCOUNT_UNLOCKED"
+	SecurityEventIPUnlocked      SecurityEventType = "IP_UNLOCKED"
+)
+
+// SecurityEvent records lockouts and unlocks for later review
+type SecurityEvent struct {
+	ID        uint              `gorm:"primaryKey" json:"id"`
+	Type      SecurityEventType `gorm:"type:varchar(30);not null" json:"type"`
+	UserID    *uint             `json:"userId"`  // Affected account, if known
+	Email     string            `json:"email"`   // Login name the event refers to
+	IP        string            `json:"ip"`      // Client address of the attempt
+	ActorID   *uint             `json:"actorId"` // Admin who performed an unlock
+	Details   string            `json:"details"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
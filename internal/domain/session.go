@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+)
+
+// Session is a login of a user on one client. Every access token carries the
+// session ID, so revoking the session invalidates its tokens immediately.
+type Session struct {
+	ID        string     `gorm:"type:varchar(64);primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null" json:"userId"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// IsActive reports whether tokens of the session are still accepted
+func (s *Session) IsActive(at time.Time) bool {
+	return s.RevokedAt == nil && at.Before(s.ExpiresAt)
+}
+
+// RefreshToken is one link of a session's rotating refresh token family. Each
+// token can be exchanged once; presenting a used token revokes the session.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	SessionID string     `gorm:"type:varchar(64);not null" json:"sessionId"`
+	Session   Session    `gorm:"foreignKey:SessionID" json:"-"`
+	TokenHash string     `gorm:"type:varchar(64);unique;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expiresAt"`
+	UsedAt    *time.Time `json:"usedAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
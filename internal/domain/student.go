@@ -13,6 +13,7 @@ type Student struct {
 	StudentID   string         `gorm:"type:varchar(50);unique;not null" json:"studentId"`
 	EnrollYear  int            `gorm:"not null" json:"enrollYear"`
 	Major       string         `gorm:"type:varchar(255);not null" json:"major"`
+	ProgramID   *uint          `json:"programId"` // Degree program, and with it the catalog year, the student follows
 	Enrollments []Enrollment   `gorm:"foreignKey:StudentID;references:ID" json:"enrollments"`
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
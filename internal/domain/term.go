@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Term struct {
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	Name            string         `gorm:"type:varchar(100);unique;not null" json:"name"`
+	StartDate       time.Time      `gorm:"not null" json:"startDate"`
+	EndDate         time.Time      `gorm:"not null" json:"endDate"`
+	AddDropDeadline time.Time      `gorm:"not null" json:"addDropDeadline"`
+	GradingDeadline time.Time      `gorm:"not null" json:"gradingDeadline"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Contains reports whether the given date range lies entirely within the term
+func (t *Term) Contains(start, end time.Time) bool {
+	return !start.Before(t.StartDate) && !end.After(t.EndDate)
+}
+
+// IsAddDropOpen reports whether courses of the term can still be dropped
+// without a withdrawal being recorded
+func (t *Term) IsAddDropOpen(at time.Time) bool {
+	return !at.After(t.AddDropDeadline)
+}
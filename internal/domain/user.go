@@ -8,20 +8,32 @@ import (
 
 type Role string
 
+// Built-in roles. Further roles can be defined at runtime, see RoleDefinition.
 const (
-	RoleAdmin   Role = "ADMIN"
-	RoleTeacher Role = "TEACHER"
-	RoleStudent Role = "STUDENT"
+	RoleAdmin          Role = "ADMIN"
+	RoleTeacher        Role = "TEACHER"
+	RoleStudent        Role = "STUDENT"
+	RoleRegistrar      Role = "REGISTRAR"
+	RoleDepartmentHead Role = "DEPARTMENT_HEAD"
 )
 
 type User struct {
-	ID        uint           `gorm:"primarykey" json:"id"`
-	Email     string         `gorm:"unique;not null" json:"email"`
-	Password  string         `gorm:"not null" json:"-"`
-	FirstName string         `gorm:"not null" json:"firstName"`
-	LastName  string         `gorm:"not null" json:"lastName"`
-	Role      Role           `gorm:"not null" json:"role"`
-	CreatedAt time.Time      `json:"createdAt"`
-	UpdatedAt time.Time      `json:"updatedAt"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                 uint           `gorm:"primarykey" json:"id"`
+	Email              string         `gorm:"unique;not null" json:"email"`
+	Password           string         `gorm:"not null" json:"-"`
+	FirstName          string         `gorm:"not null" json:"firstName"`
+	LastName           string         `gorm:"not null" json:"lastName"`
+	Role               Role           `gorm:"not null" json:"role"`
+	EmailVerifiedAt    *time.Time     `json:"emailVerifiedAt"`
+	TwoFactorSecret    string         `json:"-"` // TOTP secret, set during enrollment and active once TwoFactorEnabledAt is set
+	TwoFactorEnabledAt *time.Time     `json:"twoFactorEnabledAt"`
+	TwoFactorLastStep  int64          `gorm:"not null;default:0" json:"-"` // Last accepted TOTP step, prevents code reuse
+	CreatedAt          time.Time      `json:"createdAt"`
+	UpdatedAt          time.Time      `json:"updatedAt"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TwoFactorEnabled reports whether login requires a second factor
+func (u *User) TwoFactorEnabled() bool {
+	return u.TwoFactorEnabledAt != nil
 }
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"time"
+)
+
+type UserTokenPurpose string
+
+const (
+	TokenPurposePasswordReset     UserTokenPurpose = "PASSWORD_RESET"
+	TokenPurposeEmailVerification UserTokenPurpose = "EMAIL_VERIFICATION"
+)
+
+// UserToken is a single-use token sent to a user by email. Only its hash is stored.
+type UserToken struct {
+	ID        uint             `gorm:"primaryKey" json:"id"`
+	UserID    uint             `gorm:"not null" json:"userId"`
+	User      User             `gorm:"foreignKey:UserID" json:"-"`
+	Purpose   UserTokenPurpose `gorm:"type:varchar(30);not null" json:"purpose"`
+	TokenHash string           `gorm:"type:varchar(64);unique;not null" json:"-"`
+	ExpiresAt time.Time        `gorm:"not null" json:"expiresAt"`
+	UsedAt    *time.Time       `json:"usedAt"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// IsUsable reports whether the token can still be redeemed
+func (t *UserToken) IsUsable(at time.Time) bool {
+	return t.UsedAt == nil && at.Before(t.ExpiresAt)
+}
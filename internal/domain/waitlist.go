@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type WaitlistEntry struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	StudentID         uint      `gorm:"not null" json:"studentId"`
+	Student           Student   `gorm:"foreignKey:StudentID;references:ID" json:"student"`
+	CourseID          uint      `gorm:"not null" json:"courseId"`
+	Course            Course    `gorm:"foreignKey:CourseID;references:ID" json:"course"`
+	Position          int       `gorm:"not null" json:"position"`                        // 1 is the head of the waitlist
+	OverrideConflicts bool      `gorm:"not null;default:false" json:"overrideConflicts"` // Skips the timetable check on promotion
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
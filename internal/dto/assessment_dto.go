@@ -0,0 +1,90 @@
+package dto
+
+type AssessmentComponentCreateDTO struct {
+	Name       string   `json:"name" binding:"required,min=1,max=100"`
+	Weight     float64  `json:"weight" binding:"required,gt=0,lte=100"`
+	MaxScore   *float64 `json:"maxScore" binding:"omitempty,gt=0"`
+	ItemCount  *int     `json:"itemCount" binding:"omitempty,min=1,max=100"`
+	DropLowest *int     `json:"dropLowest" binding:"omitempty,min=0"`
+}
+
+type AssessmentComponentUpdateDTO struct {
+	Name       string   `json:"name" binding:"omitempty,min=1,max=100"`
+	Weight     *float64 `json:"weight" binding:"omitempty,gt=0,lte=100"`
+	MaxScore   *float64 `json:"maxScore" binding:"omitempty,gt=0"`
+	ItemCount  *int     `json:"itemCount" binding:"omitempty,min=1,max=100"`
+	DropLowest *int     `json:"dropLowest" binding:"omitempty,min=0"`
+}
+
+type AssessmentComponentResponseDTO struct {
+	ID         uint    `json:"id"`
+	CourseID   uint    `json:"courseId"`
+	Name       string  `json:"name"`
+	Weight     float64 `json:"weight"`
+	MaxScore   float64 `json:"maxScore"`
+	ItemCount  int     `json:"itemCount"`
+	DropLowest int     `json:"dropLowest"`
+}
+
+type GradingSchemeUpdateDTO struct {
+	Rounding string `json:"rounding" binding:"required,oneof=HALF_UP HALF_EVEN UP DOWN"`
+	Decimals *int   `json:"decimals" binding:"required,min=0,max=2"`
+}
+
+type GradingSchemeResponseDTO struct {
+	CourseID    uint                             `json:"courseId"`
+	Rounding    string                           `json:"rounding"`
+	Decimals    int                              `json:"decimals"`
+	TotalWeight float64                          `json:"totalWeight"` // Final grades are computed once the weights total 100
+	Components  []AssessmentComponentResponseDTO `json:"components"`
+}
+
+// ScoreEntryDTO sets the score of one item. A missing score clears it.
+type ScoreEntryDTO struct {
+	EnrollmentID uint     `json:"enrollmentId" binding:"required"`
+	ComponentID  uint     `json:"componentId" binding:"required"`
+	Item         int      `json:"item" binding:"omitempty,min=1"` // Defaults to 1
+	Score        *float64 `json:"score" binding:"omitempty,min=0"`
+}
+
+type ScoresUpdateDTO struct {
+	Scores []ScoreEntryDTO `json:"scores" binding:"required,min=1,max=1000,dive"`
+}
+
+type AssessmentScoreResponseDTO struct {
+	ID           uint    `json:"id"`
+	ComponentID  uint    `json:"componentId"`
+	EnrollmentID uint    `json:"enrollmentId"`
+	Item         int     `json:"item"`
+	Score        float64 `json:"score"`
+	GradedBy     uint    `json:"gradedBy"`
+}
+
+type GradebookCellDTO struct {
+	ComponentID uint       `json:"componentId"`
+	Items       []*float64 `json:"items"`      // Score per item, null if not scored
+	Percentage  *float64   `json:"percentage"` // After dropping the lowest items
+}
+
+type GradebookRowDTO struct {
+	EnrollmentID  uint               `json:"enrollmentId"`
+	StudentID     uint               `json:"studentId"`
+	StudentName   string             `json:"studentName"`
+	Status        string             `json:"status"`
+	Scores        []GradebookCellDTO `json:"scores"`
+	CurrentGrade  *float64           `json:"currentGrade"`  // Based on the components scored so far
+	ComputedGrade *float64           `json:"computedGrade"` // Set once every component is scored
+	Grade         *float64           `json:"grade"`         // Grade recorded on the enrollment
+}
+
+type GradebookResponseDTO struct {
+	CourseID    uint                             `json:"courseId"`
+	CourseCode  string                           `json:"courseCode"`
+	CourseName  string                           `json:"courseName"`
+	Rounding    string                           `json:"rounding"`
+	Decimals    int                              `json:"decimals"`
+	TotalWeight float64                          `json:"totalWeight"`
+	Locked      bool                             `json:"locked"`
+	Components  []AssessmentComponentResponseDTO `json:"components"`
+	Rows        []GradebookRowDTO                `json:"rows"`
+}
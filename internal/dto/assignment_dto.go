@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+type AssignmentCreateDTO struct {
+	Title              string    `json:"title" binding:"required,min=1,max=200"`
+	Description        string    `json:"description" binding:"omitempty,max=5000"`
+	DueAt              time.Time `json:"dueAt" binding:"required"`
+	MaxPoints          float64   `json:"maxPoints" binding:"required,gt=0"`
+	LatePolicy         string    `json:"latePolicy" binding:"omitempty,oneof=REJECT ACCEPT PENALTY"` // Defaults to REJECT
+	LatePenaltyPercent float64   `json:"latePenaltyPercent" binding:"omitempty,min=0,max=100"`       // Per started day late
+	MaxLateDays        int       `json:"maxLateDays" binding:"omitempty,min=0"`
+}
+
+type AssignmentUpdateDTO struct {
+	Title              string    `json:"title" binding:"omitempty,min=1,max=200"`
+	Description        *string   `json:"description" binding:"omitempty,max=5000"`
+	DueAt              time.Time `json:"dueAt" binding:"omitempty"`
+	MaxPoints          *float64  `json:"maxPoints" binding:"omitempty,gt=0"`
+	LatePolicy         string    `json:"latePolicy" binding:"omitempty,oneof=REJECT ACCEPT PENALTY"`
+	LatePenaltyPercent *float64  `json:"latePenaltyPercent" binding:"omitempty,min=0,max=100"`
+	MaxLateDays        *int      `json:"maxLateDays" binding:"omitempty,min=0"`
+}
+
+type AssignmentResponseDTO struct {
+	ID                 uint      `json:"id"`
+	CourseID           uint      `json:"courseId"`
+	Title              string    `json:"title"`
+	Description        string    `json:"description"`
+	DueAt              time.Time `json:"dueAt"`
+	MaxPoints          float64   `json:"maxPoints"`
+	LatePolicy         string    `json:"latePolicy"`
+	LatePenaltyPercent float64   `json:"latePenaltyPercent"`
+	MaxLateDays        int       `json:"maxLateDays"`
+	CreatedAt          time.Time `json:"createdAt"`
+}
+
+// SubmissionUploadDTO is an uploaded file handed from the controller to the service
+type SubmissionUploadDTO struct {
+	FileName    string
+	ContentType string
+	Content     io.Reader
+}
+
+type SubmissionGradeDTO struct {
+	Score    *float64 `json:"score" binding:"required,min=0"`
+	Feedback string   `json:"feedback" binding:"omitempty,max=5000"`
+}
+
+type SubmissionResponseDTO struct {
+	ID             uint       `json:"id"`
+	AssignmentID   uint       `json:"assignmentId"`
+	StudentID      uint       `json:"studentId"`
+	StudentName    string     `json:"studentName"`
+	FileName       string     `json:"fileName"`
+	ContentType    string     `json:"contentType"`
+	Size           int64      `json:"size"`
+	SubmittedAt    time.Time  `json:"submittedAt"`
+	DaysLate       int        `json:"daysLate"`
+	PenaltyPercent float64    `json:"penaltyPercent"`
+	Score          *float64   `json:"score"`
+	FinalScore     *float64   `json:"finalScore"` // Score after the late penalty
+	Feedback       string     `json:"feedback"`
+	GradedBy       *uint      `json:"gradedBy"`
+	GradedAt       *time.Time `json:"gradedAt"`
+}
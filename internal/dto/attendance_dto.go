@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+)
+
+type ClassSessionCreateDTO struct {
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required"`
+	Room     string    `json:"room" binding:"omitempty,max=100"`
+	Topic    string    `json:"topic" binding:"omitempty,max=200"`
+}
+
+type ClassSessionUpdateDTO struct {
+	StartsAt time.Time `json:"startsAt" binding:"omitempty"`
+	EndsAt   time.Time `json:"endsAt" binding:"omitempty"`
+	Room     *string   `json:"room" binding:"omitempty,max=100"`
+	Topic    *string   `json:"topic" binding:"omitempty,max=200"`
+}
+
+type ClassSessionResponseDTO struct {
+	ID        uint      `json:"id"`
+	CourseID  uint      `json:"courseId"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	Room      string    `json:"room"`
+	Topic     string    `json:"topic"`
+	CreatedBy uint      `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AttendanceMarkDTO marks the attendance of several students at a session at once
+type AttendanceMarkDTO struct {
+	Records []AttendanceMarkRecordDTO `json:"records" binding:"required,min=1,dive"`
+}
+
+type AttendanceMarkRecordDTO struct {
+	EnrollmentID uint   `json:"enrollmentId" binding:"required"`
+	Status       string `json:"status" binding:"required,oneof=PRESENT ABSENT LATE EXCUSED"`
+	Note         string `json:"note" binding:"omitempty,max=500"`
+}
+
+type AttendanceRecordResponseDTO struct {
+	ID           uint      `json:"id"`
+	SessionID    uint      `json:"sessionId"`
+	EnrollmentID uint      `json:"enrollmentId"`
+	Status       string    `json:"status"`
+	Note         string    `json:"note"`
+	MarkedBy     uint      `json:"markedBy"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// AttendanceSummaryDTO is the attendance of one enrollment in a course
+type AttendanceSummaryDTO struct {
+	EnrollmentID uint                          `json:"enrollmentId"`
+	StudentID    uint                          `json:"studentId"`
+	StudentName  string                        `json:"studentName"`
+	CourseID     uint                          `json:"courseId"`
+	Sessions     int                           `json:"sessions"` // Sessions marked for the enrollment
+	Present      int                           `json:"present"`
+	Late         int                           `json:"late"`
+	Absent       int                           `json:"absent"`
+	Excused      int                           `json:"excused"`
+	Percentage   *float64                      `json:"percentage"` // Attended share of the non-excused sessions, nil if there are none
+	Records      []AttendanceRecordResponseDTO `json:"records,omitempty"`
+}
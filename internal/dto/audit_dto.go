@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditLogQueryDTO filters the audit log. Zero values are ignored.
+type AuditLogQueryDTO struct {
+	ActorID    uint
+	EntityType string
+	EntityID   uint
+	Action     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditLogResponseDTO struct {
+	ID         uint            `json:"id"`
+	ActorID    *uint           `json:"actorId"`
+	ActorRole  string          `json:"actorRole"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entityType"`
+	EntityID   uint            `json:"entityId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Diff       json.RawMessage `json:"diff"`
+	RequestID  string          `json:"requestId"`
+	IP         string          `json:"ip"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
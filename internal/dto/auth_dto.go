@@ -1,11 +1,29 @@
 package dto
 
+import "time"
+
+// RegisterRequest is the public sign-up form. It always creates a student account.
 type RegisterRequest struct {
-	Email     string `json:"email" binding:"required,email"`
-	Password  string `json:"password" binding:"required,password"`
-	FirstName string `json:"firstName" binding:"required,min=2,max=50"`
-	LastName  string `json:"lastName" binding:"required,min=2,max=50"`
-	Role      string `json:"role" binding:"required,oneof=ADMIN TEACHER STUDENT"`
+	Email      string `json:"email" binding:"required,email"`
+	Password   string `json:"password" binding:"required,password"`
+	FirstName  string `json:"firstName" binding:"required,min=2,max=50"`
+	LastName   string `json:"lastName" binding:"required,min=2,max=50"`
+	StudentID  string `json:"studentId" binding:"required,student_id"`
+	EnrollYear int    `json:"enrollYear" binding:"required,min=2000,max=2100"`
+	Major      string `json:"major" binding:"required,min=2,max=100"`
+}
+
+// InvitationRegisterRequest creates an account from an invitation. The email and
+// role come from the invitation; teacher fields are required for TEACHER invitations.
+// The teacher's department is the one whose code starts the employee ID.
+type InvitationRegisterRequest struct {
+	Token       string    `json:"token" binding:"required"`
+	Password    string    `json:"password" binding:"required,password"`
+	FirstName   string    `json:"firstName" binding:"required,min=2,max=50"`
+	LastName    string    `json:"lastName" binding:"required,min=2,max=50"`
+	EmployeeID  string    `json:"employeeId" binding:"omitempty,employee_id"`
+	Speciality  string    `json:"speciality" binding:"omitempty,min=2,max=100"`
+	JoiningDate time.Time `json:"joiningDate"`
 }
 
 type LoginRequest struct {
@@ -13,7 +31,38 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required,password"`
+}
+
+type EmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,password"`
+}
+
+// AuthResponse carries the tokens of a new session. Tokens are omitted when a
+// registration still has to confirm its email address, or when the login needs
+// a second factor; ChallengeToken is then used to complete it.
 type AuthResponse struct {
-	Token string  `json:"token"`
-	User  UserDTO `json:"user"`
+	Token                  string     `json:"token,omitempty"`
+	ExpiresAt              *time.Time `json:"expiresAt,omitempty"` // Expiry of the access token
+	RefreshToken           string     `json:"refreshToken,omitempty"`
+	TwoFactorRequired      bool       `json:"twoFactorRequired,omitempty"`
+	TwoFactorSetupRequired bool       `json:"twoFactorSetupRequired,omitempty"`
+	ChallengeToken         string     `json:"challengeToken,omitempty"`
+	RecoveryCodes          []string   `json:"recoveryCodes,omitempty"` // Returned once when 2FA is enrolled during login
+	User                   UserDTO    `json:"user"`
 }
@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type CalendarFeedResponseDTO struct {
+	URL        string     `json:"url,omitempty"` // Only returned when the feed is created
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
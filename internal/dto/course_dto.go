@@ -3,32 +3,56 @@ package dto
 import "time"
 
 type CourseCreateDTO struct {
-	Code        string    `json:"code" binding:"required,course_code"`
-	Name        string    `json:"name" binding:"required,min=2,max=100"`
-	Description string    `json:"description" binding:"max=500"`
-	Credits     int       `json:"credits" binding:"required,min=1,max=6"`
-	TeacherID   uint      `json:"teacherId" binding:"required"`
-	StartDate   time.Time `json:"startDate" binding:"required"`
-	EndDate     time.Time `json:"endDate" binding:"required,date_range"`
+	Code         string    `json:"code" binding:"required,course_code"`
+	Name         string    `json:"name" binding:"required,min=2,max=100"`
+	Description  string    `json:"description" binding:"max=500"`
+	Credits      int       `json:"credits" binding:"required,min=1,max=6"`
+	Capacity     int       `json:"capacity" binding:"omitempty,min=0"` // 0 means unlimited
+	TeacherID    uint      `json:"teacherId" binding:"required"`
+	StartDate    time.Time `json:"startDate" binding:"required"`
+	EndDate      time.Time `json:"endDate" binding:"required,date_range"`
+	TermID       *uint     `json:"termId" binding:"omitempty"`
+	DepartmentID *uint     `json:"departmentId" binding:"omitempty"` // Defaults to the department of the code's subject code
 }
 
 type CourseResponseDTO struct {
-	ID          uint      `json:"id"`
-	Code        string    `json:"code"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Credits     int       `json:"credits"`
-	TeacherID   uint      `json:"teacherId"`
-	TeacherName string    `json:"teacherName"` // Combining teacher first and last name
-	StartDate   time.Time `json:"startDate"`
-	EndDate     time.Time `json:"endDate"`
+	ID                uint       `json:"id"`
+	Code              string     `json:"code"`
+	Name              string     `json:"name"`
+	Description       string     `json:"description"`
+	Credits           int        `json:"credits"`
+	Capacity          int        `json:"capacity"`
+	TeacherID         uint       `json:"teacherId"`
+	TeacherName       string     `json:"teacherName"` // Combining teacher first and last name
+	StartDate         time.Time  `json:"startDate"`
+	EndDate           time.Time  `json:"endDate"`
+	TermID            *uint      `json:"termId"`
+	TermName          string     `json:"termName"`
+	DepartmentID      *uint      `json:"departmentId"`
+	GradesFinalizedAt *time.Time `json:"gradesFinalizedAt"`
 }
 
 type CourseUpdateDTO struct {
-	Name        string    `json:"name" binding:"omitempty,min=2,max=100"`
-	Description string    `json:"description" binding:"omitempty,max=500"`
-	Credits     int       `json:"credits" binding:"omitempty,min=1,max=6"`
-	TeacherID   uint      `json:"teacherId" binding:"omitempty"`
-	StartDate   time.Time `json:"startDate" binding:"omitempty"`
-	EndDate     time.Time `json:"endDate" binding:"omitempty,date_range"`
+	Name         string    `json:"name" binding:"omitempty,min=2,max=100"`
+	Description  string    `json:"description" binding:"omitempty,max=500"`
+	Credits      int       `json:"credits" binding:"omitempty,min=1,max=6"`
+	Capacity     *int      `json:"capacity" binding:"omitempty,min=0"`
+	TeacherID    uint      `json:"teacherId" binding:"omitempty"`
+	StartDate    time.Time `json:"startDate" binding:"omitempty"`
+	EndDate      time.Time `json:"endDate" binding:"omitempty,date_range"`
+	TermID       *uint     `json:"termId" binding:"omitempty"`
+	DepartmentID *uint     `json:"departmentId" binding:"omitempty"`
+}
+
+type PrerequisiteCreateDTO struct {
+	PrerequisiteID uint     `json:"prerequisiteId" binding:"required"`
+	MinGrade       *float64 `json:"minGrade" binding:"omitempty,min=0,max=100"`
+}
+
+type PrerequisiteResponseDTO struct {
+	CourseID         uint     `json:"courseId"`
+	PrerequisiteID   uint     `json:"prerequisiteId"`
+	PrerequisiteCode string   `json:"prerequisiteCode"`
+	PrerequisiteName string   `json:"prerequisiteName"`
+	MinGrade         *float64 `json:"minGrade"`
 }
@@ -0,0 +1,26 @@
+package dto
+
+// DepartmentCreateDTO creates a department. Code is the prefix of its teachers'
+// employee IDs and subjectCode the prefix of its course codes.
+type DepartmentCreateDTO struct {
+	Code        string  `json:"code" binding:"required,len=3,alpha,uppercase"`
+	SubjectCode *string `json:"subjectCode" binding:"omitempty,len=4,alpha,uppercase"`
+	Name        string  `json:"name" binding:"required,min=2,max=100"`
+}
+
+// DepartmentUpdateDTO changes the given fields of a department. An empty
+// subjectCode removes it and a headTeacherId of 0 removes the head.
+type DepartmentUpdateDTO struct {
+	Name          *string `json:"name" binding:"omitempty,min=2,max=100"`
+	SubjectCode   *string `json:"subjectCode" binding:"omitempty,len=4,alpha,uppercase"`
+	HeadTeacherID *uint   `json:"headTeacherId"`
+}
+
+type DepartmentResponseDTO struct {
+	ID              uint    `json:"id"`
+	Code            string  `json:"code"`
+	SubjectCode     *string `json:"subjectCode"`
+	Name            string  `json:"name"`
+	HeadTeacherID   *uint   `json:"headTeacherId"`
+	HeadTeacherName string  `json:"headTeacherName"`
+}
@@ -4,23 +4,42 @@ package dto
 import "time"
 
 type EnrollmentCreateDTO struct {
-	StudentID  uint      `json:"studentId" binding:"required"`
-	CourseID   uint      `json:"courseId" binding:"required"`
-	EnrollDate time.Time `json:"enrollDate" binding:"required"`
+	StudentID         uint      `json:"studentId" binding:"required"`
+	CourseID          uint      `json:"courseId" binding:"required"`
+	EnrollDate        time.Time `json:"enrollDate" binding:"required"`
+	OverrideConflicts bool      `json:"overrideConflicts"` // Enroll despite timetable conflicts, requires enrollment:override-conflict
 }
 
 type EnrollmentResponseDTO struct {
-	ID          uint      `json:"id"`
-	StudentID   uint      `json:"studentId"`
-	StudentName string    `json:"studentName"` // Combined first and last name
-	CourseID    uint      `json:"courseId"`
-	CourseName  string    `json:"courseName"`
-	CourseCode  string    `json:"courseCode"`
-	Grade       *float64  `json:"grade"`
-	EnrollDate  time.Time `json:"enrollDate"`
+	ID          uint       `json:"id"`
+	StudentID   uint       `json:"studentId"`
+	StudentName string     `json:"studentName"` // Combined first and last name
+	CourseID    uint       `json:"courseId"`
+	CourseName  string     `json:"courseName"`
+	CourseCode  string     `json:"courseCode"`
+	TermID      *uint      `json:"termId"`
+	TermName    string     `json:"termName"`
+	Status      string     `json:"status"`
+	Grade       *float64   `json:"grade"`
+	EnrollDate  time.Time  `json:"enrollDate"`
+	DroppedAt   *time.Time `json:"droppedAt,omitempty"`
 }
 
 type EnrollmentUpdateDTO struct {
 	Grade      *float64  `json:"grade" binding:"omitempty,min=0,max=100"`
 	EnrollDate time.Time `json:"enrollDate" binding:"omitempty"`
+	Status     string    `json:"status" binding:"omitempty,oneof=ENROLLED COMPLETED"`
+}
+
+type LateDropDTO struct {
+	Reason string `json:"reason" binding:"required,min=5,max=500"`
+}
+
+type UnmetPrerequisiteDTO struct {
+	CourseID      uint     `json:"courseId"`
+	CourseCode    string   `json:"courseCode"`
+	CourseName    string   `json:"courseName"`
+	RequiredGrade float64  `json:"requiredGrade"`
+	BestGrade     *float64 `json:"bestGrade"` // Highest recorded grade, nil if never completed
+	Reason        string   `json:"reason"`
 }
@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+type ExamCreateDTO struct {
+	CourseID uint       `json:"courseId" binding:"required"`
+	Title    string     `json:"title" binding:"required,max=200"`
+	Duration int        `json:"duration" binding:"required,min=15,max=1440"` // Minutes
+	StartsAt *time.Time `json:"startsAt"`
+	Locked   bool       `json:"locked"`
+}
+
+type ExamUpdateDTO struct {
+	Title    *string    `json:"title" binding:"omitempty,max=200"`
+	Duration *int       `json:"duration" binding:"omitempty,min=15,max=1440"`
+	StartsAt *time.Time `json:"startsAt"`
+	Locked   *bool      `json:"locked"`
+}
+
+type ExamResponseDTO struct {
+	ID         uint       `json:"id"`
+	CourseID   uint       `json:"courseId"`
+	CourseCode string     `json:"courseCode"`
+	CourseName string     `json:"courseName"`
+	Title      string     `json:"title"`
+	Duration   int        `json:"duration"`
+	StartsAt   *time.Time `json:"startsAt"`
+	EndsAt     *time.Time `json:"endsAt"`
+	Locked     bool       `json:"locked"`
+}
+
+// ExamPlanRequestDTO plans the exams of a term into the slots starting at the
+// slotTimes ("HH:MM") on every day of the period
+type ExamPlanRequestDTO struct {
+	StartDate  time.Time `json:"startDate" binding:"required"`
+	EndDate    time.Time `json:"endDate" binding:"required"`
+	Days       []string  `json:"days" binding:"omitempty,dive,oneof=MON TUE WED THU FRI SAT SUN"` // Defaults to MON-FRI
+	SlotTimes  []string  `json:"slotTimes" binding:"required,min=1"`
+	SlotLength int       `json:"slotLength" binding:"omitempty,min=15,max=1440"` // Minutes, defaults to 180
+	MaxPerDay  *int      `json:"maxPerDay" binding:"omitempty,min=0"`            // Defaults to EXAM_MAX_PER_DAY, 0 for no limit
+	DryRun     bool      `json:"dryRun"`                                         // Only preview the plan
+}
+
+type ExamPlanResultDTO struct {
+	Exams      []ExamResponseDTO `json:"exams"`
+	Unresolved []ExamConflictDTO `json:"unresolved"` // Exams left unscheduled
+	Applied    bool              `json:"applied"`
+}
+
+// ExamConflictDTO is a clash of exams, a day with too many exams or an exam
+// without a time, with the students affected
+type ExamConflictDTO struct {
+	Type     string           `json:"type"` // CLASH, DAILY_LIMIT, NO_SLOT or UNSCHEDULED
+	ExamIDs  []uint           `json:"examIds"`
+	Date     string           `json:"date,omitempty"`
+	Students []ExamStudentDTO `json:"students"`
+}
+
+type ExamStudentDTO struct {
+	StudentID     uint   `json:"studentId"`
+	StudentNumber string `json:"studentNumber"`
+	Name          string `json:"name"`
+}
+
+type StudentExamScheduleDTO struct {
+	Exams     []ExamResponseDTO `json:"exams"`
+	Conflicts []ExamConflictDTO `json:"conflicts"`
+}
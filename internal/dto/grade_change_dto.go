@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+type GradeChangeRequestCreateDTO struct {
+	NewGrade *float64 `json:"newGrade" binding:"required,min=0,max=100"`
+	Reason   string   `json:"reason" binding:"required,min=10,max=1000"`
+}
+
+type GradeChangeReviewDTO struct {
+	Comment string `json:"comment" binding:"omitempty,max=1000"`
+}
+
+type GradeChangeRequestResponseDTO struct {
+	ID            uint       `json:"id"`
+	EnrollmentID  uint       `json:"enrollmentId"`
+	StudentID     uint       `json:"studentId"`
+	StudentName   string     `json:"studentName"`
+	CourseID      uint       `json:"courseId"`
+	CourseCode    string     `json:"courseCode"`
+	OldGrade      *float64   `json:"oldGrade"`
+	NewGrade      float64    `json:"newGrade"`
+	Reason        string     `json:"reason"`
+	Status        string     `json:"status"`
+	RequestedBy   uint       `json:"requestedBy"`
+	ReviewedBy    *uint      `json:"reviewedBy"`
+	ReviewedAt    *time.Time `json:"reviewedAt"`
+	ReviewComment string     `json:"reviewComment"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+type GradeHistoryResponseDTO struct {
+	ID              uint      `json:"id"`
+	EnrollmentID    uint      `json:"enrollmentId"`
+	OldGrade        *float64  `json:"oldGrade"`
+	NewGrade        *float64  `json:"newGrade"`
+	Source          string    `json:"source"`
+	ChangeRequestID *uint     `json:"changeRequestId"`
+	ChangedBy       uint      `json:"changedBy"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type InvitationCreateDTO struct {
+	Email          string `json:"email" binding:"required,email"`
+	Role           string `json:"role" binding:"required,max=50"` // Any role except STUDENT
+	ExpiresInHours int    `json:"expiresInHours" binding:"omitempty,min=1,max=720"`
+}
+
+type InvitationResponseDTO struct {
+	ID         uint       `json:"id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt"`
+	UserID     *uint      `json:"userId"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedBy  uint       `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Token      string     `json:"token,omitempty"` // Only set when the invitation is created
+}
@@ -0,0 +1,99 @@
+package dto
+
+// RequirementGroupDTO is a requirement of a program. CORE groups require every
+// course, ELECTIVE groups minCredits from the courses.
+type RequirementGroupDTO struct {
+	Name       string `json:"name" binding:"required,max=100"`
+	Type       string `json:"type" binding:"required,oneof=CORE ELECTIVE"`
+	MinCredits int    `json:"minCredits" binding:"omitempty,min=1"`
+	CourseIDs  []uint `json:"courseIds" binding:"required,min=1"`
+}
+
+type ProgramCreateDTO struct {
+	Code         string                `json:"code" binding:"required,max=20"`
+	Name         string                `json:"name" binding:"required,max=255"`
+	CatalogYear  int                   `json:"catalogYear" binding:"required,min=2000,max=2100"`
+	TotalCredits int                   `json:"totalCredits" binding:"required,min=1"`
+	DepartmentID *uint                 `json:"departmentId"`
+	Groups       []RequirementGroupDTO `json:"groups" binding:"dive"`
+}
+
+// ProgramUpdateDTO changes the given fields of a program. Groups, when given,
+// replace the current requirements.
+type ProgramUpdateDTO struct {
+	Name         *string                `json:"name" binding:"omitempty,max=255"`
+	TotalCredits *int                   `json:"totalCredits" binding:"omitempty,min=1"`
+	DepartmentID *uint                  `json:"departmentId"`
+	Groups       *[]RequirementGroupDTO `json:"groups" binding:"omitempty,dive"`
+}
+
+type ProgramResponseDTO struct {
+	ID           uint                          `json:"id"`
+	Code         string                        `json:"code"`
+	Name         string                        `json:"name"`
+	CatalogYear  int                           `json:"catalogYear"`
+	TotalCredits int                           `json:"totalCredits"`
+	DepartmentID *uint                         `json:"departmentId"`
+	Groups       []RequirementGroupResponseDTO `json:"groups"`
+}
+
+type RequirementGroupResponseDTO struct {
+	ID         uint                   `json:"id"`
+	Name       string                 `json:"name"`
+	Type       string                 `json:"type"`
+	MinCredits int                    `json:"minCredits,omitempty"`
+	Courses    []RequirementCourseDTO `json:"courses"`
+}
+
+type RequirementCourseDTO struct {
+	CourseID   uint   `json:"courseId"`
+	CourseCode string `json:"courseCode"`
+	CourseName string `json:"courseName"`
+	Credits    int    `json:"credits"`
+}
+
+// StudentProgramDTO links a student to a program, or unlinks it when
+// programId is null
+type StudentProgramDTO struct {
+	ProgramID *uint `json:"programId"`
+}
+
+// DegreeAuditDTO evaluates a student's enrollments against the requirements of
+// their program. Statuses are SATISFIED, IN_PROGRESS (satisfied once current
+// enrollments are passed) or MISSING.
+type DegreeAuditDTO struct {
+	StudentID         uint                  `json:"studentId"`
+	StudentNumber     string                `json:"studentNumber"`
+	StudentName       string                `json:"studentName"`
+	ProgramID         uint                  `json:"programId"`
+	ProgramCode       string                `json:"programCode"`
+	ProgramName       string                `json:"programName"`
+	CatalogYear       int                   `json:"catalogYear"`
+	Status            string                `json:"status"`
+	RequiredCredits   int                   `json:"requiredCredits"`
+	EarnedCredits     int                   `json:"earnedCredits"`
+	InProgressCredits int                   `json:"inProgressCredits"`
+	CreditsStatus     string                `json:"creditsStatus"`
+	Groups            []DegreeAuditGroupDTO `json:"groups"`
+}
+
+type DegreeAuditGroupDTO struct {
+	GroupID           uint                   `json:"groupId"`
+	Name              string                 `json:"name"`
+	Type              string                 `json:"type"`
+	Status            string                 `json:"status"`
+	RequiredCredits   int                    `json:"requiredCredits,omitempty"` // Elective groups only
+	EarnedCredits     int                    `json:"earnedCredits"`
+	InProgressCredits int                    `json:"inProgressCredits"`
+	Courses           []DegreeAuditCourseDTO `json:"courses"`
+}
+
+type DegreeAuditCourseDTO struct {
+	CourseID   uint     `json:"courseId"`
+	CourseCode string   `json:"courseCode"`
+	CourseName string   `json:"courseName"`
+	Credits    int      `json:"credits"`
+	Status     string   `json:"status"`
+	Grade      *float64 `json:"grade"`
+	TermName   string   `json:"termName,omitempty"`
+}
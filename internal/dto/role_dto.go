@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+type RoleCreateDTO struct {
+	Name        string   `json:"name" binding:"required,max=50"`
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"dive,required"`
+}
+
+// RoleUpdateDTO replaces the description and the whole permission set of a role
+type RoleUpdateDTO struct {
+	Description string   `json:"description" binding:"max=255"`
+	Permissions []string `json:"permissions" binding:"dive,required"`
+}
+
+type RoleResponseDTO struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	BuiltIn     bool      `json:"builtIn"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type UserRoleUpdateDTO struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// PermissionsResponseDTO lists the effective permissions of the current user
+type PermissionsResponseDTO struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
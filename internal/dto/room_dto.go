@@ -0,0 +1,69 @@
+package dto
+
+import "time"
+
+type RoomCreateDTO struct {
+	Building string   `json:"building" binding:"required,max=100"`
+	Number   string   `json:"number" binding:"required,max=20"`
+	Capacity int      `json:"capacity" binding:"required,min=1"`
+	Features []string `json:"features" binding:"dive,required,max=50"`
+}
+
+// RoomUpdateDTO changes the given fields of a room. Features, when given,
+// replace the current list.
+type RoomUpdateDTO struct {
+	Building *string   `json:"building" binding:"omitempty,max=100"`
+	Number   *string   `json:"number" binding:"omitempty,max=20"`
+	Capacity *int      `json:"capacity" binding:"omitempty,min=1"`
+	Features *[]string `json:"features" binding:"omitempty,dive,required,max=50"`
+}
+
+type RoomResponseDTO struct {
+	ID       uint     `json:"id"`
+	Building string   `json:"building"`
+	Number   string   `json:"number"`
+	Label    string   `json:"label"`
+	Capacity int      `json:"capacity"`
+	Features []string `json:"features"`
+}
+
+// RoomAvailabilityQueryDTO searches the rooms free on a date between two
+// times, with times written as "HH:MM"
+type RoomAvailabilityQueryDTO struct {
+	Date     time.Time
+	Start    string
+	End      string
+	Capacity int
+	Features []string
+}
+
+type RoomBookingCreateDTO struct {
+	Title    string    `json:"title" binding:"required,max=200"`
+	Purpose  string    `json:"purpose" binding:"required,oneof=EXAM EVENT OTHER"`
+	CourseID *uint     `json:"courseId"`
+	StartsAt time.Time `json:"startsAt" binding:"required"`
+	EndsAt   time.Time `json:"endsAt" binding:"required"`
+}
+
+type RoomBookingResponseDTO struct {
+	ID        uint      `json:"id"`
+	RoomID    uint      `json:"roomId"`
+	RoomLabel string    `json:"roomLabel"`
+	Title     string    `json:"title"`
+	Purpose   string    `json:"purpose"`
+	CourseID  *uint     `json:"courseId"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	BookedBy  uint      `json:"bookedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RoomConflictDTO is a course meeting or booking that already occupies a room
+type RoomConflictDTO struct {
+	RoomID    uint   `json:"roomId"`
+	RoomLabel string `json:"roomLabel"`
+	CourseID  uint   `json:"courseId,omitempty"`
+	BookingID uint   `json:"bookingId,omitempty"`
+	Title     string `json:"title"`
+	When      string `json:"when"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+// ScheduleJobCreateDTO starts the timetable generator for the courses of a
+// term. Unset fields fall back to the defaults documented on each field.
+type ScheduleJobCreateDTO struct {
+	Days            []string `json:"days" binding:"omitempty,dive,oneof=MON TUE WED THU FRI SAT SUN"` // Defaults to MON-FRI
+	DayStart        string   `json:"dayStart"`                                                        // Defaults to 08:00
+	DayEnd          string   `json:"dayEnd"`                                                          // Defaults to 18:00
+	Step            int      `json:"step" binding:"omitempty,min=5,max=240"`                          // Minutes between start times, defaults to 30
+	MeetingsPerWeek int      `json:"meetingsPerWeek" binding:"omitempty,min=1,max=7"`                 // Defaults to 2
+	Duration        int      `json:"duration" binding:"omitempty,min=15,max=600"`                     // Minutes per meeting, defaults to 90
+	// KeepExisting leaves courses that already have meetings as they are
+	KeepExisting bool                  `json:"keepExisting"`
+	Courses      []CourseSchedulingDTO `json:"courses" binding:"dive"`
+}
+
+// CourseSchedulingDTO overrides the defaults for one course of the term
+type CourseSchedulingDTO struct {
+	CourseID        uint     `json:"courseId" binding:"required"`
+	MeetingsPerWeek int      `json:"meetingsPerWeek" binding:"omitempty,min=1,max=7"`
+	Duration        int      `json:"duration" binding:"omitempty,min=15,max=600"`
+	Features        []string `json:"features" binding:"dive,required,max=50"` // Room features the course needs
+	Exclude         bool     `json:"exclude"`                                 // Leave the course as it is
+}
+
+type ScheduleJobResponseDTO struct {
+	ID         uint       `json:"id"`
+	TermID     uint       `json:"termId"`
+	Status     string     `json:"status"`
+	Progress   int        `json:"progress"`
+	Error      string     `json:"error,omitempty"`
+	CreatedBy  uint       `json:"createdBy"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	StartedAt  *time.Time `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt"`
+	AppliedAt  *time.Time `json:"appliedAt"`
+}
+
+// SchedulePreviewDTO is the timetable proposed by a job. Nothing is changed
+// until it is applied.
+type SchedulePreviewDTO struct {
+	JobID       uint                   `json:"jobId"`
+	TermID      uint                   `json:"termId"`
+	Courses     []ScheduledCourseDTO   `json:"courses"`
+	Unscheduled []UnscheduledCourseDTO `json:"unscheduled"`
+	Cost        int                    `json:"cost"` // Penalty of broken soft constraints, lower is better
+}
+
+type ScheduledCourseDTO struct {
+	CourseID   uint               `json:"courseId"`
+	CourseCode string             `json:"courseCode"`
+	CourseName string             `json:"courseName"`
+	TeacherID  uint               `json:"teacherId"`
+	Meetings   []CourseMeetingDTO `json:"meetings"`
+}
+
+type UnscheduledCourseDTO struct {
+	CourseID   uint   `json:"courseId"`
+	CourseCode string `json:"courseCode"`
+	Reason     string `json:"reason"`
+}
+
+// TeacherPreferredSlotDTO is a weekly time a teacher would like to teach at
+type TeacherPreferredSlotDTO struct {
+	Days      []string `json:"days" binding:"required,min=1,dive,oneof=MON TUE WED THU FRI SAT SUN"`
+	StartTime string   `json:"startTime" binding:"required"`
+	EndTime   string   `json:"endTime" binding:"required"`
+}
+
+// TeacherPreferencesDTO replaces the preferred slots of a teacher. An empty
+// list removes them.
+type TeacherPreferencesDTO struct {
+	Slots []TeacherPreferredSlotDTO `json:"slots" binding:"dive"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// UnlockRequest clears failed login tracking for an account, an IP address or both
+type UnlockRequest struct {
+	Email string `json:"email" binding:"required_without=IP,omitempty,email"`
+	IP    string `json:"ip" binding:"omitempty,ip"`
+}
+
+type SecurityEventResponseDTO struct {
+	ID        uint      `json:"id"`
+	Type      string    `json:"type"`
+	UserID    *uint     `json:"userId"`
+	Email     string    `json:"email"`
+	IP        string    `json:"ip"`
+	ActorID   *uint     `json:"actorId"`
+	Details   string    `json:"details"`
+	CreatedAt time.Time `json:"createdAt"`
+}
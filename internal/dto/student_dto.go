@@ -19,6 +19,7 @@ type StudentResponseDTO struct {
 	StudentID  string `json:"studentId"`
 	EnrollYear int    `json:"enrollYear"`
 	Major      string `json:"major"`
+	ProgramID  *uint  `json:"programId"`
 }
 
 type StudentUpdateDTO struct {
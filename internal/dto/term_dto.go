@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+type TermCreateDTO struct {
+	Name            string    `json:"name" binding:"required,min=2,max=100"`
+	StartDate       time.Time `json:"startDate" binding:"required"`
+	EndDate         time.Time `json:"endDate" binding:"required,date_range"`
+	AddDropDeadline time.Time `json:"addDropDeadline" binding:"required"`
+	GradingDeadline time.Time `json:"gradingDeadline" binding:"required"`
+}
+
+type TermResponseDTO struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	StartDate       time.Time `json:"startDate"`
+	EndDate         time.Time `json:"endDate"`
+	AddDropDeadline time.Time `json:"addDropDeadline"`
+	GradingDeadline time.Time `json:"gradingDeadline"`
+}
+
+type TermUpdateDTO struct {
+	Name            string    `json:"name" binding:"omitempty,min=2,max=100"`
+	StartDate       time.Time `json:"startDate" binding:"omitempty"`
+	EndDate         time.Time `json:"endDate" binding:"omitempty"`
+	AddDropDeadline time.Time `json:"addDropDeadline" binding:"omitempty"`
+	GradingDeadline time.Time `json:"gradingDeadline" binding:"omitempty"`
+}
+
+type TermCourseMatchResponseDTO struct {
+	TermID         uint `json:"termId"`
+	CoursesMatched int  `json:"coursesMatched"`
+}
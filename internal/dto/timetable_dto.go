@@ -0,0 +1,58 @@
+package dto
+
+// CourseMeetingDTO is a weekly meeting of a course, with times written as "HH:MM"
+type CourseMeetingDTO struct {
+	Days      []string `json:"days" binding:"required,min=1,dive,oneof=MON TUE WED THU FRI SAT SUN"`
+	StartTime string   `json:"startTime" binding:"required"`
+	EndTime   string   `json:"endTime" binding:"required"`
+	RoomID    *uint    `json:"roomId"` // Books the room; takes precedence over room
+	Room      string   `json:"room" binding:"omitempty,max=100"`
+}
+
+// CourseMeetingsUpdateDTO replaces the meeting pattern of a course. An empty
+// list removes it.
+type CourseMeetingsUpdateDTO struct {
+	Meetings []CourseMeetingDTO `json:"meetings" binding:"dive"`
+}
+
+type CourseMeetingResponseDTO struct {
+	ID        uint     `json:"id"`
+	CourseID  uint     `json:"courseId"`
+	Days      []string `json:"days"`
+	StartTime string   `json:"startTime"`
+	EndTime   string   `json:"endTime"`
+	RoomID    *uint    `json:"roomId"`
+	Room      string   `json:"room"`
+}
+
+// ScheduleConflictDTO is a meeting of another course that overlaps with the
+// course being scheduled or enrolled in
+type ScheduleConflictDTO struct {
+	CourseID   uint     `json:"courseId"`
+	CourseCode string   `json:"courseCode"`
+	CourseName string   `json:"courseName"`
+	Days       []string `json:"days"` // Days on which both courses meet
+	StartTime  string   `json:"startTime"`
+	EndTime    string   `json:"endTime"`
+}
+
+// TimetableDTO is the weekly grid of a student or teacher, Monday to Sunday
+type TimetableDTO struct {
+	Days []TimetableDayDTO `json:"days"`
+}
+
+type TimetableDayDTO struct {
+	Day     string              `json:"day"`
+	Entries []TimetableEntryDTO `json:"entries"`
+}
+
+type TimetableEntryDTO struct {
+	CourseID    uint   `json:"courseId"`
+	CourseCode  string `json:"courseCode"`
+	CourseName  string `json:"courseName"`
+	TeacherName string `json:"teacherName"`
+	StartTime   string `json:"startTime"`
+	EndTime     string `json:"endTime"`
+	Room        string `json:"room"`
+	Conflict    bool   `json:"conflict"` // Overlaps with another entry of the day
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+type TranscriptCourseDTO struct {
+	CourseID    uint     `json:"courseId"`
+	CourseCode  string   `json:"courseCode"`
+	CourseName  string   `json:"courseName"`
+	Credits     int      `json:"credits"`
+	Status      string   `json:"status"`
+	Grade       *float64 `json:"grade"`
+	LetterGrade string   `json:"letterGrade"` // "W" for withdrawals, "IP" while in progress
+	GradePoints *float64 `json:"gradePoints"`
+}
+
+type TranscriptTermDTO struct {
+	TermID           *uint                 `json:"termId"`
+	TermName         string                `json:"termName"`
+	Courses          []TranscriptCourseDTO `json:"courses"`
+	AttemptedCredits int                   `json:"attemptedCredits"`
+	EarnedCredits    int                   `json:"earnedCredits"`
+	GPA              float64               `json:"gpa"`
+}
+
+type TranscriptDTO struct {
+	StudentID        uint                `json:"studentId"`
+	StudentNumber    string              `json:"studentNumber"`
+	StudentName      string              `json:"studentName"`
+	Major            string              `json:"major"`
+	Terms            []TranscriptTermDTO `json:"terms"`
+	AttemptedCredits int                 `json:"attemptedCredits"`
+	EarnedCredits    int                 `json:"earnedCredits"`
+	CumulativeGPA    float64             `json:"cumulativeGpa"`
+}
+
+type DocumentVerificationDTO struct {
+	Code          string    `json:"code"`
+	Valid         bool      `json:"valid"`
+	Type          string    `json:"type"`
+	StudentName   string    `json:"studentName"`
+	StudentNumber string    `json:"studentNumber"`
+	IssuedAt      time.Time `json:"issuedAt"`
+	ContentHash   string    `json:"contentHash"` // SHA-256 of the issued PDF
+}
@@ -0,0 +1,33 @@
+package dto
+
+type TwoFactorChallengeRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+}
+
+// TwoFactorVerifyRequest completes a login with either an authenticator code or a recovery code
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challengeToken" binding:"required"`
+	Code           string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode   string `json:"recoveryCode"`
+}
+
+type TwoFactorActivateRequest struct {
+	ChallengeToken string `json:"challengeToken"` // Only used during login enrollment
+	Code           string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// TwoFactorDisableRequest requires the password and a current code, so a
+// stolen session alone cannot turn off the second factor
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type TwoFactorSetupDTO struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthUri"`
+}
+
+type TwoFactorActivatedDTO struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
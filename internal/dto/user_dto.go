@@ -1,9 +1,10 @@
 package dto
 
 type UserDTO struct {
-	ID        uint   `json:"id"`
-	Email     string `json:"email"`
-	FirstName string `json:"firstName"`
-	LastName  string `json:"lastName"`
-	Role      string `json:"role"`
+	ID            uint   `json:"id"`
+	Email         string `json:"email"`
+	FirstName     string `json:"firstName"`
+	LastName      string `json:"lastName"`
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"emailVerified"`
 }
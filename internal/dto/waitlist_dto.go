@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type WaitlistEntryResponseDTO struct {
+	ID          uint      `json:"id"`
+	StudentID   uint      `json:"studentId"`
+	StudentName string    `json:"studentName"`
+	CourseID    uint      `json:"courseId"`
+	CourseName  string    `json:"courseName"`
+	CourseCode  string    `json:"courseCode"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
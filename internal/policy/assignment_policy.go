@@ -0,0 +1,17 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanManageAssignments allows the graders of a course to create assignments
+// and to grade submissions
+func CanManageAssignments(subject Subject, course *domain.Course) bool {
+	return CanGradeCourse(subject, course)
+}
+
+// CanViewSubmission allows students to see their own submissions and the
+// graders of the course to see every submission
+func CanViewSubmission(subject Subject, submission *domain.Submission) bool {
+	return subject.IsStudent(submission.StudentID) || CanManageAssignments(subject, &submission.Assignment.Course)
+}
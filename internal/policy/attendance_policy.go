@@ -0,0 +1,21 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanTakeAttendance allows attendance:write-all holders to schedule sessions and
+// mark attendance in any course and attendance:write holders to do so in the
+// courses they teach
+func CanTakeAttendance(subject Subject, course *domain.Course) bool {
+	if subject.Can(domain.PermissionAttendanceWriteAll) {
+		return true
+	}
+	return subject.Can(domain.PermissionAttendanceWrite) && subject.Teaches(course.TeacherID)
+}
+
+// CanViewAttendance allows students to see their own attendance and the
+// teachers taking attendance in the course to see everyone's
+func CanViewAttendance(subject Subject, enrollment *domain.Enrollment) bool {
+	return CanViewEnrollment(subject, enrollment) || CanTakeAttendance(subject, &enrollment.Course)
+}
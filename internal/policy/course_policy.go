@@ -0,0 +1,35 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanCreateCourse allows course:update-all holders to create any course and
+// course:create holders to create courses they teach themselves
+func CanCreateCourse(subject Subject, teacherID uint) bool {
+	if subject.Can(domain.PermissionCourseUpdateAll) {
+		return true
+	}
+	return subject.Can(domain.PermissionCourseCreate) && subject.Teaches(teacherID)
+}
+
+// CanManageCourse allows course:update-all holders to modify any course and
+// course:update-own holders to modify the courses they teach
+func CanManageCourse(subject Subject, course *domain.Course) bool {
+	if subject.Can(domain.PermissionCourseUpdateAll) {
+		return true
+	}
+	return subject.Can(domain.PermissionCourseUpdateOwn) && subject.Teaches(course.TeacherID)
+}
+
+// CanReassignCourse allows only course:update-all holders to hand a course over
+// to another teacher
+func CanReassignCourse(subject Subject, course *domain.Course, teacherID uint) bool {
+	return subject.Can(domain.PermissionCourseUpdateAll) || teacherID == course.TeacherID
+}
+
+// CanViewWaitlist allows waitlist:read holders to read any waitlist and teachers
+// who manage the enrollments of a course to read its waitlist
+func CanViewWaitlist(subject Subject, course *domain.Course) bool {
+	return subject.Can(domain.PermissionWaitlistRead) || CanManageEnrollment(subject, course)
+}
@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+func TestCanManageCourse(t *testing.T) {
+	course := &domain.Course{ID: 1, TeacherID: ownerTeacherID}
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    bool
+	}{
+		{"admin", admin, true},
+		{"owning teacher", ownerTeacher, true},
+		{"other teacher", otherTeacher, false},
+		{"owning student", ownerStudent, false},
+		{"other student", otherStudent, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanManageCourse(tt.subject, course); got != tt.want {
+				t.Errorf("CanManageCourse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanReassignCourse(t *testing.T) {
+	course := &domain.Course{ID: 1, TeacherID: ownerTeacherID}
+
+	tests := []struct {
+		name      string
+		subject   Subject
+		teacherID uint
+		want      bool
+	}{
+		{"admin", admin, otherTeacherID, true},
+		{"owning teacher", ownerTeacher, otherTeacherID, false},
+		{"other teacher", otherTeacher, otherTeacherID, false},
+		{"owning student", ownerStudent, otherTeacherID, false},
+		{"other student", otherStudent, otherTeacherID, false},
+		// Keeping the current teacher is not a reassignment
+		{"owning teacher keeps course", ownerTeacher, ownerTeacherID, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanReassignCourse(tt.subject, course, tt.teacherID); got != tt.want {
+				t.Errorf("CanReassignCourse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanViewEnrollment allows staff to read any enrollment and students to read their own
+func CanViewEnrollment(subject Subject, enrollment *domain.Enrollment) bool {
+	return CanViewStudent(subject, enrollment.StudentID)
+}
+
+// CanManageEnrollment allows enrollment:manage-all holders to enroll, drop and
+// update students in any course and enrollment:manage-own holders to do so in
+// the courses they teach
+func CanManageEnrollment(subject Subject, course *domain.Course) bool {
+	if subject.Can(domain.PermissionEnrollmentManageAll) {
+		return true
+	}
+	return subject.Can(domain.PermissionEnrollmentManageOwn) && subject.Teaches(course.TeacherID)
+}
+
+// CanGradeCourse allows grade:write-all holders to grade any course and
+// grade:write holders to grade the courses they teach
+func CanGradeCourse(subject Subject, course *domain.Course) bool {
+	if subject.Can(domain.PermissionGradeWriteAll) {
+		return true
+	}
+	return subject.Can(domain.PermissionGradeWrite) && subject.Teaches(course.TeacherID)
+}
+
+// CanGradeEnrollment allows recording a grade in a course the subject may grade
+func CanGradeEnrollment(subject Subject, enrollment *domain.Enrollment) bool {
+	return CanGradeCourse(subject, &enrollment.Course)
+}
+
+// CanReviewGradeChange allows grade:approve-change-all holders to decide any
+// request and grade:approve-change holders to decide requests in courses of the
+// department they head. Nobody reviews their own request. The request needs its
+// enrollment's course and department loaded.
+func CanReviewGradeChange(subject Subject, request *domain.GradeChangeRequest) bool {
+	if request.RequestedBy == subject.UserID {
+		return false
+	}
+	if subject.Can(domain.PermissionGradeApproveChangeAll) {
+		return true
+	}
+	return subject.Can(domain.PermissionGradeApproveChange) && subject.Heads(request.Enrollment.Course.Department)
+}
@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+func testEnrollment() *domain.Enrollment {
+	return &domain.Enrollment{
+		ID:        1,
+		StudentID: ownerStudentID,
+		CourseID:  1,
+		Course:    domain.Course{ID: 1, TeacherID: ownerTeacherID},
+	}
+}
+
+func TestCanViewEnrollment(t *testing.T) {
+	enrollment := testEnrollment()
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    bool
+	}{
+		{"admin", admin, true},
+		{"owning teacher", ownerTeacher, true},
+		{"other teacher", otherTeacher, true},
+		{"owning student", ownerStudent, true},
+		{"other student", otherStudent, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanViewEnrollment(tt.subject, enrollment); got != tt.want {
+				t.Errorf("CanViewEnrollment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanGradeEnrollment(t *testing.T) {
+	enrollment := testEnrollment()
+
+	tests := []struct {
+		name    string
+		subject Subject
+		want    bool
+	}{
+		{"admin", admin, true},
+		{"owning teacher", ownerTeacher, true},
+		{"other teacher", otherTeacher, false},
+		{"owning student", ownerStudent, false},
+		{"other student", otherStudent, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanGradeEnrollment(tt.subject, enrollment); got != tt.want {
+				t.Errorf("CanGradeEnrollment() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// gradeChangeIn returns a request made by the user for a course of the department
+func gradeChangeIn(department *domain.Department, requestedBy uint) *domain.GradeChangeRequest {
+	enrollment := testEnrollment()
+	if department != nil {
+		enrollment.Course.DepartmentID = &department.ID
+		enrollment.Course.Department = department
+	}
+	return &domain.GradeChangeRequest{ID: 1, EnrollmentID: enrollment.ID, Enrollment: *enrollment, NewGrade: 90, RequestedBy: requestedBy}
+}
+
+func TestCanReviewGradeChange(t *testing.T) {
+	head := uint(headTeacherID)
+	department := &domain.Department{ID: 1, Code: "CSC", HeadTeacherID: &head}
+
+	// Requested by the teacher of the course
+	request := gradeChangeIn(department, ownerTeacher.UserID)
+
+	tests := []struct {
+		name    string
+		subject Subject
+		request *domain.GradeChangeRequest
+		want    bool
+	}{
+		{"admin", admin, request, true},
+		{"owning teacher", ownerTeacher, request, false},
+		{"other teacher", otherTeacher, request, false},
+		{"owning student", ownerStudent, request, false},
+		{"other student", otherStudent, request, false},
+		{"department head", departmentHead, request, true},
+		{"head of another department", otherHead, request, false},
+		{"department head reviewing own request", departmentHead, gradeChangeIn(department, departmentHead.UserID), false},
+		{"department head, course without department", departmentHead, gradeChangeIn(nil, ownerTeacher.UserID), false},
+		{"admin, course without department", admin, gradeChangeIn(nil, ownerTeacher.UserID), true},
+		{"admin reviewing own request", admin, gradeChangeIn(department, admin.UserID), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanReviewGradeChange(tt.subject, tt.request); got != tt.want {
+				t.Errorf("CanReviewGradeChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanCancelBooking allows room managers to cancel any booking and everyone else
+// to cancel the bookings they made
+func CanCancelBooking(subject Subject, booking *domain.RoomBooking) bool {
+	return subject.Can(domain.PermissionRoomManage) || booking.BookedBy == subject.UserID
+}
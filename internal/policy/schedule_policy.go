@@ -0,0 +1,11 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanManagePreferences allows teachers to set their own preferred teaching
+// times and schedule:generate holders to set anyone's
+func CanManagePreferences(subject Subject, teacherID uint) bool {
+	return subject.Can(domain.PermissionScheduleGenerate) || subject.Teaches(teacherID)
+}
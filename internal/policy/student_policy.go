@@ -0,0 +1,16 @@
+package policy
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// CanListStudents allows student:read-all holders to browse all students
+func CanListStudents(subject Subject) bool {
+	return subject.Can(domain.PermissionStudentReadAll)
+}
+
+// CanViewStudent allows student:read-all holders to read any student record and
+// students to read their own
+func CanViewStudent(subject Subject, studentID uint) bool {
+	return CanListStudents(subject) || subject.IsStudent(studentID)
+}
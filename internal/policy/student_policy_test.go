@@ -0,0 +1,24 @@
+package policy
+
+import "testing"
+
+func TestCanViewStudent(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject Subject
+		want    bool
+	}{
+		{"admin", admin, true},
+		{"owning teacher", ownerTeacher, true},
+		{"other teacher", otherTeacher, true},
+		{"owning student", ownerStudent, true},
+		{"other student", otherStudent, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanViewStudent(tt.subject, ownerStudentID); got != tt.want {
+				t.Errorf("CanViewStudent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
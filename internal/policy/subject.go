@@ -0,0 +1,88 @@
+// Package policy decides whether an authenticated user may act on a resource.
+// Policies are plain functions over domain types so they can be tested without
+// an HTTP context or a database.
+package policy
+
+import (
+	"log"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// Subject is the authenticated user a policy decision is made for
+type Subject struct {
+	UserID      uint
+	Role        domain.Role
+	Permissions map[domain.Permission]bool // Permissions granted by the role
+	StudentID   uint                       // Student profile of the user, 0 if none
+	TeacherID   uint                       // Teacher profile of the user, 0 if none
+}
+
+// Can reports whether the role of the subject grants the permission
+func (s Subject) Can(permission domain.Permission) bool {
+	return s.Permissions[permission]
+}
+
+// Teaches reports whether the subject is the teacher with the given ID
+func (s Subject) Teaches(teacherID uint) bool {
+	return s.TeacherID != 0 && s.TeacherID == teacherID
+}
+
+// Heads reports whether the subject is the head of the department
+func (s Subject) Heads(department *domain.Department) bool {
+	return department != nil && department.HeadTeacherID != nil && s.Teaches(*department.HeadTeacherID)
+}
+
+// IsStudent reports whether the subject is the student with the given ID
+func (s Subject) IsStudent(studentID uint) bool {
+	return s.StudentID != 0 && s.StudentID == studentID
+}
+
+type StudentFinder interface {
+	FindByUserID(userID uint) (*domain.Student, error)
+}
+
+type TeacherFinder interface {
+	FindByUserID(userID uint) (*domain.Teacher, error)
+}
+
+// PermissionSource returns the permissions granted to a role
+type PermissionSource interface {
+	PermissionsFor(role domain.Role) ([]domain.Permission, error)
+}
+
+// Resolver builds subjects from the permissions of the user's role and the
+// profiles linked to the account
+type Resolver struct {
+	permissions PermissionSource
+	students    StudentFinder
+	teachers    TeacherFinder
+}
+
+func NewResolver(permissions PermissionSource, students StudentFinder, teachers TeacherFinder) *Resolver {
+	return &Resolver{permissions: permissions, students: students, teachers: teachers}
+}
+
+// Resolve returns the subject for a user. Profiles are looked up regardless of
+// the role, so e.g. a department head keeps ownership of the courses they teach.
+// Users whose permissions cannot be loaded get none.
+func (r *Resolver) Resolve(userID uint, role domain.Role) Subject {
+	subject := Subject{UserID: userID, Role: role, Permissions: make(map[domain.Permission]bool)}
+
+	permissions, err := r.permissions.PermissionsFor(role)
+	if err != nil {
+		log.Printf("Failed to load permissions of role %s: %v", role, err)
+	}
+	for _, permission := range permissions {
+		subject.Permissions[permission] = true
+	}
+
+	if student, err := r.students.FindByUserID(userID); err == nil {
+		subject.StudentID = student.ID
+	}
+	if teacher, err := r.teachers.FindByUserID(userID); err == nil {
+		subject.TeacherID = teacher.ID
+	}
+
+	return subject
+}
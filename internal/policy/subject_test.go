@@ -0,0 +1,110 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+const (
+	ownerTeacherID = 10
+	otherTeacherID = 11
+	ownerStudentID = 20
+	otherStudentID = 21
+
+	// Teacher IDs of department heads
+	headTeacherID      = 12
+	otherHeadTeacherID = 13
+)
+
+// grant returns the permission set of a subject, mirroring the seeded roles
+func grant(permissions ...domain.Permission) map[domain.Permission]bool {
+	granted := make(map[domain.Permission]bool)
+	for _, permission := range permissions {
+		granted[permission] = true
+	}
+	return granted
+}
+
+var teacherPermissions = []domain.Permission{
+	domain.PermissionStudentReadAll,
+	domain.PermissionCourseCreate,
+	domain.PermissionCourseUpdateOwn,
+	domain.PermissionEnrollmentManageOwn,
+	domain.PermissionGradeWrite,
+}
+
+var headPermissions = []domain.Permission{
+	domain.PermissionStudentReadAll,
+	domain.PermissionCourseUpdateAll,
+	domain.PermissionGradeWriteAll,
+	domain.PermissionGradeApproveChange,
+}
+
+var (
+	admin = Subject{UserID: 1, Role: domain.RoleAdmin, Permissions: grant(domain.AllPermissions...)}
+
+	ownerTeacher = Subject{UserID: 2, Role: domain.RoleTeacher, Permissions: grant(teacherPermissions...), TeacherID: ownerTeacherID}
+	otherTeacher = Subject{UserID: 3, Role: domain.RoleTeacher, Permissions: grant(teacherPermissions...), TeacherID: otherTeacherID}
+
+	ownerStudent = Subject{UserID: 4, Role: domain.RoleStudent, Permissions: grant(), StudentID: ownerStudentID}
+	otherStudent = Subject{UserID: 5, Role: domain.RoleStudent, Permissions: grant(), StudentID: otherStudentID}
+
+	departmentHead = Subject{UserID: 6, Role: domain.RoleDepartmentHead, Permissions: grant(headPermissions...), TeacherID: headTeacherID}
+	otherHead      = Subject{UserID: 7, Role: domain.RoleDepartmentHead, Permissions: grant(headPermissions...), TeacherID: otherHeadTeacherID}
+)
+
+func TestSubjectHeads(t *testing.T) {
+	head := uint(headTeacherID)
+	department := &domain.Department{ID: 1, Code: "CSC", HeadTeacherID: &head}
+
+	tests := []struct {
+		name       string
+		subject    Subject
+		department *domain.Department
+		want       bool
+	}{
+		{"head", departmentHead, department, true},
+		{"head of another department", otherHead, department, false},
+		{"teacher", ownerTeacher, department, false},
+		{"student", ownerStudent, department, false},
+		{"department without head", departmentHead, &domain.Department{ID: 2, Code: "MAT"}, false},
+		{"no department", departmentHead, nil, false},
+		{"subject without teacher profile", Subject{UserID: 8}, &domain.Department{ID: 3, Code: "PHY", HeadTeacherID: new(uint)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.subject.Heads(tt.department); got != tt.want {
+				t.Errorf("Heads() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubjectCan(t *testing.T) {
+	tests := []struct {
+		name       string
+		subject    Subject
+		permission domain.Permission
+		want       bool
+	}{
+		{"admin", admin, domain.PermissionCourseUpdateAll, true},
+		{"admin", admin, domain.PermissionAuditRead, true},
+		{"owning teacher", ownerTeacher, domain.PermissionCourseUpdateOwn, true},
+		{"owning teacher", ownerTeacher, domain.PermissionCourseUpdateAll, false},
+		{"other teacher", otherTeacher, domain.PermissionGradeWrite, true},
+		{"other teacher", otherTeacher, domain.PermissionGradeWriteAll, false},
+		{"owning student", ownerStudent, domain.PermissionStudentReadAll, false},
+		{"other student", otherStudent, domain.PermissionCourseCreate, false},
+		{"department head", departmentHead, domain.PermissionGradeApproveChange, true},
+		{"department head", departmentHead, domain.PermissionGradeApproveChangeAll, false},
+		{"no permissions loaded", Subject{UserID: 8, Role: domain.RoleTeacher}, domain.PermissionGradeWrite, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+string(tt.permission), func(t *testing.T) {
+			if got := tt.subject.Can(tt.permission); got != tt.want {
+				t.Errorf("Can(%s) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
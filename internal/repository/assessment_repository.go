@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm/clause"
+)
+
+type AssessmentRepository struct {
+	*Repository
+}
+
+func NewAssessmentRepository(repo *Repository) *AssessmentRepository {
+	return &AssessmentRepository{Repository: repo}
+}
+
+func (r *AssessmentRepository) CreateComponent(component *domain.AssessmentComponent) error {
+	return r.db.Create(component).Error
+}
+
+func (r *AssessmentRepository) FindComponentByID(id uint) (*domain.AssessmentComponent, error) {
+	var component domain.AssessmentComponent
+	if err := r.db.First(&component, id).Error; err != nil {
+		return nil, err
+	}
+	return &component, nil
+}
+
+func (r *AssessmentRepository) FindComponentsByCourseID(courseID uint) ([]domain.AssessmentComponent, error) {
+	var components []domain.AssessmentComponent
+	if err := r.db.Where("course_id = ?", courseID).Order("id ASC").Find(&components).Error; err != nil {
+		return nil, err
+	}
+	return components, nil
+}
+
+func (r *AssessmentRepository) CountComponents(courseID uint) (int, error) {
+	var count int64
+	if err := r.db.Model(&domain.AssessmentComponent{}).Where("course_id = ?", courseID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *AssessmentRepository) UpdateComponent(component *domain.AssessmentComponent) error {
+	return r.db.Save(component).Error
+}
+
+// DeleteComponent removes a component together with its scores
+func (r *AssessmentRepository) DeleteComponent(id uint) error {
+	if err := r.db.Where("component_id = ?", id).Delete(&domain.AssessmentScore{}).Error; err != nil {
+		return err
+	}
+	return r.db.Delete(&domain.AssessmentComponent{}, id).Error
+}
+
+// FindScoresByCourseID lists the scores on every component of a course
+func (r *AssessmentRepository) FindScoresByCourseID(courseID uint) ([]domain.AssessmentScore, error) {
+	var scores []domain.AssessmentScore
+	if err := r.db.
+		Joins("JOIN assessment_components ON assessment_components.id = assessment_scores.component_id").
+		Where("assessment_components.course_id = ?", courseID).
+		Order("assessment_scores.enrollment_id, assessment_scores.component_id, assessment_scores.item").
+		Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+func (r *AssessmentRepository) FindScore(componentID, enrollmentID uint, item int) (*domain.AssessmentScore, error) {
+	var score domain.AssessmentScore
+	if err := r.db.Where("component_id = ? AND enrollment_id = ? AND item = ?", componentID, enrollmentID, item).First(&score).Error; err != nil {
+		return nil, err
+	}
+	return &score, nil
+}
+
+// SaveScore creates the score of an item or replaces the existing one
+func (r *AssessmentRepository) SaveScore(score *domain.AssessmentScore) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "component_id"}, {Name: "enrollment_id"}, {Name: "item"}},
+		DoUpdates: clause.AssignmentColumns([]string{"score", "graded_by", "updated_at"}),
+	}).Create(score).Error
+}
+
+func (r *AssessmentRepository) DeleteScore(id uint) error {
+	return r.db.Delete(&domain.AssessmentScore{}, id).Error
+}
+
+// DeleteScoresBeyond removes the scores of items a component no longer has
+func (r *AssessmentRepository) DeleteScoresBeyond(componentID uint, itemCount int) error {
+	return r.db.Where("component_id = ? AND item > ?", componentID, itemCount).Delete(&domain.AssessmentScore{}).Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type AssignmentRepository struct {
+	*Repository
+}
+
+func NewAssignmentRepository(repo *Repository) *AssignmentRepository {
+	return &AssignmentRepository{Repository: repo}
+}
+
+func (r *AssignmentRepository) Create(assignment *domain.Assignment) error {
+	return r.db.Create(assignment).Error
+}
+
+func (r *AssignmentRepository) FindByID(id uint) (*domain.Assignment, error) {
+	var assignment domain.Assignment
+	if err := r.db.Preload("Course.Term").First(&assignment, id).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *AssignmentRepository) FindByCourseID(courseID uint) ([]domain.Assignment, error) {
+	var assignments []domain.Assignment
+	if err := r.db.Where("course_id = ?", courseID).Order("due_at ASC, id ASC").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// FindByCourseIDs loads the assignments of several courses at once
+func (r *AssignmentRepository) FindByCourseIDs(courseIDs []uint) ([]domain.Assignment, error) {
+	var assignments []domain.Assignment
+	if len(courseIDs) == 0 {
+		return assignments, nil
+	}
+	if err := r.db.Where("course_id IN ?", courseIDs).Order("due_at ASC, id ASC").Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (r *AssignmentRepository) Update(assignment *domain.Assignment) error {
+	return r.db.Omit("Course").Save(assignment).Error
+}
+
+func (r *AssignmentRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Assignment{}, id).Error
+}
+
+func (r *AssignmentRepository) CreateSubmission(submission *domain.Submission) error {
+	return r.db.Omit("Assignment", "Student").Create(submission).Error
+}
+
+func (r *AssignmentRepository) FindSubmissionByID(id uint) (*domain.Submission, error) {
+	var submission domain.Submission
+	if err := r.db.Preload("Assignment.Course.Term").Preload("Student.User").First(&submission, id).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *AssignmentRepository) FindSubmission(assignmentID, studentID uint) (*domain.Submission, error) {
+	var submission domain.Submission
+	if err := r.db.Preload("Student.User").Where("assignment_id = ? AND student_id = ?", assignmentID, studentID).First(&submission).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *AssignmentRepository) FindSubmissionsByAssignmentID(assignmentID uint) ([]domain.Submission, error) {
+	var submissions []domain.Submission
+	if err := r.db.Preload("Student.User").Where("assignment_id = ?", assignmentID).Order("submitted_at ASC").Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+func (r *AssignmentRepository) UpdateSubmission(submission *domain.Submission) error {
+	return r.db.Omit("Assignment", "Student").Save(submission).Error
+}
+
+// DeleteSubmissions removes the submissions of an assignment and returns the
+// storage keys of their files
+func (r *AssignmentRepository) DeleteSubmissions(assignmentID uint) ([]string, error) {
+	var keys []string
+	if err := r.db.Model(&domain.Submission{}).Where("assignment_id = ?", assignmentID).Pluck("storage_key", &keys).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where("assignment_id = ?", assignmentID).Delete(&domain.Submission{}).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm/clause"
+)
+
+type AttendanceRepository struct {
+	*Repository
+}
+
+func NewAttendanceRepository(repo *Repository) *AttendanceRepository {
+	return &AttendanceRepository{Repository: repo}
+}
+
+func (r *AttendanceRepository) CreateSession(session *domain.ClassSession) error {
+	return r.db.Omit("Course").Create(session).Error
+}
+
+func (r *AttendanceRepository) FindSessionByID(id uint) (*domain.ClassSession, error) {
+	var session domain.ClassSession
+	if err := r.db.Preload("Course.Teacher.User").First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *AttendanceRepository) FindSessionsByCourseID(courseID uint) ([]domain.ClassSession, error) {
+	var sessions []domain.ClassSession
+	if err := r.db.Where("course_id = ?", courseID).Order("starts_at ASC").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (r *AttendanceRepository) UpdateSession(session *domain.ClassSession) error {
+	return r.db.Omit("Course").Save(session).Error
+}
+
+// DeleteSession removes a session together with its attendance records
+func (r *AttendanceRepository) DeleteSession(id uint) error {
+	if err := r.db.Where("session_id = ?", id).Delete(&domain.AttendanceRecord{}).Error; err != nil {
+		return err
+	}
+	return r.db.Delete(&domain.ClassSession{}, id).Error
+}
+
+// SaveRecord creates the attendance of an enrollment at a session or replaces
+// the existing one
+func (r *AttendanceRepository) SaveRecord(record *domain.AttendanceRecord) error {
+	return r.db.Omit("Session").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "session_id"}, {Name: "enrollment_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "note", "marked_by", "updated_at"}),
+	}).Create(record).Error
+}
+
+func (r *AttendanceRepository) FindRecordsBySessionID(sessionID uint) ([]domain.AttendanceRecord, error) {
+	var records []domain.AttendanceRecord
+	if err := r.db.Where("session_id = ?", sessionID).Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// FindRecordsByEnrollmentID lists the attendance of an enrollment in session order
+func (r *AttendanceRepository) FindRecordsByEnrollmentID(enrollmentID uint) ([]domain.AttendanceRecord, error) {
+	var records []domain.AttendanceRecord
+	if err := r.db.Preload("Session").
+		Joins("JOIN class_sessions ON class_sessions.id = attendance_records.session_id").
+		Where("attendance_records.enrollment_id = ?", enrollmentID).
+		Order("class_sessions.starts_at ASC").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// FindRecordsByCourseID lists the attendance at every session of a course
+func (r *AttendanceRepository) FindRecordsByCourseID(courseID uint) ([]domain.AttendanceRecord, error) {
+	var records []domain.AttendanceRecord
+	if err := r.db.
+		Joins("JOIN class_sessions ON class_sessions.id = attendance_records.session_id").
+		Where("class_sessions.course_id = ?", courseID).
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *AttendanceRepository) CountAbsences(enrollmentID uint) (int, error) {
+	var count int64
+	if err := r.db.Model(&domain.AttendanceRecord{}).Where("enrollment_id = ? AND status = ?", enrollmentID, domain.AttendanceAbsent).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// CreateAlert records an absence alert. It returns false when the enrollment
+// has been alerted before.
+func (r *AttendanceRepository) CreateAlert(alert *domain.AttendanceAlert) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+// AuditLogFilter narrows down audit log queries. Zero values are ignored.
+type AuditLogFilter struct {
+	ActorID    uint
+	EntityType string
+	EntityID   uint
+	Action     string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+	Offset     int
+}
+
+type AuditLogRepository struct {
+	*Repository
+}
+
+func NewAuditLogRepository(repo *Repository) *AuditLogRepository {
+	return &AuditLogRepository{Repository: repo}
+}
+
+func (r *AuditLogRepository) Create(entry *domain.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// FindAll returns the newest entries first
+func (r *AuditLogRepository) FindAll(filter AuditLogFilter) ([]domain.AuditLog, error) {
+	query := r.db.Order("created_at DESC, id DESC")
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var entries []domain.AuditLog
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
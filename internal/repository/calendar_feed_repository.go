@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarFeedRepository struct {
+	*Repository
+}
+
+func NewCalendarFeedRepository(repo *Repository) *CalendarFeedRepository {
+	return &CalendarFeedRepository{Repository: repo}
+}
+
+// Save stores the feed of a user, replacing the token of an existing feed
+func (r *CalendarFeedRepository) Save(feed *domain.CalendarFeed) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"token_hash": feed.TokenHash, "created_at": feed.CreatedAt, "last_used_at": nil}),
+	}).Create(feed).Error
+}
+
+func (r *CalendarFeedRepository) FindByUserID(userID uint) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := r.db.Where("user_id = ?", userID).First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *CalendarFeedRepository) FindByHash(tokenHash string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *CalendarFeedRepository) Touch(id uint, at time.Time) error {
+	return r.db.Model(&domain.CalendarFeed{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+// DeleteByUserID revokes the feed of a user. It returns false when there was none.
+func (r *CalendarFeedRepository) DeleteByUserID(userID uint) (bool, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&domain.CalendarFeed{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
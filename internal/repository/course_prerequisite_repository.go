@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type CoursePrerequisiteRepository struct {
+	*Repository
+}
+
+func NewCoursePrerequisiteRepository(repo *Repository) *CoursePrerequisiteRepository {
+	return &CoursePrerequisiteRepository{Repository: repo}
+}
+
+func (r *CoursePrerequisiteRepository) Create(prerequisite *domain.CoursePrerequisite) error {
+	return r.db.Create(prerequisite).Error
+}
+
+func (r *CoursePrerequisiteRepository) FindAll() ([]domain.CoursePrerequisite, error) {
+	var prerequisites []domain.CoursePrerequisite
+	if err := r.db.Find(&prerequisites).Error; err != nil {
+		return nil, err
+	}
+	return prerequisites, nil
+}
+
+func (r *CoursePrerequisiteRepository) FindByCourseID(courseID uint) ([]domain.CoursePrerequisite, error) {
+	var prerequisites []domain.CoursePrerequisite
+	if err := r.db.Preload("Prerequisite").Where("course_id = ?", courseID).Find(&prerequisites).Error; err != nil {
+		return nil, err
+	}
+	return prerequisites, nil
+}
+
+func (r *CoursePrerequisiteRepository) FindByCourseAndPrerequisite(courseID, prerequisiteID uint) (*domain.CoursePrerequisite, error) {
+	var prerequisite domain.CoursePrerequisite
+	if err := r.db.Preload("Prerequisite").Where("course_id = ? AND prerequisite_id = ?", courseID, prerequisiteID).First(&prerequisite).Error; err != nil {
+		return nil, err
+	}
+	return &prerequisite, nil
+}
+
+// LockGraph takes an exclusive lock on the prerequisite table so that concurrent
+// inserts cannot create a cycle between the check and the write. Must be called
+// inside a transaction.
+func (r *CoursePrerequisiteRepository) LockGraph() error {
+	return r.db.Exec("LOCK TABLE course_prerequisites IN SHARE ROW EXCLUSIVE MODE").Error
+}
+
+func (r *CoursePrerequisiteRepository) DeleteByCourseAndPrerequisite(courseID, prerequisiteID uint) error {
+	return r.db.Where("course_id = ? AND prerequisite_id = ?", courseID, prerequisiteID).Delete(&domain.CoursePrerequisite{}).Error
+}
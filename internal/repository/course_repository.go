@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm/clause"
 )
 
 type CourseRepository struct {
@@ -18,7 +21,7 @@ func (r *CourseRepository) Create(course *domain.Course) error {
 
 func (r *CourseRepository) FindAll() ([]domain.Course, error) {
 	var courses []domain.Course
-	if err := r.db.Preload("Teacher.User").Find(&courses).Error; err != nil {
+	if err := r.db.Preload("Teacher.User").Preload("Term").Find(&courses).Error; err != nil {
 		return nil, err
 	}
 	return courses, nil
@@ -26,7 +29,17 @@ func (r *CourseRepository) FindAll() ([]domain.Course, error) {
 
 func (r *CourseRepository) FindByID(id uint) (*domain.Course, error) {
 	var course domain.Course
-	if err := r.db.Preload("Teacher.User").First(&course, id).Error; err != nil {
+	if err := r.db.Preload("Teacher.User").Preload("Term").First(&course, id).Error; err != nil {
+		return nil, err
+	}
+	return &course, nil
+}
+
+// FindByIDForUpdate loads a course and locks its row until the surrounding
+// transaction ends, serializing seat allocation for the course
+func (r *CourseRepository) FindByIDForUpdate(id uint) (*domain.Course, error) {
+	var course domain.Course
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&course, id).Error; err != nil {
 		return nil, err
 	}
 	return &course, nil
@@ -34,7 +47,7 @@ func (r *CourseRepository) FindByID(id uint) (*domain.Course, error) {
 
 func (r *CourseRepository) FindByCode(code string) (*domain.Course, error) {
 	var course domain.Course
-	if err := r.db.Preload("Teacher.User").Where("code = ?", code).First(&course).Error; err != nil {
+	if err := r.db.Preload("Teacher.User").Preload("Term").Where("code = ?", code).First(&course).Error; err != nil {
 		return nil, err
 	}
 	return &course, nil
@@ -42,12 +55,60 @@ func (r *CourseRepository) FindByCode(code string) (*domain.Course, error) {
 
 func (r *CourseRepository) FindByTeacherID(teacherID uint) ([]domain.Course, error) {
 	var courses []domain.Course
-	if err := r.db.Preload("Teacher.User").Where("teacher_id = ?", teacherID).Find(&courses).Error; err != nil {
+	if err := r.db.Preload("Teacher.User").Preload("Term").Where("teacher_id = ?", teacherID).Find(&courses).Error; err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+func (r *CourseRepository) FindByTermID(termID uint) ([]domain.Course, error) {
+	var courses []domain.Course
+	if err := r.db.Preload("Teacher.User").Preload("Term").Where("term_id = ?", termID).Find(&courses).Error; err != nil {
+		return nil, err
+	}
+	return courses, nil
+}
+
+func (r *CourseRepository) FindByDepartmentID(departmentID uint) ([]domain.Course, error) {
+	var courses []domain.Course
+	if err := r.db.Preload("Teacher.User").Preload("Term").Where("department_id = ?", departmentID).Find(&courses).Error; err != nil {
 		return nil, err
 	}
 	return courses, nil
 }
 
+func (r *CourseRepository) CountByTermID(termID uint) (int, error) {
+	var count int64
+	if err := r.db.Model(&domain.Course{}).Where("term_id = ?", termID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// AssignUnscheduledToTerm links every course without a term whose date range
+// falls inside the term, returning the number of courses updated
+func (r *CourseRepository) AssignUnscheduledToTerm(term *domain.Term) (int, error) {
+	result := r.db.Model(&domain.Course{}).
+		Where("term_id IS NULL AND start_date >= ? AND end_date <= ?", term.StartDate, term.EndDate).
+		Update("term_id", term.ID)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// MarkGradesFinalized locks the grades of a course. It returns false when the
+// grades were already finalized in the meantime.
+func (r *CourseRepository) MarkGradesFinalized(id, userID uint, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.Course{}).
+		Where("id = ? AND grades_finalized_at IS NULL", id).
+		Updates(map[string]interface{}{"grades_finalized_at": at, "grades_finalized_by": userID})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 func (r *CourseRepository) Update(course *domain.Course) error {
 	return r.db.Save(course).Error
 }
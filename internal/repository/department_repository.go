@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type DepartmentRepository struct {
+	*Repository
+}
+
+func NewDepartmentRepository(repo *Repository) *DepartmentRepository {
+	return &DepartmentRepository{Repository: repo}
+}
+
+func (r *DepartmentRepository) Create(department *domain.Department) error {
+	return r.db.Omit("HeadTeacher").Create(department).Error
+}
+
+func (r *DepartmentRepository) FindAll() ([]domain.Department, error) {
+	var departments []domain.Department
+	if err := r.db.Preload("HeadTeacher.User").Order("code").Find(&departments).Error; err != nil {
+		return nil, err
+	}
+	return departments, nil
+}
+
+func (r *DepartmentRepository) FindByID(id uint) (*domain.Department, error) {
+	var department domain.Department
+	if err := r.db.Preload("HeadTeacher.User").First(&department, id).Error; err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *DepartmentRepository) FindByCode(code string) (*domain.Department, error) {
+	var department domain.Department
+	if err := r.db.Where("code = ?", code).First(&department).Error; err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *DepartmentRepository) FindBySubjectCode(subjectCode string) (*domain.Department, error) {
+	var department domain.Department
+	if err := r.db.Where("subject_code = ?", subjectCode).First(&department).Error; err != nil {
+		return nil, err
+	}
+	return &department, nil
+}
+
+func (r *DepartmentRepository) Update(department *domain.Department) error {
+	return r.db.Omit("HeadTeacher").Save(department).Error
+}
+
+func (r *DepartmentRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Department{}, id).Error
+}
+
+// ClearHead removes the teacher as head of any department
+func (r *DepartmentRepository) ClearHead(teacherID uint) error {
+	return r.db.Model(&domain.Department{}).Where("head_teacher_id = ?", teacherID).Update("head_teacher_id", nil).Error
+}
+
+// IsInUse reports whether teachers, courses or programs belong to the department
+func (r *DepartmentRepository) IsInUse(id uint) (bool, error) {
+	for _, model := range []interface{}{&domain.Teacher{}, &domain.Course{}, &domain.Program{}} {
+		var count int64
+		if err := r.db.Model(model).Where("department_id = ?", id).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
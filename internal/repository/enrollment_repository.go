@@ -18,7 +18,7 @@ func (r *EnrollmentRepository) Create(enrollment *domain.Enrollment) error {
 
 func (r *EnrollmentRepository) FindAll() ([]domain.Enrollment, error) {
 	var enrollments []domain.Enrollment
-	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Preload("Course.Term").Find(&enrollments).Error; err != nil {
 		return nil, err
 	}
 	return enrollments, nil
@@ -26,7 +26,7 @@ func (r *EnrollmentRepository) FindAll() ([]domain.Enrollment, error) {
 
 func (r *EnrollmentRepository) FindByID(id uint) (*domain.Enrollment, error) {
 	var enrollment domain.Enrollment
-	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").First(&enrollment, id).Error; err != nil {
+	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Preload("Course.Term").First(&enrollment, id).Error; err != nil {
 		return nil, err
 	}
 	return &enrollment, nil
@@ -34,7 +34,7 @@ func (r *EnrollmentRepository) FindByID(id uint) (*domain.Enrollment, error) {
 
 func (r *EnrollmentRepository) FindByStudentID(studentID uint) ([]domain.Enrollment, error) {
 	var enrollments []domain.Enrollment
-	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Where("student_id = ?", studentID).Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Preload("Course.Term").Where("student_id = ?", studentID).Find(&enrollments).Error; err != nil {
 		return nil, err
 	}
 	return enrollments, nil
@@ -42,7 +42,18 @@ func (r *EnrollmentRepository) FindByStudentID(studentID uint) ([]domain.Enrollm
 
 func (r *EnrollmentRepository) FindByCourseID(courseID uint) ([]domain.Enrollment, error) {
 	var enrollments []domain.Enrollment
-	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Where("course_id = ?", courseID).Find(&enrollments).Error; err != nil {
+	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Preload("Course.Term").Where("course_id = ?", courseID).Find(&enrollments).Error; err != nil {
+		return nil, err
+	}
+	return enrollments, nil
+}
+
+func (r *EnrollmentRepository) FindByTermID(termID uint) ([]domain.Enrollment, error) {
+	var enrollments []domain.Enrollment
+	if err := r.db.Preload("Student.User").Preload("Course.Teacher.User").Preload("Course.Term").
+		Joins("JOIN courses ON courses.id = enrollments.course_id").
+		Where("courses.term_id = ?", termID).
+		Find(&enrollments).Error; err != nil {
 		return nil, err
 	}
 	return enrollments, nil
@@ -56,6 +67,17 @@ func (r *EnrollmentRepository) CountByCourseID(courseID uint) (int, error) {
 	return int(count), nil
 }
 
+// CountSeatedByCourseID counts the enrollments that occupy a seat in the course
+func (r *EnrollmentRepository) CountSeatedByCourseID(courseID uint) (int, error) {
+	var count int64
+	if err := r.db.Model(&domain.Enrollment{}).
+		Where("course_id = ? AND status IN ?", courseID, []domain.EnrollmentStatus{domain.EnrollmentStatusEnrolled, domain.EnrollmentStatusCompleted}).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
 func (r *EnrollmentRepository) Update(enrollment *domain.Enrollment) error {
 	return r.db.Save(enrollment).Error
 }
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type ExamRepository struct {
+	*Repository
+}
+
+func NewExamRepository(repo *Repository) *ExamRepository {
+	return &ExamRepository{Repository: repo}
+}
+
+func (r *ExamRepository) Create(exam *domain.Exam) error {
+	return r.db.Omit("Course").Create(exam).Error
+}
+
+func (r *ExamRepository) FindByID(id uint) (*domain.Exam, error) {
+	var exam domain.Exam
+	if err := r.db.Preload("Course").First(&exam, id).Error; err != nil {
+		return nil, err
+	}
+	return &exam, nil
+}
+
+// FindByCourseIDs lists the exams of several courses, scheduled ones by time
+// and unscheduled ones last
+func (r *ExamRepository) FindByCourseIDs(courseIDs []uint) ([]domain.Exam, error) {
+	var exams []domain.Exam
+	if len(courseIDs) == 0 {
+		return exams, nil
+	}
+	if err := r.db.Preload("Course").Where("course_id IN ?", courseIDs).
+		Order("starts_at ASC NULLS LAST, id ASC").
+		Find(&exams).Error; err != nil {
+		return nil, err
+	}
+	return exams, nil
+}
+
+// FindByTermID lists the exams of the courses of a term
+func (r *ExamRepository) FindByTermID(termID uint) ([]domain.Exam, error) {
+	var exams []domain.Exam
+	if err := r.db.Preload("Course").
+		Joins("JOIN courses ON courses.id = exams.course_id AND courses.deleted_at IS NULL").
+		Where("courses.term_id = ?", termID).
+		Order("exams.starts_at ASC NULLS LAST, exams.id ASC").
+		Find(&exams).Error; err != nil {
+		return nil, err
+	}
+	return exams, nil
+}
+
+func (r *ExamRepository) Update(exam *domain.Exam) error {
+	return r.db.Omit("Course").Save(exam).Error
+}
+
+// UpdateStartsAt moves an exam, nil unschedules it
+func (r *ExamRepository) UpdateStartsAt(id uint, startsAt *time.Time) error {
+	return r.db.Model(&domain.Exam{}).Where("id = ?", id).Update("starts_at", startsAt).Error
+}
+
+func (r *ExamRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Exam{}, id).Error
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type GradeChangeRepository struct {
+	*Repository
+}
+
+func NewGradeChangeRepository(repo *Repository) *GradeChangeRepository {
+	return &GradeChangeRepository{Repository: repo}
+}
+
+func (r *GradeChangeRepository) Create(request *domain.GradeChangeRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *GradeChangeRepository) FindByID(id uint) (*domain.GradeChangeRequest, error) {
+	var request domain.GradeChangeRequest
+	if err := r.db.Preload("Enrollment.Student.User").Preload("Enrollment.Course.Term").Preload("Enrollment.Course.Department").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindAll lists requests, newest first. A non-zero requestedBy limits the list
+// to the requests made by that user and, when headTeacherID is also given, the
+// requests in courses of the departments headed by that teacher. Zero values of
+// status and requestedBy are ignored.
+func (r *GradeChangeRepository) FindAll(status domain.GradeChangeStatus, requestedBy, headTeacherID uint) ([]domain.GradeChangeRequest, error) {
+	query := r.db.Preload("Enrollment.Student.User").Preload("Enrollment.Course")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if requestedBy != 0 && headTeacherID != 0 {
+		headed := r.db.Table("enrollments").Select("enrollments.id").
+			Joins("JOIN courses ON courses.id = enrollments.course_id").
+			Joins("JOIN departments ON departments.id = courses.department_id").
+			Where("departments.head_teacher_id = ?", headTeacherID)
+		query = query.Where("requested_by = ? OR enrollment_id IN (?)", requestedBy, headed)
+	} else if requestedBy != 0 {
+		query = query.Where("requested_by = ?", requestedBy)
+	}
+
+	var requests []domain.GradeChangeRequest
+	if err := query.Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (r *GradeChangeRepository) HasPending(enrollmentID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.GradeChangeRequest{}).Where("enrollment_id = ? AND status = ?", enrollmentID, domain.GradeChangePending).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// MarkReviewed records the decision on a pending request. It returns false when
+// the request was already decided in the meantime.
+func (r *GradeChangeRepository) MarkReviewed(request *domain.GradeChangeRequest, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.GradeChangeRequest{}).
+		Where("id = ? AND status = ?", request.ID, domain.GradeChangePending).
+		Updates(map[string]interface{}{
+			"status":         request.Status,
+			"reviewed_by":    request.ReviewedBy,
+			"reviewed_at":    at,
+			"review_comment": request.ReviewComment,
+			"updated_at":     at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GradeChangeRepository) CreateHistory(entry *domain.GradeHistory) error {
+	return r.db.Create(entry).Error
+}
+
+// FindHistory lists the grade changes of an enrollment, oldest first
+func (r *GradeChangeRepository) FindHistory(enrollmentID uint) ([]domain.GradeHistory, error) {
+	var history []domain.GradeHistory
+	if err := r.db.Where("enrollment_id = ?", enrollmentID).Order("created_at ASC, id ASC").Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type InvitationRepository struct {
+	*Repository
+}
+
+func NewInvitationRepository(repo *Repository) *InvitationRepository {
+	return &InvitationRepository{Repository: repo}
+}
+
+func (r *InvitationRepository) Create(invitation *domain.Invitation) error {
+	return r.db.Create(invitation).Error
+}
+
+func (r *InvitationRepository) FindAll() ([]domain.Invitation, error) {
+	var invitations []domain.Invitation
+	if err := r.db.Order("created_at DESC").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *InvitationRepository) FindByID(id uint) (*domain.Invitation, error) {
+	var invitation domain.Invitation
+	if err := r.db.First(&invitation, id).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+func (r *InvitationRepository) FindByTokenHash(tokenHash string) (*domain.Invitation, error) {
+	var invitation domain.Invitation
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// MarkAccepted claims a pending invitation for a user. It returns false when the
+// invitation was accepted, revoked or expired in the meantime.
+func (r *InvitationRepository) MarkAccepted(id, userID uint, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", id, at).
+		Updates(map[string]interface{}{"accepted_at": at, "user_id": userID})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkRevoked revokes a pending invitation. It returns false when the invitation
+// was accepted or revoked in the meantime.
+func (r *InvitationRepository) MarkRevoked(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *InvitationRepository) Update(invitation *domain.Invitation) error {
+	return r.db.Save(invitation).Error
+}
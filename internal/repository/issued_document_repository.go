@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type IssuedDocumentRepository struct {
+	*Repository
+}
+
+func NewIssuedDocumentRepository(repo *Repository) *IssuedDocumentRepository {
+	return &IssuedDocumentRepository{Repository: repo}
+}
+
+func (r *IssuedDocumentRepository) Create(document *domain.IssuedDocument) error {
+	return r.db.Create(document).Error
+}
+
+func (r *IssuedDocumentRepository) FindByCode(code string) (*domain.IssuedDocument, error) {
+	var document domain.IssuedDocument
+	if err := r.db.Preload("Student.User").Where("code = ?", code).First(&document).Error; err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
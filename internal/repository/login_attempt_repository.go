@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/pkg/throttle"
+	"gorm.io/gorm"
+)
+
+// loginAttempt is the row behind a throttle entry
+type loginAttempt struct {
+	Key           string `gorm:"primaryKey"`
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (loginAttempt) TableName() string {
+	return "login_attempts"
+}
+
+func (a *loginAttempt) entry() throttle.Entry {
+	entry := throttle.Entry{Key: a.Key, Failures: a.Failures, LastFailureAt: a.LastFailureAt}
+	if a.LockedUntil != nil {
+		entry.LockedUntil = *a.LockedUntil
+	}
+	return entry
+}
+
+// LoginAttemptRepository is a throttle.Store backed by Postgres, so failed
+// login tracking is shared between all application instances
+type LoginAttemptRepository struct {
+	*Repository
+}
+
+func NewLoginAttemptRepository(repo *Repository) *LoginAttemptRepository {
+	return &LoginAttemptRepository{Repository: repo}
+}
+
+func (r *LoginAttemptRepository) Get(key string) (throttle.Entry, error) {
+	var attempt loginAttempt
+	err := r.db.Where("key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return throttle.Entry{}, nil
+	}
+	if err != nil {
+		return throttle.Entry{}, err
+	}
+	return attempt.entry(), nil
+}
+
+// Fail increments the failure counter in a single upsert so concurrent
+// attempts are all counted
+func (r *LoginAttemptRepository) Fail(key string, at time.Time, window time.Duration) (throttle.Entry, error) {
+	var attempt loginAttempt
+	err := r.db.Raw(`
+		INSERT INTO login_attempts (key, failures, last_failure_at)
+		VALUES (?, 1, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END,
+			last_failure_at = EXCLUDED.last_failure_at
+		RETURNING key, failures, last_failure_at, locked_until`,
+		key, at, at.Add(-window),
+	).Scan(&attempt).Error
+	if err != nil {
+		return throttle.Entry{}, err
+	}
+	return attempt.entry(), nil
+}
+
+func (r *LoginAttemptRepository) Lock(key string, until time.Time) error {
+	return r.db.Model(&loginAttempt{}).Where("key = ?", key).Update("locked_until", until).Error
+}
+
+func (r *LoginAttemptRepository) Reset(key string) error {
+	return r.db.Where("key = ?", key).Delete(&loginAttempt{}).Error
+}
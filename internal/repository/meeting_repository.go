@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type MeetingRepository struct {
+	*Repository
+}
+
+func NewMeetingRepository(repo *Repository) *MeetingRepository {
+	return &MeetingRepository{Repository: repo}
+}
+
+func (r *MeetingRepository) FindByCourseID(courseID uint) ([]domain.CourseMeeting, error) {
+	var meetings []domain.CourseMeeting
+	if err := r.db.Preload("Location").Where("course_id = ?", courseID).Order("start_minute ASC, id ASC").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// FindByCourseIDs loads the meetings of several courses at once
+func (r *MeetingRepository) FindByCourseIDs(courseIDs []uint) ([]domain.CourseMeeting, error) {
+	var meetings []domain.CourseMeeting
+	if len(courseIDs) == 0 {
+		return meetings, nil
+	}
+	if err := r.db.Preload("Location").Where("course_id IN ?", courseIDs).Order("start_minute ASC, id ASC").Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// Replace swaps the meetings of a course for the given ones. Run it inside a
+// transaction so the course is never left without its schedule.
+func (r *MeetingRepository) Replace(courseID uint, meetings []domain.CourseMeeting) error {
+	if err := r.db.Where("course_id = ?", courseID).Delete(&domain.CourseMeeting{}).Error; err != nil {
+		return err
+	}
+	if len(meetings) == 0 {
+		return nil
+	}
+	return r.db.Omit("Course", "Location").Create(&meetings).Error
+}
+
+// FindByRoomIDs lists the meetings held in the rooms by courses whose dates
+// overlap with [from, to]
+func (r *MeetingRepository) FindByRoomIDs(roomIDs []uint, from, to time.Time) ([]domain.CourseMeeting, error) {
+	var meetings []domain.CourseMeeting
+	if len(roomIDs) == 0 {
+		return meetings, nil
+	}
+	if err := r.db.Preload("Course").Preload("Location").
+		Joins("JOIN courses ON courses.id = course_meetings.course_id AND courses.deleted_at IS NULL").
+		Where("course_meetings.room_id IN ? AND courses.start_date <= ? AND courses.end_date >= ?", roomIDs, to, from).
+		Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
+
+// FindByRoomID lists the meetings held in the room, with their courses
+func (r *MeetingRepository) FindByRoomID(roomID uint) ([]domain.CourseMeeting, error) {
+	var meetings []domain.CourseMeeting
+	if err := r.db.Preload("Course").Where("room_id = ?", roomID).Find(&meetings).Error; err != nil {
+		return nil, err
+	}
+	return meetings, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ProgramRepository struct {
+	*Repository
+}
+
+func NewProgramRepository(repo *Repository) *ProgramRepository {
+	return &ProgramRepository{Repository: repo}
+}
+
+// withRequirements loads the requirement groups in order with their courses,
+// including courses deleted since the program was defined
+func withRequirements(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Groups", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Groups.Courses.Course", func(db *gorm.DB) *gorm.DB { return db.Unscoped() })
+}
+
+// Create saves the program with its requirement groups and their courses
+func (r *ProgramRepository) Create(program *domain.Program) error {
+	return r.db.Create(program).Error
+}
+
+func (r *ProgramRepository) FindAll() ([]domain.Program, error) {
+	var programs []domain.Program
+	if err := withRequirements(r.db).Order("code, catalog_year DESC").Find(&programs).Error; err != nil {
+		return nil, err
+	}
+	return programs, nil
+}
+
+func (r *ProgramRepository) FindByID(id uint) (*domain.Program, error) {
+	var program domain.Program
+	if err := withRequirements(r.db).First(&program, id).Error; err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
+
+func (r *ProgramRepository) FindByCodeAndCatalogYear(code string, catalogYear int) (*domain.Program, error) {
+	var program domain.Program
+	if err := r.db.Where("code = ? AND catalog_year = ?", code, catalogYear).First(&program).Error; err != nil {
+		return nil, err
+	}
+	return &program, nil
+}
+
+func (r *ProgramRepository) Update(program *domain.Program) error {
+	return r.db.Omit("Groups").Save(program).Error
+}
+
+// ReplaceGroups replaces the requirement groups of a program and their courses
+func (r *ProgramRepository) ReplaceGroups(programID uint, groups []domain.RequirementGroup) error {
+	if err := r.db.Where("program_id = ?", programID).Delete(&domain.RequirementGroup{}).Error; err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	for i := range groups {
+		groups[i].ProgramID = programID
+	}
+	return r.db.Create(&groups).Error
+}
+
+func (r *ProgramRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Program{}, id).Error
+}
+
+// IsInUse reports whether students follow the program
+func (r *ProgramRepository) IsInUse(id uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&domain.Student{}).Where("program_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type RecoveryCodeRepository struct {
+	*Repository
+}
+
+func NewRecoveryCodeRepository(repo *Repository) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{Repository: repo}
+}
+
+// Replace discards all recovery codes of a user and stores the new ones
+func (r *RecoveryCodeRepository) Replace(userID uint, codes []domain.RecoveryCode) error {
+	if err := r.db.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return r.db.Create(&codes).Error
+}
+
+// Redeem marks an unused code of the user as used. It returns false when no
+// such code exists.
+func (r *RecoveryCodeRepository) Redeem(userID uint, codeHash string, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *RecoveryCodeRepository) CountUnused(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
@@ -9,3 +9,11 @@ type Repository struct {
 func NewRepository(db *gorm.DB) *Repository {
 	return &Repository{db: db}
 }
+
+// Transaction runs fn inside a database transaction. The Repository passed to fn
+// is bound to the transaction and can be used to construct transactional repositories.
+func (r *Repository) Transaction(fn func(tx *Repository) error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return fn(&Repository{db: tx})
+	})
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type RoleRepository struct {
+	*Repository
+}
+
+func NewRoleRepository(repo *Repository) *RoleRepository {
+	return &RoleRepository{Repository: repo}
+}
+
+func (r *RoleRepository) Create(role *domain.RoleDefinition) error {
+	return r.db.Create(role).Error
+}
+
+func (r *RoleRepository) FindAll() ([]domain.RoleDefinition, error) {
+	var roles []domain.RoleDefinition
+	if err := r.db.Preload("Permissions").Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) FindByName(name domain.Role) (*domain.RoleDefinition, error) {
+	var role domain.RoleDefinition
+	if err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindPermissions returns the permissions granted to a role, none if the role does not exist
+func (r *RoleRepository) FindPermissions(name domain.Role) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	if err := r.db.Model(&domain.RolePermission{}).Where("role_name = ?", name).Pluck("permission", &permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// Update saves the role and replaces its permission set
+func (r *RoleRepository) Update(role *domain.RoleDefinition) error {
+	if err := r.db.Omit("Permissions").Save(role).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("role_name = ?", role.Name).Delete(&domain.RolePermission{}).Error; err != nil {
+		return err
+	}
+	if len(role.Permissions) == 0 {
+		return nil
+	}
+	return r.db.Create(&role.Permissions).Error
+}
+
+func (r *RoleRepository) Delete(name domain.Role) error {
+	return r.db.Where("name = ?", name).Delete(&domain.RoleDefinition{}).Error
+}
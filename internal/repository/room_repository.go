@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm/clause"
+)
+
+type RoomRepository struct {
+	*Repository
+}
+
+func NewRoomRepository(repo *Repository) *RoomRepository {
+	return &RoomRepository{Repository: repo}
+}
+
+func (r *RoomRepository) Create(room *domain.Room) error {
+	return r.db.Create(room).Error
+}
+
+func (r *RoomRepository) FindAll() ([]domain.Room, error) {
+	var rooms []domain.Room
+	if err := r.db.Preload("Features").Order("building, number").Find(&rooms).Error; err != nil {
+		return nil, err
+	}
+	return rooms, nil
+}
+
+func (r *RoomRepository) FindByID(id uint) (*domain.Room, error) {
+	var room domain.Room
+	if err := r.db.Preload("Features").First(&room, id).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// FindByIDForUpdate loads a room and locks its row until the surrounding
+// transaction ends, serializing bookings of the room
+func (r *RoomRepository) FindByIDForUpdate(id uint) (*domain.Room, error) {
+	var room domain.Room
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&room, id).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func (r *RoomRepository) FindByBuildingAndNumber(building, number string) (*domain.Room, error) {
+	var room domain.Room
+	if err := r.db.Where("building = ? AND number = ?", building, number).First(&room).Error; err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// Update saves the room and replaces its features
+func (r *RoomRepository) Update(room *domain.Room) error {
+	if err := r.db.Omit("Features").Save(room).Error; err != nil {
+		return err
+	}
+	if err := r.db.Where("room_id = ?", room.ID).Delete(&domain.RoomFeature{}).Error; err != nil {
+		return err
+	}
+	if len(room.Features) == 0 {
+		return nil
+	}
+	return r.db.Create(&room.Features).Error
+}
+
+func (r *RoomRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Room{}, id).Error
+}
+
+// IsInUse reports whether course meetings or bookings reference the room
+func (r *RoomRepository) IsInUse(id uint) (bool, error) {
+	var meetings, bookings int64
+	if err := r.db.Model(&domain.CourseMeeting{}).Where("room_id = ?", id).Count(&meetings).Error; err != nil {
+		return false, err
+	}
+	if err := r.db.Model(&domain.RoomBooking{}).Where("room_id = ?", id).Count(&bookings).Error; err != nil {
+		return false, err
+	}
+	return meetings+bookings > 0, nil
+}
+
+func (r *RoomRepository) CreateBooking(booking *domain.RoomBooking) error {
+	return r.db.Omit("Room").Create(booking).Error
+}
+
+func (r *RoomRepository) FindBookingByID(id uint) (*domain.RoomBooking, error) {
+	var booking domain.RoomBooking
+	if err := r.db.Preload("Room").First(&booking, id).Error; err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// FindBookings lists the bookings of the rooms that overlap with [from, to)
+func (r *RoomRepository) FindBookings(roomIDs []uint, from, to time.Time) ([]domain.RoomBooking, error) {
+	var bookings []domain.RoomBooking
+	if len(roomIDs) == 0 {
+		return bookings, nil
+	}
+	if err := r.db.Preload("Room").
+		Where("room_id IN ? AND starts_at < ? AND ends_at > ?", roomIDs, to, from).
+		Order("starts_at ASC").
+		Find(&bookings).Error; err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func (r *RoomRepository) DeleteBooking(id uint) error {
+	return r.db.Delete(&domain.RoomBooking{}, id).Error
+}
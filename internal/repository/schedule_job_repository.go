@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type ScheduleJobRepository struct {
+	*Repository
+}
+
+func NewScheduleJobRepository(repo *Repository) *ScheduleJobRepository {
+	return &ScheduleJobRepository{Repository: repo}
+}
+
+func (r *ScheduleJobRepository) Create(job *domain.ScheduleJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *ScheduleJobRepository) FindByID(id uint) (*domain.ScheduleJob, error) {
+	var job domain.ScheduleJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindByTermID lists the jobs of a term, newest first
+func (r *ScheduleJobRepository) FindByTermID(termID uint) ([]domain.ScheduleJob, error) {
+	var jobs []domain.ScheduleJob
+	if err := r.db.Omit("result").Where("term_id = ?", termID).Order("created_at DESC, id DESC").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// HasActive reports whether a job of the term is pending or running
+func (r *ScheduleJobRepository) HasActive(termID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.ScheduleJob{}).
+		Where("term_id = ? AND status IN ?", termID, []domain.ScheduleJobStatus{domain.ScheduleJobPending, domain.ScheduleJobRunning}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ScheduleJobRepository) MarkRunning(id uint, at time.Time) error {
+	return r.db.Model(&domain.ScheduleJob{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": domain.ScheduleJobRunning, "started_at": at}).Error
+}
+
+func (r *ScheduleJobRepository) UpdateProgress(id uint, progress int) error {
+	return r.db.Model(&domain.ScheduleJob{}).Where("id = ?", id).Update("progress", progress).Error
+}
+
+// Finish records the outcome of a job, the proposal when it succeeded or the
+// error when it failed
+func (r *ScheduleJobRepository) Finish(id uint, status domain.ScheduleJobStatus, result json.RawMessage, message string, at time.Time) error {
+	updates := map[string]interface{}{"status": status, "error": message, "finished_at": at}
+	if result != nil {
+		updates["result"] = result
+		updates["progress"] = 100
+	}
+	return r.db.Model(&domain.ScheduleJob{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// MarkApplied records that the proposal of a job was applied. It returns false
+// when the job was applied in the meantime.
+func (r *ScheduleJobRepository) MarkApplied(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.ScheduleJob{}).
+		Where("id = ? AND status = ?", id, domain.ScheduleJobSucceeded).
+		Updates(map[string]interface{}{"status": domain.ScheduleJobApplied, "applied_at": at})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FailUnfinished fails the jobs left pending or running, e.g. by a restart,
+// returning the number of jobs updated
+func (r *ScheduleJobRepository) FailUnfinished(message string, at time.Time) (int, error) {
+	result := r.db.Model(&domain.ScheduleJob{}).
+		Where("status IN ?", []domain.ScheduleJobStatus{domain.ScheduleJobPending, domain.ScheduleJobRunning}).
+		Updates(map[string]interface{}{"status": domain.ScheduleJobFailed, "error": message, "finished_at": at})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
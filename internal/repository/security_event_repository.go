@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type SecurityEventRepository struct {
+	*Repository
+}
+
+func NewSecurityEventRepository(repo *Repository) *SecurityEventRepository {
+	return &SecurityEventRepository{Repository: repo}
+}
+
+func (r *SecurityEventRepository) Create(event *domain.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+// FindAll returns the newest events first, optionally filtered by type and time range
+func (r *SecurityEventRepository) FindAll(eventType string, from, to *time.Time, limit int) ([]domain.SecurityEvent, error) {
+	query := r.db.Order("created_at DESC")
+	if eventType != "" {
+		query = query.Where("type = ?", eventType)
+	}
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at <= ?", *to)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var events []domain.SecurityEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
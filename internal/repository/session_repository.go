@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type SessionRepository struct {
+	*Repository
+}
+
+func NewSessionRepository(repo *Repository) *SessionRepository {
+	return &SessionRepository{Repository: repo}
+}
+
+func (r *SessionRepository) Create(session *domain.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *SessionRepository) FindByID(id string) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// IsActive reports whether the session exists, belongs to the user and has
+// neither expired nor been revoked
+func (r *SessionRepository) IsActive(id string, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL AND expires_at > ?", id, userID, time.Now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Extend moves the expiry of a session when its refresh token is rotated
+func (r *SessionRepository) Extend(id string, expiresAt time.Time) error {
+	return r.db.Model(&domain.Session{}).Where("id = ?", id).Update("expires_at", expiresAt).Error
+}
+
+func (r *SessionRepository) Revoke(id string) error {
+	return r.db.Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAllByUserID ends every session of a user, e.g. after a password change
+func (r *SessionRepository) RevokeAllByUserID(userID uint) error {
+	return r.db.Model(&domain.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *SessionRepository) CreateRefreshToken(token *domain.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *SessionRepository) FindRefreshTokenByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	if err := r.db.Preload("Session").Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed consumes a refresh token. It returns false when the token
+// has already been used, which indicates it was replayed.
+func (r *SessionRepository) MarkRefreshTokenUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
@@ -55,3 +55,8 @@ func (r *StudentRepository) Update(student *domain.Student) error {
 func (r *StudentRepository) Delete(id uint) error {
 	return r.db.Delete(&domain.Student{}, id).Error
 }
+
+// UpdateProgram links the student to a program, or unlinks it when programID is nil
+func (r *StudentRepository) UpdateProgram(id uint, programID *uint) error {
+	return r.db.Model(&domain.Student{}).Where("id = ?", id).Update("program_id", programID).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type TeacherPreferenceRepository struct {
+	*Repository
+}
+
+func NewTeacherPreferenceRepository(repo *Repository) *TeacherPreferenceRepository {
+	return &TeacherPreferenceRepository{Repository: repo}
+}
+
+func (r *TeacherPreferenceRepository) FindByTeacherID(teacherID uint) ([]domain.TeacherPreferredSlot, error) {
+	var slots []domain.TeacherPreferredSlot
+	if err := r.db.Where("teacher_id = ?", teacherID).Order("start_minute ASC, id ASC").Find(&slots).Error; err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// FindByTeacherIDs loads the preferred slots of several teachers at once
+func (r *TeacherPreferenceRepository) FindByTeacherIDs(teacherIDs []uint) ([]domain.TeacherPreferredSlot, error) {
+	var slots []domain.TeacherPreferredSlot
+	if len(teacherIDs) == 0 {
+		return slots, nil
+	}
+	if err := r.db.Where("teacher_id IN ?", teacherIDs).Find(&slots).Error; err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
+
+// Replace swaps the preferred slots of a teacher for the given ones. Run it
+// inside a transaction so the teacher is never left without preferences.
+func (r *TeacherPreferenceRepository) Replace(teacherID uint, slots []domain.TeacherPreferredSlot) error {
+	if err := r.db.Where("teacher_id = ?", teacherID).Delete(&domain.TeacherPreferredSlot{}).Error; err != nil {
+		return err
+	}
+	if len(slots) == 0 {
+		return nil
+	}
+	return r.db.Create(&slots).Error
+}
@@ -18,7 +18,7 @@ func (r *TeacherRepository) Create(teacher *domain.Teacher) error {
 
 func (r *TeacherRepository) FindAll() ([]domain.Teacher, error) {
 	var teachers []domain.Teacher
-	if err := r.db.Preload("User").Find(&teachers).Error; err != nil {
+	if err := r.db.Preload("User").Preload("Department").Find(&teachers).Error; err != nil {
 		return nil, err
 	}
 	return teachers, nil
@@ -26,7 +26,7 @@ func (r *TeacherRepository) FindAll() ([]domain.Teacher, error) {
 
 func (r *TeacherRepository) FindByID(id uint) (*domain.Teacher, error) {
 	var teacher domain.Teacher
-	if err := r.db.Preload("User").First(&teacher, id).Error; err != nil {
+	if err := r.db.Preload("User").Preload("Department").First(&teacher, id).Error; err != nil {
 		return nil, err
 	}
 	return &teacher, nil
@@ -48,6 +48,14 @@ func (r *TeacherRepository) FindByUserID(userID uint) (*domain.Teacher, error) {
 	return &teacher, nil
 }
 
+func (r *TeacherRepository) FindByDepartmentID(departmentID uint) ([]domain.Teacher, error) {
+	var teachers []domain.Teacher
+	if err := r.db.Preload("User").Preload("Department").Where("department_id = ?", departmentID).Find(&teachers).Error; err != nil {
+		return nil, err
+	}
+	return teachers, nil
+}
+
 func (r *TeacherRepository) Update(teacher *domain.Teacher) error {
 	return r.db.Save(teacher).Error
 }
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type TermRepository struct {
+	*Repository
+}
+
+func NewTermRepository(repo *Repository) *TermRepository {
+	return &TermRepository{Repository: repo}
+}
+
+func (r *TermRepository) Create(term *domain.Term) error {
+	return r.db.Create(term).Error
+}
+
+func (r *TermRepository) FindAll() ([]domain.Term, error) {
+	var terms []domain.Term
+	if err := r.db.Order("start_date ASC").Find(&terms).Error; err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+func (r *TermRepository) FindByID(id uint) (*domain.Term, error) {
+	var term domain.Term
+	if err := r.db.First(&term, id).Error; err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *TermRepository) FindByName(name string) (*domain.Term, error) {
+	var term domain.Term
+	if err := r.db.Where("name = ?", name).First(&term).Error; err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+func (r *TermRepository) Update(term *domain.Term) error {
+	return r.db.Save(term).Error
+}
+
+func (r *TermRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Term{}, id).Error
+}
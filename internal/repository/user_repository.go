@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -32,6 +33,24 @@ func (r *UserRepository) FindByEmail(email string) (*domain.User, error) {
 	return &user, nil
 }
 
+func (r *UserRepository) CountByRole(role domain.Role) (int64, error) {
+	var count int64
+	if err := r.db.Model(&domain.User{}).Where("role = ?", role).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// CountByRoleForUpdate counts the users of a role and locks their rows until
+// the surrounding transaction ends, so concurrent role changes see each other
+func (r *UserRepository) CountByRoleForUpdate(role domain.Role) (int64, error) {
+	var ids []uint
+	if err := r.db.Model(&domain.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("role = ?", role).Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
 func (r *UserRepository) Update(user *domain.User) error {
 	return r.db.Save(user).Error
 }
@@ -39,3 +58,20 @@ func (r *UserRepository) Update(user *domain.User) error {
 func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&domain.User{}, id).Error
 }
+
+// UpdateRole assigns a role without touching the other columns
+func (r *UserRepository) UpdateRole(id uint, role domain.Role) error {
+	return r.db.Model(&domain.User{}).Where("id = ?", id).Update("role", role).Error
+}
+
+// RecordTwoFactorStep advances the last accepted TOTP step only when step is
+// newer, so two concurrent logins cannot both redeem the same code
+func (r *UserRepository) RecordTwoFactorStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND two_factor_last_step < ?", id, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
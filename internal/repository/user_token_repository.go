@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+)
+
+type UserTokenRepository struct {
+	*Repository
+}
+
+func NewUserTokenRepository(repo *Repository) *UserTokenRepository {
+	return &UserTokenRepository{Repository: repo}
+}
+
+func (r *UserTokenRepository) Create(token *domain.UserToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *UserTokenRepository) FindByHash(purpose domain.UserTokenPurpose, tokenHash string) (*domain.UserToken, error) {
+	var token domain.UserToken
+	if err := r.db.Preload("User").Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed redeems a token. It returns false when the token was already used.
+func (r *UserTokenRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&domain.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateByUserID marks all unused tokens of a purpose as used, so only the
+// most recently sent link works
+func (r *UserTokenRepository) InvalidateByUserID(userID uint, purpose domain.UserTokenPurpose, at time.Time) error {
+	return r.db.Model(&domain.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", at).Error
+}
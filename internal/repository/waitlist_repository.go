@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"gorm.io/gorm"
+)
+
+type WaitlistRepository struct {
+	*Repository
+}
+
+func NewWaitlistRepository(repo *Repository) *WaitlistRepository {
+	return &WaitlistRepository{Repository: repo}
+}
+
+func (r *WaitlistRepository) Create(entry *domain.WaitlistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *WaitlistRepository) FindByCourseID(courseID uint) ([]domain.WaitlistEntry, error) {
+	var entries []domain.WaitlistEntry
+	if err := r.db.Preload("Student.User").Preload("Course").Where("course_id = ?", courseID).Order("position ASC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *WaitlistRepository) FindByStudentAndCourse(studentID, courseID uint) (*domain.WaitlistEntry, error) {
+	var entry domain.WaitlistEntry
+	if err := r.db.Preload("Student.User").Preload("Course").Where("student_id = ? AND course_id = ?", studentID, courseID).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindHead returns the entry at the front of the course waitlist
+func (r *WaitlistRepository) FindHead(courseID uint) (*domain.WaitlistEntry, error) {
+	var entry domain.WaitlistEntry
+	if err := r.db.Preload("Student.User").Preload("Course").Where("course_id = ?", courseID).Order("position ASC").First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *WaitlistRepository) MaxPosition(courseID uint) (int, error) {
+	var position int
+	if err := r.db.Model(&domain.WaitlistEntry{}).Where("course_id = ?", courseID).Select("COALESCE(MAX(position), 0)").Scan(&position).Error; err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+// Remove deletes an entry and moves everyone behind it one position forward
+func (r *WaitlistRepository) Remove(entry *domain.WaitlistEntry) error {
+	if err := r.db.Delete(&domain.WaitlistEntry{}, entry.ID).Error; err != nil {
+		return err
+	}
+	return r.db.Model(&domain.WaitlistEntry{}).
+		Where("course_id = ? AND position > ?", entry.CourseID, entry.Position).
+		Update("position", gorm.Expr("position - 1")).Error
+}
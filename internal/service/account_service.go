@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/auth"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/mailer"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 48 * time.Hour
+)
+
+// AccountService handles the email based account flows: verifying an address
+// and resetting a forgotten password
+type AccountService struct {
+	userRepo       *repository.UserRepository
+	userTokenRepo  *repository.UserTokenRepository
+	sessionRepo    *repository.SessionRepository
+	auditService   *AuditService
+	mailer         mailer.Mailer
+	baseURL        string
+	userDTOFactory *factory.UserDTOFactory
+}
+
+func NewAccountService(
+	userRepo *repository.UserRepository,
+	userTokenRepo *repository.UserTokenRepository,
+	sessionRepo *repository.SessionRepository,
+	auditService *AuditService,
+	mailer mailer.Mailer,
+	baseURL string,
+) *AccountService {
+	return &AccountService{
+		userRepo:       userRepo,
+		userTokenRepo:  userTokenRepo,
+		sessionRepo:    sessionRepo,
+		auditService:   auditService,
+		mailer:         mailer,
+		baseURL:        baseURL,
+		userDTOFactory: factory.NewUserDTOFactory(),
+	}
+}
+
+// SendVerification emails a new verification link, invalidating earlier ones
+func (s *AccountService) SendVerification(user *domain.User) error {
+	token, err := s.issueToken(user.ID, domain.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Hello %s,\n\nPlease confirm your email address by opening the link below:\n\n%s/auth/verify-email?token=%s\n\nThe link expires in %d hours.\n",
+		user.FirstName, s.baseURL, token, int(emailVerificationTTL.Hours()),
+	)
+	return s.send(user.Email, "Confirm your email address", body)
+}
+
+// ResendVerification sends a new verification link. It succeeds silently for
+// unknown or already verified addresses, and when sending fails, so it cannot
+// be used to probe accounts.
+func (s *AccountService) ResendVerification(req *dto.EmailRequest) error {
+	user, err := s.userRepo.FindByEmail(req.Email)
+	if err != nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+	if err := s.SendVerification(user); err != nil {
+		log.Printf("Failed to resend verification email to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+func (s *AccountService) VerifyEmail(actor domain.Actor, req *dto.VerifyEmailRequest) error {
+	return s.userRepo.Transaction(func(tx *repository.Repository) error {
+		token, err := redeemToken(repository.NewUserTokenRepository(tx), domain.TokenPurposeEmailVerification, req.Token)
+		if err != nil {
+			return err
+		}
+
+		user := &token.User
+		if user.EmailVerifiedAt != nil {
+			return nil
+		}
+		before := s.userDTOFactory.CreateFromEntity(user)
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return errors.InternalServerError("Failed to verify email", err)
+		}
+		return s.auditService.Record(tx, actor.OrUser(user), domain.AuditActionUpdate, domain.AuditEntityUser, user.ID, before, s.userDTOFactory.CreateFromEntity(user))
+	})
+}
+
+// ForgotPassword emails a password reset token. Like ResendVerification it
+// does not reveal whether the address belongs to an account.
+func (s *AccountService) ForgotPassword(req *dto.EmailRequest) error {
+	user, err := s.userRepo.FindByEmail(req.Email)
+	if err != nil {
+		return nil
+	}
+
+	// Failures are only logged: an error for existing addresses alone would
+	// reveal which ones belong to an account
+	token, err := s.issueToken(user.ID, domain.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		log.Printf("Failed to issue password reset token for user %d: %v", user.ID, err)
+		return nil
+	}
+
+	body := fmt.Sprintf(
+		"Hello %s,\n\nA password reset was requested for your account. Use the token below with POST %s/auth/reset-password to choose a new password:\n\n%s\n\nThe token expires in %d minutes. If you did not request a reset you can ignore this email.\n",
+		user.FirstName, s.baseURL, token, int(passwordResetTTL.Minutes()),
+	)
+	// send logs the failure itself
+	_ = s.send(user.Email, "Reset your password", body)
+	return nil
+}
+
+// ResetPassword sets a new password and signs the user out of all sessions.
+// Receiving the token also proves ownership of the email address. The token is
+// only used up if the password change, the invalidation of other reset tokens
+// and the sign-out all succeed.
+func (s *AccountService) ResetPassword(actor domain.Actor, req *dto.ResetPasswordRequest) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.InternalServerError("Failed to hash password", err)
+	}
+
+	now := time.Now()
+	return s.userRepo.Transaction(func(tx *repository.Repository) error {
+		userTokenRepo := repository.NewUserTokenRepository(tx)
+		token, err := redeemToken(userTokenRepo, domain.TokenPurposePasswordReset, req.Token)
+		if err != nil {
+			return err
+		}
+
+		user := &token.User
+		before := s.userDTOFactory.CreateFromEntity(user)
+		user.Password = string(hashedPassword)
+		if user.EmailVerifiedAt == nil {
+			user.EmailVerifiedAt = &now
+		}
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return errors.InternalServerError("Failed to update password", err)
+		}
+
+		if err := userTokenRepo.InvalidateByUserID(user.ID, domain.TokenPurposePasswordReset, now); err != nil {
+			return errors.InternalServerError("Failed to invalidate reset tokens", err)
+		}
+		if err := repository.NewSessionRepository(tx).RevokeAllByUserID(user.ID); err != nil {
+			return errors.InternalServerError("Failed to revoke sessions", err)
+		}
+		return s.auditService.Record(tx, actor.OrUser(user), domain.AuditActionPasswordReset, domain.AuditEntityUser, user.ID, before, s.userDTOFactory.CreateFromEntity(user))
+	})
+}
+
+func (s *AccountService) issueToken(userID uint, purpose domain.UserTokenPurpose, ttl time.Duration) (string, error) {
+	token, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", errors.InternalServerError("Failed to generate token", err)
+	}
+
+	now := time.Now()
+	if err := s.userTokenRepo.InvalidateByUserID(userID, purpose, now); err != nil {
+		return "", errors.InternalServerError("Failed to invalidate previous tokens", err)
+	}
+
+	userToken := &domain.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := s.userTokenRepo.Create(userToken); err != nil {
+		return "", errors.InternalServerError("Failed to store token", err)
+	}
+
+	return token, nil
+}
+
+// redeemToken looks up a token and marks it used, so it works exactly once. Pass
+// a transactional repository to undo the redemption if later steps fail.
+func redeemToken(userTokenRepo *repository.UserTokenRepository, purpose domain.UserTokenPurpose, token string) (*domain.UserToken, error) {
+	userToken, err := userTokenRepo.FindByHash(purpose, auth.HashToken(token))
+	if err != nil {
+		return nil, errors.BadRequest("Invalid or expired token", nil)
+	}
+
+	now := time.Now()
+	if !userToken.IsUsable(now) {
+		return nil, errors.BadRequest("Invalid or expired token", nil)
+	}
+
+	redeemed, err := userTokenRepo.MarkUsed(userToken.ID, now)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to redeem token", err)
+	}
+	if !redeemed {
+		return nil, errors.BadRequest("Invalid or expired token", nil)
+	}
+
+	return userToken, nil
+}
+
+func (s *AccountService) send(to, subject, body string) error {
+	if err := s.mailer.Send(mailer.Message{To: to, Subject: subject, Body: body}); err != nil {
+		log.Printf("Failed to send %q to %s: %v", subject, to, err)
+		return errors.InternalServerError("Failed to send email", err)
+	}
+	return nil
+}
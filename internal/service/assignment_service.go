@@ -0,0 +1,423 @@
+package service
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/auth"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/grading"
+	"github.com/Tretorhate/university-management-system/pkg/storage"
+)
+
+// AssignmentService manages coursework: assignments of a course, the files
+// students submit for them and the grading of those submissions
+type AssignmentService struct {
+	assignmentRepo       *repository.AssignmentRepository
+	courseRepo           *repository.CourseRepository
+	enrollmentRepo       *repository.EnrollmentRepository
+	blobs                storage.BlobStore
+	auditService         *AuditService
+	assignmentDTOFactory *factory.AssignmentResponseDTOFactory
+	submissionDTOFactory *factory.SubmissionResponseDTOFactory
+}
+
+func NewAssignmentService(assignmentRepo *repository.AssignmentRepository, courseRepo *repository.CourseRepository, enrollmentRepo *repository.EnrollmentRepository, blobs storage.BlobStore, auditService *AuditService) *AssignmentService {
+	return &AssignmentService{
+		assignmentRepo:       assignmentRepo,
+		courseRepo:           courseRepo,
+		enrollmentRepo:       enrollmentRepo,
+		blobs:                blobs,
+		auditService:         auditService,
+		assignmentDTOFactory: factory.NewAssignmentResponseDTOFactory(),
+		submissionDTOFactory: factory.NewSubmissionResponseDTOFactory(),
+	}
+}
+
+func (s *AssignmentService) Create(subject policy.Subject, actor domain.Actor, courseID uint, req *dto.AssignmentCreateDTO) (*dto.AssignmentResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if !policy.CanManageAssignments(subject, course) {
+		return nil, errors.Forbidden("You can only add assignments to your own courses", nil)
+	}
+
+	assignment := &domain.Assignment{
+		CourseID:           course.ID,
+		Title:              req.Title,
+		Description:        req.Description,
+		DueAt:              req.DueAt,
+		MaxPoints:          req.MaxPoints,
+		LatePolicy:         domain.LatePolicyReject,
+		LatePenaltyPercent: req.LatePenaltyPercent,
+		MaxLateDays:        req.MaxLateDays,
+		CreatedBy:          actor.UserID,
+	}
+	if req.LatePolicy != "" {
+		assignment.LatePolicy = domain.LatePolicy(req.LatePolicy)
+	}
+	if err := validateLatePolicy(assignment); err != nil {
+		return nil, err
+	}
+
+	var response *dto.AssignmentResponseDTO
+	err = s.assignmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewAssignmentRepository(tx).Create(assignment); err != nil {
+			return errors.InternalServerError("Failed to create assignment", err)
+		}
+		response = s.assignmentDTOFactory.CreateFromEntity(assignment)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityAssignment, assignment.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetByCourse lists the assignments of a course by due date
+func (s *AssignmentService) GetByCourse(courseID uint) ([]dto.AssignmentResponseDTO, error) {
+	if _, err := s.courseRepo.FindByID(courseID); err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	assignments, err := s.assignmentRepo.FindByCourseID(courseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve assignments", err)
+	}
+
+	var dtos []dto.AssignmentResponseDTO
+	for _, assignment := range assignments {
+		dtos = append(dtos, *s.assignmentDTOFactory.CreateFromEntity(&assignment))
+	}
+
+	return dtos, nil
+}
+
+func (s *AssignmentService) GetByID(id uint) (*dto.AssignmentResponseDTO, error) {
+	assignment, err := s.assignmentRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Assignment not found", err)
+	}
+	return s.assignmentDTOFactory.CreateFromEntity(assignment), nil
+}
+
+// Update changes an assignment. Penalties of existing submissions are kept.
+func (s *AssignmentService) Update(subject policy.Subject, actor domain.Actor, id uint, req *dto.AssignmentUpdateDTO) (*dto.AssignmentResponseDTO, error) {
+	assignment, err := s.assignmentRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Assignment not found", err)
+	}
+	if !policy.CanManageAssignments(subject, &assignment.Course) {
+		return nil, errors.Forbidden("You can only modify assignments of your own courses", nil)
+	}
+	before := s.assignmentDTOFactory.CreateFromEntity(assignment)
+
+	if req.Title != "" {
+		assignment.Title = req.Title
+	}
+	if req.Description != nil {
+		assignment.Description = *req.Description
+	}
+	if !req.DueAt.IsZero() {
+		assignment.DueAt = req.DueAt
+	}
+	if req.MaxPoints != nil {
+		assignment.MaxPoints = *req.MaxPoints
+	}
+	if req.LatePolicy != "" {
+		assignment.LatePolicy = domain.LatePolicy(req.LatePolicy)
+	}
+	if req.LatePenaltyPercent != nil {
+		assignment.LatePenaltyPercent = *req.LatePenaltyPercent
+	}
+	if req.MaxLateDays != nil {
+		assignment.MaxLateDays = *req.MaxLateDays
+	}
+	if err := validateLatePolicy(assignment); err != nil {
+		return nil, err
+	}
+
+	var response *dto.AssignmentResponseDTO
+	err = s.assignmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewAssignmentRepository(tx).Update(assignment); err != nil {
+			return errors.InternalServerError("Failed to update assignment", err)
+		}
+		response = s.assignmentDTOFactory.CreateFromEntity(assignment)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityAssignment, assignment.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete removes an assignment together with its submissions and their files
+func (s *AssignmentService) Delete(subject policy.Subject, actor domain.Actor, id uint) error {
+	assignment, err := s.assignmentRepo.FindByID(id)
+	if err != nil {
+		return errors.NotFound("Assignment not found", err)
+	}
+	if !policy.CanManageAssignments(subject, &assignment.Course) {
+		return errors.Forbidden("You can only delete assignments of your own courses", nil)
+	}
+
+	var keys []string
+	err = s.assignmentRepo.Transaction(func(tx *repository.Repository) error {
+		assignmentRepo := repository.NewAssignmentRepository(tx)
+		var err error
+		if keys, err = assignmentRepo.DeleteSubmissions(assignment.ID); err != nil {
+			return errors.InternalServerError("Failed to delete submissions", err)
+		}
+		if err := assignmentRepo.Delete(assignment.ID); err != nil {
+			return errors.InternalServerError("Failed to delete assignment", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityAssignment, assignment.ID, s.assignmentDTOFactory.CreateFromEntity(assignment), nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		s.deleteBlob(key)
+	}
+	return nil
+}
+
+// Submit stores the file a student hands in. Late submissions are rejected or
+// penalized according to the late policy, and resubmitting replaces the
+// previous file until it has been graded.
+func (s *AssignmentService) Submit(subject policy.Subject, actor domain.Actor, assignmentID uint, upload *dto.SubmissionUploadDTO) (*dto.SubmissionResponseDTO, error) {
+	assignment, err := s.assignmentRepo.FindByID(assignmentID)
+	if err != nil {
+		return nil, errors.NotFound("Assignment not found", err)
+	}
+	if subject.StudentID == 0 {
+		return nil, errors.Forbidden("Only students can submit assignments", nil)
+	}
+	if upload.FileName == "" || len(upload.FileName) > 255 {
+		return nil, errors.BadRequest("File name must be between 1 and 255 characters", nil)
+	}
+	enrolled, err := s.isEnrolled(subject.StudentID, assignment.CourseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to check enrollment", err)
+	}
+	if !enrolled {
+		return nil, errors.Forbidden("You are not enrolled in this course", nil)
+	}
+
+	now := time.Now()
+	daysLate := grading.DaysLate(assignment.DueAt, now)
+	if !assignment.AcceptsLate(daysLate) {
+		return nil, errors.BadRequest("The deadline of this assignment has passed", nil).
+			WithDetails(map[string]interface{}{"dueAt": assignment.DueAt})
+	}
+	var penalty float64
+	if assignment.LatePolicy == domain.LatePolicyPenalty {
+		penalty = grading.LatePenalty(daysLate, assignment.LatePenaltyPercent)
+	}
+
+	existing, _ := s.assignmentRepo.FindSubmission(assignment.ID, subject.StudentID)
+	if existing != nil && existing.GradedAt != nil {
+		return nil, errors.Conflict("Your submission has already been graded", nil)
+	}
+
+	token, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to store file", err)
+	}
+	key := fmt.Sprintf("submissions/%d/%d/%s", assignment.ID, subject.StudentID, token)
+	size, err := s.blobs.Put(key, upload.Content)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to store file", err)
+	}
+
+	submission := existing
+	var before *dto.SubmissionResponseDTO
+	var oldKey string
+	if submission == nil {
+		submission = &domain.Submission{AssignmentID: assignment.ID, StudentID: subject.StudentID}
+	} else {
+		before = s.submissionDTOFactory.CreateFromEntity(submission)
+		oldKey = submission.StorageKey
+	}
+	submission.FileName = upload.FileName
+	submission.ContentType = upload.ContentType
+	submission.Size = size
+	submission.StorageKey = key
+	submission.SubmittedAt = now
+	submission.DaysLate = daysLate
+	submission.PenaltyPercent = penalty
+
+	var response *dto.SubmissionResponseDTO
+	err = s.assignmentRepo.Transaction(func(tx *repository.Repository) error {
+		assignmentRepo := repository.NewAssignmentRepository(tx)
+		if existing == nil {
+			if err := assignmentRepo.CreateSubmission(submission); err != nil {
+				return errors.InternalServerError("Failed to save submission", err)
+			}
+			response = s.submissionDTOFactory.CreateFromEntity(submission)
+			return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntitySubmission, submission.ID, nil, response)
+		}
+		if err := assignmentRepo.UpdateSubmission(submission); err != nil {
+			return errors.InternalServerError("Failed to save submission", err)
+		}
+		response = s.submissionDTOFactory.CreateFromEntity(submission)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntitySubmission, submission.ID, before, response)
+	})
+	if err != nil {
+		s.deleteBlob(key)
+		return nil, err
+	}
+	if oldKey != "" {
+		s.deleteBlob(oldKey)
+	}
+	return response, nil
+}
+
+// GetSubmissions lists the submissions of an assignment. Graders of the course
+// see every submission, students only their own.
+func (s *AssignmentService) GetSubmissions(subject policy.Subject, assignmentID uint) ([]dto.SubmissionResponseDTO, error) {
+	assignment, err := s.assignmentRepo.FindByID(assignmentID)
+	if err != nil {
+		return nil, errors.NotFound("Assignment not found", err)
+	}
+
+	var submissions []domain.Submission
+	switch {
+	case policy.CanManageAssignments(subject, &assignment.Course):
+		submissions, err = s.assignmentRepo.FindSubmissionsByAssignmentID(assignment.ID)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to retrieve submissions", err)
+		}
+	case subject.StudentID != 0:
+		if submission, _ := s.assignmentRepo.FindSubmission(assignment.ID, subject.StudentID); submission != nil {
+			submissions = append(submissions, *submission)
+		}
+	default:
+		return nil, errors.Forbidden("You can only view submissions of your own courses", nil)
+	}
+
+	var dtos []dto.SubmissionResponseDTO
+	for _, submission := range submissions {
+		dtos = append(dtos, *s.submissionDTOFactory.CreateFromEntity(&submission))
+	}
+
+	return dtos, nil
+}
+
+func (s *AssignmentService) GetSubmission(subject policy.Subject, id uint) (*dto.SubmissionResponseDTO, error) {
+	submission, err := s.findVisibleSubmission(subject, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.submissionDTOFactory.CreateFromEntity(submission), nil
+}
+
+// OpenSubmissionFile returns the submitted file. The caller must close it.
+func (s *AssignmentService) OpenSubmissionFile(subject policy.Subject, id uint) (*dto.SubmissionResponseDTO, io.ReadCloser, error) {
+	submission, err := s.findVisibleSubmission(subject, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Open(submission.StorageKey)
+	if stdErrors.Is(err, storage.ErrNotFound) {
+		return nil, nil, errors.NotFound("Submitted file is missing", err)
+	}
+	if err != nil {
+		return nil, nil, errors.InternalServerError("Failed to open submitted file", err)
+	}
+
+	return s.submissionDTOFactory.CreateFromEntity(submission), content, nil
+}
+
+// Grade scores a submission and deducts its late penalty. Grading again
+// replaces the previous score and feedback.
+func (s *AssignmentService) Grade(subject policy.Subject, actor domain.Actor, id uint, req *dto.SubmissionGradeDTO) (*dto.SubmissionResponseDTO, error) {
+	submission, err := s.assignmentRepo.FindSubmissionByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Submission not found", err)
+	}
+	course := &submission.Assignment.Course
+	if !policy.CanManageAssignments(subject, course) {
+		return nil, errors.Forbidden("You can only grade submissions in your own courses", nil)
+	}
+	if course.GradesLocked(time.Now()) {
+		return nil, errors.Conflict("Grades of this course are locked", nil)
+	}
+	if *req.Score > submission.Assignment.MaxPoints {
+		return nil, errors.BadRequest("Score exceeds the maximum points of the assignment", nil).
+			WithDetails(map[string]interface{}{"maxPoints": submission.Assignment.MaxPoints})
+	}
+	before := s.submissionDTOFactory.CreateFromEntity(submission)
+
+	now := time.Now()
+	grader := actor.UserID
+	score := *req.Score
+	finalScore := grading.ApplyPenalty(score, submission.PenaltyPercent)
+	submission.Score = &score
+	submission.FinalScore = &finalScore
+	submission.Feedback = req.Feedback
+	submission.GradedBy = &grader
+	submission.GradedAt = &now
+
+	var response *dto.SubmissionResponseDTO
+	err = s.assignmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewAssignmentRepository(tx).UpdateSubmission(submission); err != nil {
+			return errors.InternalServerError("Failed to grade submission", err)
+		}
+		response = s.submissionDTOFactory.CreateFromEntity(submission)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntitySubmission, submission.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *AssignmentService) findVisibleSubmission(subject policy.Subject, id uint) (*domain.Submission, error) {
+	submission, err := s.assignmentRepo.FindSubmissionByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Submission not found", err)
+	}
+	if !policy.CanViewSubmission(subject, submission) {
+		return nil, errors.Forbidden("You can only view your own submissions", nil)
+	}
+	return submission, nil
+}
+
+// isEnrolled reports whether the student is actively enrolled in the course
+func (s *AssignmentService) isEnrolled(studentID, courseID uint) (bool, error) {
+	enrollments, err := s.enrollmentRepo.FindByStudentID(studentID)
+	if err != nil {
+		return false, err
+	}
+	for _, enrollment := range enrollments {
+		if enrollment.CourseID == courseID && enrollment.Status == domain.EnrollmentStatusEnrolled {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *AssignmentService) deleteBlob(key string) {
+	if err := s.blobs.Delete(key); err != nil {
+		log.Printf("Failed to delete stored file %s: %v", key, err)
+	}
+}
+
+func validateLatePolicy(assignment *domain.Assignment) error {
+	if assignment.LatePolicy == domain.LatePolicyPenalty && assignment.LatePenaltyPercent <= 0 {
+		return errors.BadRequest("latePenaltyPercent is required for the PENALTY late policy", nil)
+	}
+	return nil
+}
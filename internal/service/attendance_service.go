@@ -0,0 +1,413 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/mailer"
+)
+
+// AttendanceService schedules the class sessions of courses, records who
+// attended them and alerts students whose absences exceed the limit
+type AttendanceService struct {
+	attendanceRepo    *repository.AttendanceRepository
+	courseRepo        *repository.CourseRepository
+	enrollmentRepo    *repository.EnrollmentRepository
+	auditService      *AuditService
+	mailer            mailer.Mailer
+	absenceLimit      int // Absences allowed before an alert is sent, 0 disables alerts
+	sessionDTOFactory *factory.ClassSessionResponseDTOFactory
+	recordDTOFactory  *factory.AttendanceRecordResponseDTOFactory
+}
+
+func NewAttendanceService(attendanceRepo *repository.AttendanceRepository, courseRepo *repository.CourseRepository, enrollmentRepo *repository.EnrollmentRepository, auditService *AuditService, mailer mailer.Mailer, absenceLimit int) *AttendanceService {
+	return &AttendanceService{
+		attendanceRepo:    attendanceRepo,
+		courseRepo:        courseRepo,
+		enrollmentRepo:    enrollmentRepo,
+		auditService:      auditService,
+		mailer:            mailer,
+		absenceLimit:      absenceLimit,
+		sessionDTOFactory: factory.NewClassSessionResponseDTOFactory(),
+		recordDTOFactory:  factory.NewAttendanceRecordResponseDTOFactory(),
+	}
+}
+
+func (s *AttendanceService) CreateSession(subject policy.Subject, actor domain.Actor, courseID uint, req *dto.ClassSessionCreateDTO) (*dto.ClassSessionResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if !policy.CanTakeAttendance(subject, course) {
+		return nil, errors.Forbidden("You can only schedule sessions of your own courses", nil)
+	}
+
+	session := &domain.ClassSession{
+		CourseID:  course.ID,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Room:      req.Room,
+		Topic:     req.Topic,
+		CreatedBy: actor.UserID,
+	}
+	if err := validateSession(session, course); err != nil {
+		return nil, err
+	}
+
+	var response *dto.ClassSessionResponseDTO
+	err = s.attendanceRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewAttendanceRepository(tx).CreateSession(session); err != nil {
+			return errors.InternalServerError("Failed to create session", err)
+		}
+		response = s.sessionDTOFactory.CreateFromEntity(session)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityClassSession, session.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetSessions lists the sessions of a course in chronological order
+func (s *AttendanceService) GetSessions(courseID uint) ([]dto.ClassSessionResponseDTO, error) {
+	if _, err := s.courseRepo.FindByID(courseID); err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	sessions, err := s.attendanceRepo.FindSessionsByCourseID(courseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve sessions", err)
+	}
+
+	var dtos []dto.ClassSessionResponseDTO
+	for _, session := range sessions {
+		dtos = append(dtos, *s.sessionDTOFactory.CreateFromEntity(&session))
+	}
+
+	return dtos, nil
+}
+
+func (s *AttendanceService) UpdateSession(subject policy.Subject, actor domain.Actor, id uint, req *dto.ClassSessionUpdateDTO) (*dto.ClassSessionResponseDTO, error) {
+	session, err := s.attendanceRepo.FindSessionByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Session not found", err)
+	}
+	if !policy.CanTakeAttendance(subject, &session.Course) {
+		return nil, errors.Forbidden("You can only modify sessions of your own courses", nil)
+	}
+	before := s.sessionDTOFactory.CreateFromEntity(session)
+
+	if !req.StartsAt.IsZero() {
+		session.StartsAt = req.StartsAt
+	}
+	if !req.EndsAt.IsZero() {
+		session.EndsAt = req.EndsAt
+	}
+	if req.Room != nil {
+		session.Room = *req.Room
+	}
+	if req.Topic != nil {
+		session.Topic = *req.Topic
+	}
+	if err := validateSession(session, &session.Course); err != nil {
+		return nil, err
+	}
+
+	var response *dto.ClassSessionResponseDTO
+	err = s.attendanceRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewAttendanceRepository(tx).UpdateSession(session); err != nil {
+			return errors.InternalServerError("Failed to update session", err)
+		}
+		response = s.sessionDTOFactory.CreateFromEntity(session)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityClassSession, session.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteSession removes a session together with the attendance taken at it
+func (s *AttendanceService) DeleteSession(subject policy.Subject, actor domain.Actor, id uint) error {
+	session, err := s.attendanceRepo.FindSessionByID(id)
+	if err != nil {
+		return errors.NotFound("Session not found", err)
+	}
+	if !policy.CanTakeAttendance(subject, &session.Course) {
+		return errors.Forbidden("You can only delete sessions of your own courses", nil)
+	}
+
+	return s.attendanceRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewAttendanceRepository(tx).DeleteSession(session.ID); err != nil {
+			return errors.InternalServerError("Failed to delete session", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityClassSession, session.ID, s.sessionDTOFactory.CreateFromEntity(session), nil)
+	})
+}
+
+// GetSessionAttendance lists the attendance taken at a session
+func (s *AttendanceService) GetSessionAttendance(subject policy.Subject, id uint) ([]dto.AttendanceRecordResponseDTO, error) {
+	session, err := s.attendanceRepo.FindSessionByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Session not found", err)
+	}
+	if !policy.CanTakeAttendance(subject, &session.Course) {
+		return nil, errors.Forbidden("You can only view attendance of your own courses", nil)
+	}
+
+	records, err := s.attendanceRepo.FindRecordsBySessionID(session.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve attendance", err)
+	}
+
+	var dtos []dto.AttendanceRecordResponseDTO
+	for _, record := range records {
+		dtos = append(dtos, *s.recordDTOFactory.CreateFromEntity(&record))
+	}
+
+	return dtos, nil
+}
+
+// MarkAttendance records the attendance of several students at a session.
+// Marking a student again replaces the previous status. Only students holding
+// a seat in the course can be marked, and only once the session has started.
+func (s *AttendanceService) MarkAttendance(subject policy.Subject, actor domain.Actor, id uint, req *dto.AttendanceMarkDTO) ([]dto.AttendanceRecordResponseDTO, error) {
+	session, err := s.attendanceRepo.FindSessionByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Session not found", err)
+	}
+	if !policy.CanTakeAttendance(subject, &session.Course) {
+		return nil, errors.Forbidden("You can only take attendance in your own courses", nil)
+	}
+	if time.Now().Before(session.StartsAt) {
+		return nil, errors.BadRequest("Attendance can only be taken once the session has started", nil).
+			WithDetails(map[string]interface{}{"startsAt": session.StartsAt})
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByCourseID(session.CourseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+	seated := make(map[uint]*domain.Enrollment)
+	for i := range enrollments {
+		if enrollments[i].HoldsSeat() {
+			seated[enrollments[i].ID] = &enrollments[i]
+		}
+	}
+
+	marked := make(map[uint]bool)
+	var notEnrolled []uint
+	for _, entry := range req.Records {
+		if marked[entry.EnrollmentID] {
+			return nil, errors.BadRequest("Each enrollment can only be marked once per request", nil).
+				WithDetails(map[string]interface{}{"enrollmentId": entry.EnrollmentID})
+		}
+		marked[entry.EnrollmentID] = true
+		if seated[entry.EnrollmentID] == nil {
+			notEnrolled = append(notEnrolled, entry.EnrollmentID)
+		}
+	}
+	if len(notEnrolled) > 0 {
+		return nil, errors.BadRequest("Only students enrolled in the course can be marked", nil).
+			WithDetails(map[string]interface{}{"enrollmentIds": notEnrolled})
+	}
+
+	existing, err := s.attendanceRepo.FindRecordsBySessionID(session.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve attendance", err)
+	}
+	previous := make(map[uint]*dto.AttendanceRecordResponseDTO)
+	for _, record := range existing {
+		previous[record.EnrollmentID] = s.recordDTOFactory.CreateFromEntity(&record)
+	}
+
+	records := make([]domain.AttendanceRecord, len(req.Records))
+	var dtos []dto.AttendanceRecordResponseDTO
+	err = s.attendanceRepo.Transaction(func(tx *repository.Repository) error {
+		attendanceRepo := repository.NewAttendanceRepository(tx)
+		for i, entry := range req.Records {
+			records[i] = domain.AttendanceRecord{
+				SessionID:    session.ID,
+				EnrollmentID: entry.EnrollmentID,
+				Status:       domain.AttendanceStatus(entry.Status),
+				Note:         entry.Note,
+				MarkedBy:     actor.UserID,
+			}
+			if err := attendanceRepo.SaveRecord(&records[i]); err != nil {
+				return errors.InternalServerError("Failed to save attendance", err)
+			}
+
+			response := s.recordDTOFactory.CreateFromEntity(&records[i])
+			if before, ok := previous[records[i].EnrollmentID]; ok {
+				err = s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityAttendance, response.ID, before, response)
+			} else {
+				err = s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityAttendance, response.ID, nil, response)
+			}
+			if err != nil {
+				return err
+			}
+			dtos = append(dtos, *response)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		if records[i].Status == domain.AttendanceAbsent {
+			s.checkAbsences(seated[records[i].EnrollmentID], &session.Course)
+		}
+	}
+
+	return dtos, nil
+}
+
+// GetEnrollmentAttendance returns the attendance of a student in a course
+// together with every record
+func (s *AttendanceService) GetEnrollmentAttendance(subject policy.Subject, enrollmentID uint) (*dto.AttendanceSummaryDTO, error) {
+	enrollment, err := s.enrollmentRepo.FindByID(enrollmentID)
+	if err != nil {
+		return nil, errors.NotFound("Enrollment not found", err)
+	}
+	if !policy.CanViewAttendance(subject, enrollment) {
+		return nil, errors.Forbidden("You can only view your own attendance", nil)
+	}
+
+	records, err := s.attendanceRepo.FindRecordsByEnrollmentID(enrollment.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve attendance", err)
+	}
+
+	summary := summarizeAttendance(enrollment, records)
+	for _, record := range records {
+		summary.Records = append(summary.Records, *s.recordDTOFactory.CreateFromEntity(&record))
+	}
+	return summary, nil
+}
+
+// GetCourseAttendance returns the attendance of every student holding a seat
+// in a course
+func (s *AttendanceService) GetCourseAttendance(subject policy.Subject, courseID uint) ([]dto.AttendanceSummaryDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if !policy.CanTakeAttendance(subject, course) {
+		return nil, errors.Forbidden("You can only view attendance of your own courses", nil)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+	records, err := s.attendanceRepo.FindRecordsByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve attendance", err)
+	}
+	byEnrollment := make(map[uint][]domain.AttendanceRecord)
+	for _, record := range records {
+		byEnrollment[record.EnrollmentID] = append(byEnrollment[record.EnrollmentID], record)
+	}
+
+	var dtos []dto.AttendanceSummaryDTO
+	for _, enrollment := range enrollments {
+		if !enrollment.HoldsSeat() {
+			continue
+		}
+		dtos = append(dtos, *summarizeAttendance(&enrollment, byEnrollment[enrollment.ID]))
+	}
+
+	return dtos, nil
+}
+
+// checkAbsences alerts the student and the teacher the first time the absences
+// of an enrollment exceed the limit. Failures are logged, since the attendance
+// itself has already been saved.
+func (s *AttendanceService) checkAbsences(enrollment *domain.Enrollment, course *domain.Course) {
+	if s.absenceLimit <= 0 {
+		return
+	}
+
+	absences, err := s.attendanceRepo.CountAbsences(enrollment.ID)
+	if err != nil {
+		log.Printf("Failed to count absences of enrollment %d: %v", enrollment.ID, err)
+		return
+	}
+	if absences <= s.absenceLimit {
+		return
+	}
+
+	created, err := s.attendanceRepo.CreateAlert(&domain.AttendanceAlert{EnrollmentID: enrollment.ID, Absences: absences})
+	if err != nil {
+		log.Printf("Failed to record absence alert of enrollment %d: %v", enrollment.ID, err)
+		return
+	}
+	if !created {
+		return
+	}
+
+	student := enrollment.Student.User
+	body := fmt.Sprintf(
+		"Hello,\n\n%s %s has missed %d sessions of %s %s, exceeding the limit of %d absences.\n",
+		student.FirstName, student.LastName, absences, course.Code, course.Name, s.absenceLimit,
+	)
+	subject := fmt.Sprintf("Attendance alert for %s", course.Code)
+	for _, to := range []string{student.Email, course.Teacher.User.Email} {
+		if to == "" {
+			continue
+		}
+		if err := s.mailer.Send(mailer.Message{To: to, Subject: subject, Body: body}); err != nil {
+			log.Printf("Failed to send attendance alert to %s: %v", to, err)
+		}
+	}
+}
+
+// summarizeAttendance counts the records of an enrollment. Late students count
+// as attended and excused absences are left out of the percentage.
+func summarizeAttendance(enrollment *domain.Enrollment, records []domain.AttendanceRecord) *dto.AttendanceSummaryDTO {
+	summary := &dto.AttendanceSummaryDTO{
+		EnrollmentID: enrollment.ID,
+		StudentID:    enrollment.StudentID,
+		StudentName:  enrollment.Student.User.FirstName + " " + enrollment.Student.User.LastName,
+		CourseID:     enrollment.CourseID,
+		Sessions:     len(records),
+	}
+	for _, record := range records {
+		switch record.Status {
+		case domain.AttendancePresent:
+			summary.Present++
+		case domain.AttendanceLate:
+			summary.Late++
+		case domain.AttendanceAbsent:
+			summary.Absent++
+		case domain.AttendanceExcused:
+			summary.Excused++
+		}
+	}
+
+	if counted := summary.Sessions - summary.Excused; counted > 0 {
+		percentage := float64(summary.Present+summary.Late) / float64(counted) * 100
+		percentage = float64(int(percentage*100+0.5)) / 100
+		summary.Percentage = &percentage
+	}
+	return summary
+}
+
+func validateSession(session *domain.ClassSession, course *domain.Course) error {
+	if !session.EndsAt.After(session.StartsAt) {
+		return errors.BadRequest("Session must end after it starts", nil)
+	}
+	if session.StartsAt.Before(course.StartDate) || session.StartsAt.After(course.EndDate.AddDate(0, 0, 1)) {
+		return errors.BadRequest("Session must take place between the start and end date of the course", nil).
+			WithDetails(map[string]interface{}{"startDate": course.StartDate, "endDate": course.EndDate})
+	}
+	return nil
+}
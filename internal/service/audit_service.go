@@ -0,0 +1,135 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// AuditService writes the audit log. Services record every mutation with the
+// response DTO of the entity before and after the change, so snapshots never
+// contain secrets such as password hashes.
+type AuditService struct {
+	auditRepo       *repository.AuditLogRepository
+	auditDTOFactory *factory.AuditLogResponseDTOFactory
+}
+
+func NewAuditService(auditRepo *repository.AuditLogRepository) *AuditService {
+	return &AuditService{
+		auditRepo:       auditRepo,
+		auditDTOFactory: factory.NewAuditLogResponseDTOFactory(),
+	}
+}
+
+// Record appends an entry for a change. before is nil for creations and after
+// is nil for hard deletions. It must run in the transaction of the change, so
+// the change is rolled back when its entry cannot be written.
+func (s *AuditService) Record(tx *repository.Repository, actor domain.Actor, action domain.AuditAction, entityType domain.AuditEntityType, entityID uint, before, after interface{}) error {
+	entry := &domain.AuditLog{
+		ActorRole:  actor.Role,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		RequestID:  actor.RequestID,
+		IP:         actor.IP,
+	}
+	if actor.UserID != 0 {
+		actorID := actor.UserID
+		entry.ActorID = &actorID
+	}
+
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return errors.InternalServerError("Failed to encode audit snapshot", err)
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return errors.InternalServerError("Failed to encode audit snapshot", err)
+	}
+	if entry.Diff, err = diffSnapshots(entry.Before, entry.After); err != nil {
+		return errors.InternalServerError("Failed to compute audit diff", err)
+	}
+
+	if err := repository.NewAuditLogRepository(tx).Create(entry); err != nil {
+		return errors.InternalServerError("Failed to record audit entry", err)
+	}
+	return nil
+}
+
+// GetAll lists audit entries, newest first
+func (s *AuditService) GetAll(query *dto.AuditLogQueryDTO) ([]dto.AuditLogResponseDTO, error) {
+	filter := repository.AuditLogFilter(*query)
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAuditLimit
+	}
+	if filter.Limit > maxAuditLimit {
+		filter.Limit = maxAuditLimit
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, errors.BadRequest("to must not be before from", nil)
+	}
+
+	entries, err := s.auditRepo.FindAll(filter)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve audit log", err)
+	}
+
+	var dtos []dto.AuditLogResponseDTO
+	for _, entry := range entries {
+		dtos = append(dtos, *s.auditDTOFactory.CreateFromEntity(&entry))
+	}
+
+	return dtos, nil
+}
+
+// snapshot encodes an entity as JSON, nil stays nil
+func snapshot(value interface{}) (json.RawMessage, error) {
+	if value == nil || reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil() {
+		return nil, nil
+	}
+	return json.Marshal(value)
+}
+
+// diffSnapshots maps every top-level field that differs between two snapshots
+// to {"from": old, "to": new}. A missing snapshot counts as an empty object.
+func diffSnapshots(before, after json.RawMessage) (json.RawMessage, error) {
+	if before == nil && after == nil {
+		return nil, nil
+	}
+
+	oldFields := map[string]interface{}{}
+	newFields := map[string]interface{}{}
+	if before != nil {
+		if err := json.Unmarshal(before, &oldFields); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if err := json.Unmarshal(after, &newFields); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := map[string]map[string]interface{}{}
+	for key, oldValue := range oldFields {
+		if newValue, ok := newFields[key]; !ok || !reflect.DeepEqual(oldValue, newValue) {
+			diff[key] = map[string]interface{}{"from": oldValue, "to": newFields[key]}
+		}
+	}
+	for key, newValue := range newFields {
+		if _, ok := oldFields[key]; !ok {
+			diff[key] = map[string]interface{}{"from": nil, "to": newValue}
+		}
+	}
+
+	return json.Marshal(diff)
+}
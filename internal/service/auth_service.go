@@ -1,6 +1,10 @@
 package service
 
 import (
+	stdErrors "errors"
+	"net/http"
+	"time"
+
 	"github.com/Tretorhate/university-management-system/internal/domain"
 	"github.com/Tretorhate/university-management-system/internal/dto"
 	"github.com/Tretorhate/university-management-system/internal/repository"
@@ -10,76 +14,522 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+var errRefreshTokenReused = stdErrors.New("refresh token reused")
+
 type AuthService struct {
-	userRepo     *repository.UserRepository
-	jwtService   *auth.JWTService
+	userRepo       *repository.UserRepository
+	studentRepo    *repository.StudentRepository
+	teacherRepo    *repository.TeacherRepository
+	invitationRepo *repository.InvitationRepository
+	sessionRepo    *repository.SessionRepository
+	auditService   *AuditService
+	accountService *AccountService
+	twoFactor      *TwoFactorService
+	loginGuard     *LoginGuard
+	jwtService     *auth.JWTService
+	refreshTTL     time.Duration
 	userDTOFactory *factory.UserDTOFactory
+
+	// requireEmailVerification blocks login until the user confirmed their email
+	requireEmailVerification bool
 }
 
-func NewAuthService(userRepo *repository.UserRepository, jwtService *auth.JWTService) *AuthService {
+// DefaultRefreshTokenTTL is how long a session stays alive without being refreshed
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+func NewAuthService(
+	userRepo *repository.UserRepository,
+	studentRepo *repository.StudentRepository,
+	teacherRepo *repository.TeacherRepository,
+	invitationRepo *repository.InvitationRepository,
+	sessionRepo *repository.SessionRepository,
+	auditService *AuditService,
+	accountService *AccountService,
+	twoFactor *TwoFactorService,
+	loginGuard *LoginGuard,
+	jwtService *auth.JWTService,
+	refreshTTL time.Duration,
+	requireEmailVerification bool,
+) *AuthService {
+	if refreshTTL <= 0 {
+		refreshTTL = DefaultRefreshTokenTTL
+	}
 	return &AuthService{
-		userRepo:     userRepo,
-		jwtService:   jwtService,
+		userRepo:       userRepo,
+		studentRepo:    studentRepo,
+		teacherRepo:    teacherRepo,
+		invitationRepo: invitationRepo,
+		sessionRepo:    sessionRepo,
+		auditService:   auditService,
+		accountService: accountService,
+		twoFactor:      twoFactor,
+		loginGuard:     loginGuard,
+		jwtService:     jwtService,
+		refreshTTL:     refreshTTL,
 		userDTOFactory: factory.NewUserDTOFactory(),
+
+		requireEmailVerification: requireEmailVerification,
 	}
 }
 
-func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
+// Register signs up a new student. Staff accounts can only be created by admins
+// or through an invitation.
+func (s *AuthService) Register(actor domain.Actor, req *dto.RegisterRequest) (*dto.AuthResponse, error) {
 	// Check if user already exists
 	existingUser, _ := s.userRepo.FindByEmail(req.Email)
 	if existingUser != nil {
 		return nil, errors.BadRequest("User with this email already exists", nil)
 	}
 
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	existingStudent, _ := s.studentRepo.FindByStudentID(req.StudentID)
+	if existingStudent != nil {
+		return nil, errors.BadRequest("Student with this ID already exists", nil)
+	}
+
+	user, err := s.newUser(req.Email, req.Password, req.FirstName, req.LastName, domain.RoleStudent)
 	if err != nil {
-		return nil, errors.InternalServerError("Failed to hash password", err)
+		return nil, err
 	}
 
-	// Create user
-	user := &domain.User{
-		Email:     req.Email,
-		Password:  string(hashedPassword),
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Role:      domain.Role(req.Role),
+	// Create the user and its student profile together
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Create(user); err != nil {
+			return errors.InternalServerError("Failed to create user", err)
+		}
+
+		student := &domain.Student{
+			UserID:     user.ID,
+			StudentID:  req.StudentID,
+			EnrollYear: req.EnrollYear,
+			Major:      req.Major,
+		}
+		if err := repository.NewStudentRepository(tx).Create(student); err != nil {
+			return errors.InternalServerError("Failed to create student", err)
+		}
+		return s.auditService.Record(tx, actor.OrUser(user), domain.AuditActionCreate, domain.AuditEntityUser, user.ID, nil, s.userDTOFactory.CreateFromEntity(user))
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
-		return nil, errors.InternalServerError("Failed to create user", err)
+	// The account exists either way; a failed email can be resent later
+	_ = s.accountService.SendVerification(user)
+
+	// No tokens are issued until the email address is confirmed
+	if s.requireEmailVerification {
+		return &dto.AuthResponse{User: *s.userDTOFactory.CreateFromEntity(user)}, nil
 	}
+	return s.authResponse(user)
+}
 
-	// Generate token
-	token, err := s.jwtService.GenerateToken(user)
+// RegisterWithInvitation creates the account an admin invited. The invitation is
+// consumed in the same transaction so it can only be used once.
+func (s *AuthService) RegisterWithInvitation(actor domain.Actor, req *dto.InvitationRegisterRequest) (*dto.AuthResponse, error) {
+	invitation, err := s.invitationRepo.FindByTokenHash(auth.HashToken(req.Token))
 	if err != nil {
-		return nil, errors.InternalServerError("Failed to generate token", err)
+		return nil, errors.BadRequest("Invalid invitation token", nil)
 	}
 
-	// Use factory to create user DTO
-	userDTO := s.userDTOFactory.CreateFromEntity(user)
+	now := time.Now()
+	if status := invitation.Status(now); status != domain.InvitationPending {
+		return nil, errors.BadRequest("Invitation is no longer valid", nil).
+			WithDetails(map[string]interface{}{"status": status})
+	}
 
-	return &dto.AuthResponse{
-		Token: token,
-		User:  *userDTO,
-	}, nil
+	existingUser, _ := s.userRepo.FindByEmail(invitation.Email)
+	if existingUser != nil {
+		return nil, errors.BadRequest("User with this email already exists", nil)
+	}
+
+	// Teachers need a teacher profile; other staff such as department heads get
+	// one when they provide the teacher fields
+	createTeacher := invitation.Role == domain.RoleTeacher || req.EmployeeID != ""
+	if createTeacher {
+		if req.EmployeeID == "" || req.Speciality == "" || req.JoiningDate.IsZero() {
+			return nil, errors.BadRequest("employeeId, speciality and joiningDate are required for teacher profiles", nil)
+		}
+		existingTeacher, _ := s.teacherRepo.FindByEmployeeID(req.EmployeeID)
+		if existingTeacher != nil {
+			return nil, errors.BadRequest("Teacher with this employee ID already exists", nil)
+		}
+	}
+
+	user, err := s.newUser(invitation.Email, req.Password, req.FirstName, req.LastName, invitation.Role)
+	if err != nil {
+		return nil, err
+	}
+	// The invitation was sent to this address, so it is already confirmed
+	user.EmailVerifiedAt = &now
+
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Create(user); err != nil {
+			return errors.InternalServerError("Failed to create user", err)
+		}
+
+		accepted, err := repository.NewInvitationRepository(tx).MarkAccepted(invitation.ID, user.ID, now)
+		if err != nil {
+			return errors.InternalServerError("Failed to accept invitation", err)
+		}
+		if !accepted {
+			return errors.BadRequest("Invitation is no longer valid", nil)
+		}
+
+		if createTeacher {
+			department, err := repository.NewDepartmentRepository(tx).FindByCode(domain.CodePrefix(req.EmployeeID))
+			if err != nil {
+				return errors.BadRequest("No department matches the employee ID", err).
+					WithDetails(map[string]interface{}{"departmentCode": domain.CodePrefix(req.EmployeeID)})
+			}
+			teacher := &domain.Teacher{
+				UserID:       user.ID,
+				EmployeeID:   req.EmployeeID,
+				DepartmentID: department.ID,
+				Speciality:   req.Speciality,
+				JoiningDate:  req.JoiningDate,
+			}
+			if err := repository.NewTeacherRepository(tx).Create(teacher); err != nil {
+				return errors.InternalServerError("Failed to create teacher", err)
+			}
+		}
+		return s.auditService.Record(tx, actor.OrUser(user), domain.AuditActionCreate, domain.AuditEntityUser, user.ID, nil, s.userDTOFactory.CreateFromEntity(user))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authResponse(user)
 }
 
-func (s *AuthService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
+// Login checks the password of a user. Failed attempts are throttled per
+// account and per client IP.
+func (s *AuthService) Login(req *dto.LoginRequest, clientIP string) (*dto.AuthResponse, error) {
+	if err := s.loginGuard.Check(req.Email, clientIP); err != nil {
+		return nil, err
+	}
+
 	// Find user
 	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
+		s.loginGuard.Failure(req.Email, clientIP, nil)
 		return nil, errors.Unauthorized("Invalid email or password", nil)
 	}
 
 	// Verify password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
+		s.loginGuard.Failure(req.Email, clientIP, user)
 		return nil, errors.Unauthorized("Invalid email or password", nil)
 	}
 
+	if s.requireEmailVerification && user.EmailVerifiedAt == nil {
+		return nil, errors.Forbidden("Email address has not been verified", nil)
+	}
+
+	// Users with two-factor authentication, or whose role requires it, finish
+	// the login with a challenge token
+	if user.TwoFactorEnabled() || s.twoFactor.IsRequired(user.Role) {
+		return s.challengeResponse(user)
+	}
+
+	s.loginGuard.Success(user.Email)
+	return s.authResponse(user)
+}
+
+// VerifyTwoFactor completes a login with an authenticator or recovery code.
+// Wrong codes count as failed logins of the account.
+func (s *AuthService) VerifyTwoFactor(req *dto.TwoFactorVerifyRequest, clientIP string) (*dto.AuthResponse, error) {
+	user, setup, err := s.challengeUser(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if setup {
+		return nil, errors.BadRequest("Two-factor authentication must be set up first", nil)
+	}
+
+	if err := s.loginGuard.Check(user.Email, clientIP); err != nil {
+		return nil, err
+	}
+	if err := s.twoFactor.verify(user, req.Code, req.RecoveryCode); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Code == http.StatusUnauthorized {
+			s.loginGuard.Failure(user.Email, clientIP, user)
+		}
+		return nil, err
+	}
+
+	s.loginGuard.Success(user.Email)
+	return s.authResponse(user)
+}
+
+// SetupTwoFactor starts enrollment for a user whose role requires two-factor
+// authentication but who has not enabled it yet
+func (s *AuthService) SetupTwoFactor(req *dto.TwoFactorChallengeRequest) (*dto.TwoFactorSetupDTO, error) {
+	user, setup, err := s.challengeUser(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !setup {
+		return nil, errors.BadRequest("Two-factor authentication is already set up", nil)
+	}
+
+	return s.twoFactor.setup(user)
+}
+
+// ActivateTwoFactor finishes enrollment during login and starts the session.
+// The recovery codes are included in the response.
+func (s *AuthService) ActivateTwoFactor(req *dto.TwoFactorActivateRequest) (*dto.AuthResponse, error) {
+	user, setup, err := s.challengeUser(req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !setup {
+		return nil, errors.BadRequest("Two-factor authentication is already set up", nil)
+	}
+
+	codes, err := s.twoFactor.activate(user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	s.loginGuard.Success(user.Email)
+
+	response, err := s.authResponse(user)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = codes
+	return response, nil
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token pair.
+// Refresh tokens rotate on every use; replaying an already used token is
+// treated as theft and revokes the whole session.
+func (s *AuthService) Refresh(req *dto.RefreshRequest) (*dto.AuthResponse, error) {
+	token, err := s.sessionRepo.FindRefreshTokenByHash(auth.HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, errors.Unauthorized("Invalid refresh token", nil)
+	}
+
+	now := time.Now()
+	if !token.Session.IsActive(now) || now.After(token.ExpiresAt) {
+		return nil, errors.Unauthorized("Session has expired or was revoked", nil)
+	}
+
+	user, err := s.userRepo.FindByID(token.Session.UserID)
+	if err != nil {
+		_ = s.sessionRepo.Revoke(token.SessionID)
+		return nil, errors.Unauthorized("Session has expired or was revoked", nil)
+	}
+
+	var refreshToken string
+	err = s.sessionRepo.Transaction(func(tx *repository.Repository) error {
+		sessionRepo := repository.NewSessionRepository(tx)
+
+		fresh, err := sessionRepo.MarkRefreshTokenUsed(token.ID, now)
+		if err != nil {
+			return errors.InternalServerError("Failed to rotate refresh token", err)
+		}
+		if !fresh {
+			return errRefreshTokenReused
+		}
+
+		refreshToken, err = s.issueRefreshToken(sessionRepo, token.SessionID, now)
+		return err
+	})
+	if err == errRefreshTokenReused {
+		// Revoke outside the rolled back transaction so it sticks
+		if err := s.sessionRepo.Revoke(token.SessionID); err != nil {
+			return nil, errors.InternalServerError("Failed to revoke session", err)
+		}
+		return nil, errors.Unauthorized("Refresh token has already been used, session revoked", nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tokenResponse(user, token.SessionID, refreshToken)
+}
+
+// Logout revokes the session the refresh token belongs to
+func (s *AuthService) Logout(req *dto.RefreshRequest) error {
+	token, err := s.sessionRepo.FindRefreshTokenByHash(auth.HashToken(req.RefreshToken))
+	if err != nil {
+		return errors.Unauthorized("Invalid refresh token", nil)
+	}
+
+	if err := s.sessionRepo.Revoke(token.SessionID); err != nil {
+		return errors.InternalServerError("Failed to revoke session", err)
+	}
+	return nil
+}
+
+// ChangePassword updates the password of a user and signs out all of their
+// sessions. A new session is started for the caller.
+func (s *AuthService) ChangePassword(actor domain.Actor, req *dto.ChangePasswordRequest) (*dto.AuthResponse, error) {
+	user, err := s.userRepo.FindByID(actor.UserID)
+	if err != nil {
+		return nil, errors.NotFound("User not found", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword)); err != nil {
+		return nil, errors.Unauthorized("Current password is incorrect", nil)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to hash password", err)
+	}
+	user.Password = string(hashedPassword)
+
+	// The old sessions must not outlive the old password
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return errors.InternalServerError("Failed to update password", err)
+		}
+		if err := repository.NewSessionRepository(tx).RevokeAllByUserID(user.ID); err != nil {
+			return errors.InternalServerError("Failed to revoke sessions", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionPasswordChange, domain.AuditEntityUser, user.ID, nil, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authResponse(user)
+}
+
+// EnsureAdmin creates the first admin account when none exists yet, so a fresh
+// installation can start issuing invitations
+func (s *AuthService) EnsureAdmin(email, password string) (bool, error) {
+	count, err := s.userRepo.CountByRole(domain.RoleAdmin)
+	if err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return false, nil
+	}
+
+	user, err := s.newUser(email, password, "System", "Administrator", domain.RoleAdmin)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Create(user); err != nil {
+			return err
+		}
+		// Recorded without an actor since the system creates this account
+		return s.auditService.Record(tx, domain.Actor{}, domain.AuditActionCreate, domain.AuditEntityUser, user.ID, nil, s.userDTOFactory.CreateFromEntity(user))
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *AuthService) newUser(email, password, firstName, lastName string, role domain.Role) (*domain.User, error) {
+	// Hash password
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to hash password", err)
+	}
+
+	return &domain.User{
+		Email:     email,
+		Password:  string(hashedPassword),
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      role,
+	}, nil
+}
+
+func (s *AuthService) challengeResponse(user *domain.User) (*dto.AuthResponse, error) {
+	setup := !user.TwoFactorEnabled()
+	token, err := s.jwtService.GenerateChallengeToken(user, setup)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to generate challenge token", err)
+	}
+
+	return &dto.AuthResponse{
+		TwoFactorRequired:      !setup,
+		TwoFactorSetupRequired: setup,
+		ChallengeToken:         token,
+		User:                   *s.userDTOFactory.CreateFromEntity(user),
+	}, nil
+}
+
+// challengeUser resolves the user of a challenge token issued by Login
+func (s *AuthService) challengeUser(challengeToken string) (*domain.User, bool, error) {
+	claims, err := s.jwtService.ValidateChallengeToken(challengeToken)
+	if err != nil {
+		return nil, false, errors.Unauthorized("Invalid or expired challenge token", nil)
+	}
+
+	user, err := s.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		return nil, false, errors.Unauthorized("Invalid or expired challenge token", nil)
+	}
+
+	return user, claims.Setup, nil
+}
+
+// authResponse starts a new session for the user and issues its first tokens
+func (s *AuthService) authResponse(user *domain.User) (*dto.AuthResponse, error) {
+	sessionID, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to create session", err)
+	}
+
+	now := time.Now()
+	var refreshToken string
+	err = s.sessionRepo.Transaction(func(tx *repository.Repository) error {
+		sessionRepo := repository.NewSessionRepository(tx)
+
+		session := &domain.Session{
+			ID:        sessionID,
+			UserID:    user.ID,
+			ExpiresAt: now.Add(s.refreshTTL),
+		}
+		if err := sessionRepo.Create(session); err != nil {
+			return errors.InternalServerError("Failed to create session", err)
+		}
+
+		refreshToken, err = s.issueRefreshToken(sessionRepo, sessionID, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tokenResponse(user, sessionID, refreshToken)
+}
+
+// issueRefreshToken adds a new refresh token to the session and extends the
+// session to match its expiry
+func (s *AuthService) issueRefreshToken(sessionRepo *repository.SessionRepository, sessionID string, now time.Time) (string, error) {
+	token, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", errors.InternalServerError("Failed to generate refresh token", err)
+	}
+
+	expiresAt := now.Add(s.refreshTTL)
+	refreshToken := &domain.RefreshToken{
+		SessionID: sessionID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: expiresAt,
+	}
+	if err := sessionRepo.CreateRefreshToken(refreshToken); err != nil {
+		return "", errors.InternalServerError("Failed to store refresh token", err)
+	}
+	if err := sessionRepo.Extend(sessionID, expiresAt); err != nil {
+		return "", errors.InternalServerError("Failed to extend session", err)
+	}
+
+	return token, nil
+}
+
+func (s *AuthService) tokenResponse(user *domain.User, sessionID, refreshToken string) (*dto.AuthResponse, error) {
 	// Generate token
-	token, err := s.jwtService.GenerateToken(user)
+	token, err := s.jwtService.GenerateToken(user, sessionID)
 	if err != nil {
 		return nil, errors.InternalServerError("Failed to generate token", err)
 	}
@@ -87,8 +537,11 @@ func (s *AuthService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
 	// Use factory to create user DTO
 	userDTO := s.userDTOFactory.CreateFromEntity(user)
 
+	expiresAt := time.Now().Add(s.jwtService.TTL())
 	return &dto.AuthResponse{
-		Token: token,
-		User:  *userDTO,
+		Token:        token,
+		ExpiresAt:    &expiresAt,
+		RefreshToken: refreshToken,
+		User:         *userDTO,
 	}, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/pkg/auth"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/ical"
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+)
+
+const calendarProdID = "-//University Management System//Timetable//EN"
+
+// CalendarService publishes the timetables of students and teachers as
+// iCalendar feeds that calendar applications can subscribe to. Feeds are read
+// with a secret token in the URL instead of a JWT.
+type CalendarService struct {
+	feedRepo       *repository.CalendarFeedRepository
+	userRepo       *repository.UserRepository
+	studentRepo    *repository.StudentRepository
+	teacherRepo    *repository.TeacherRepository
+	enrollmentRepo *repository.EnrollmentRepository
+	courseRepo     *repository.CourseRepository
+	meetingRepo    *repository.MeetingRepository
+	assignmentRepo *repository.AssignmentRepository
+	baseURL        string
+	uidDomain      string // Makes event UIDs globally unique
+}
+
+func NewCalendarService(feedRepo *repository.CalendarFeedRepository, userRepo *repository.UserRepository, studentRepo *repository.StudentRepository, teacherRepo *repository.TeacherRepository, enrollmentRepo *repository.EnrollmentRepository, courseRepo *repository.CourseRepository, meetingRepo *repository.MeetingRepository, assignmentRepo *repository.AssignmentRepository, baseURL string) *CalendarService {
+	uidDomain := "university-management-system"
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Hostname() != "" {
+		uidDomain = parsed.Hostname()
+	}
+
+	return &CalendarService{
+		feedRepo:       feedRepo,
+		userRepo:       userRepo,
+		studentRepo:    studentRepo,
+		teacherRepo:    teacherRepo,
+		enrollmentRepo: enrollmentRepo,
+		courseRepo:     courseRepo,
+		meetingRepo:    meetingRepo,
+		assignmentRepo: assignmentRepo,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		uidDomain:      uidDomain,
+	}
+}
+
+// CreateFeed issues a new feed URL for the user. Any previous URL stops working.
+func (s *CalendarService) CreateFeed(subject policy.Subject) (*dto.CalendarFeedResponseDTO, error) {
+	if subject.StudentID == 0 && subject.TeacherID == 0 {
+		return nil, errors.Forbidden("Only students and teachers have a timetable feed", nil)
+	}
+
+	token, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to generate feed token", err)
+	}
+
+	feed := &domain.CalendarFeed{
+		UserID:    subject.UserID,
+		TokenHash: auth.HashToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := s.feedRepo.Save(feed); err != nil {
+		return nil, errors.InternalServerError("Failed to create calendar feed", err)
+	}
+
+	return &dto.CalendarFeedResponseDTO{
+		URL:       fmt.Sprintf("%s/calendar/%s/feed.ics", s.baseURL, token),
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+// GetFeed describes the feed of the user. The URL cannot be shown again.
+func (s *CalendarService) GetFeed(userID uint) (*dto.CalendarFeedResponseDTO, error) {
+	feed, err := s.feedRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.NotFound("No calendar feed has been created", err)
+	}
+	return &dto.CalendarFeedResponseDTO{CreatedAt: feed.CreatedAt, LastUsedAt: feed.LastUsedAt}, nil
+}
+
+func (s *CalendarService) RevokeFeed(userID uint) error {
+	deleted, err := s.feedRepo.DeleteByUserID(userID)
+	if err != nil {
+		return errors.InternalServerError("Failed to revoke calendar feed", err)
+	}
+	if !deleted {
+		return errors.NotFound("No calendar feed has been created", nil)
+	}
+	return nil
+}
+
+// Feed renders the calendar behind a feed token: the weekly meetings, term
+// dates and assignment due dates of the courses the user attends or teaches
+// that have not ended yet
+func (s *CalendarService) Feed(token string) ([]byte, error) {
+	feed, err := s.feedRepo.FindByHash(auth.HashToken(token))
+	if err != nil {
+		return nil, errors.NotFound("Calendar feed not found", err)
+	}
+	user, err := s.userRepo.FindByID(feed.UserID)
+	if err != nil {
+		return nil, errors.NotFound("Calendar feed not found", err)
+	}
+	if err := s.feedRepo.Touch(feed.ID, time.Now()); err != nil {
+		log.Printf("Failed to record use of calendar feed %d: %v", feed.ID, err)
+	}
+
+	var courses []domain.Course
+	student, _ := s.studentRepo.FindByUserID(user.ID)
+	if student != nil {
+		enrollments, err := s.enrollmentRepo.FindByStudentID(student.ID)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+		}
+		for _, enrollment := range enrollments {
+			if enrollment.HoldsSeat() && inTimetable(&enrollment.Course, 0) {
+				courses = append(courses, enrollment.Course)
+			}
+		}
+	}
+	teacher, _ := s.teacherRepo.FindByUserID(user.ID)
+	if teacher != nil {
+		taught, err := s.courseRepo.FindByTeacherID(teacher.ID)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to retrieve courses", err)
+		}
+		for _, course := range taught {
+			if inTimetable(&course, 0) {
+				courses = append(courses, course)
+			}
+		}
+	}
+
+	calendar := &ical.Calendar{
+		ProdID:    calendarProdID,
+		Name:      fmt.Sprintf("Timetable of %s %s", user.FirstName, user.LastName),
+		Generated: time.Now(),
+	}
+	if calendar.Events, err = s.events(courses, student != nil, teacher != nil); err != nil {
+		return nil, err
+	}
+
+	return calendar.Encode(), nil
+}
+
+func (s *CalendarService) events(courses []domain.Course, forStudent, forTeacher bool) ([]ical.Event, error) {
+	byID := make(map[uint]*domain.Course)
+	seenTerms := make(map[uint]bool)
+	var terms []*domain.Term
+	var ids []uint
+	for i := range courses {
+		course := &courses[i]
+		if byID[course.ID] != nil {
+			continue
+		}
+		byID[course.ID] = course
+		ids = append(ids, course.ID)
+		if course.Term != nil && !seenTerms[course.Term.ID] {
+			seenTerms[course.Term.ID] = true
+			terms = append(terms, course.Term)
+		}
+	}
+
+	meetings, err := s.meetingRepo.FindByCourseIDs(ids)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve meetings", err)
+	}
+	assignments, err := s.assignmentRepo.FindByCourseIDs(ids)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve assignments", err)
+	}
+
+	var events []ical.Event
+	for _, meeting := range meetings {
+		if event, ok := s.meetingEvent(byID[meeting.CourseID], &meeting); ok {
+			events = append(events, event)
+		}
+	}
+
+	for _, assignment := range assignments {
+		course := byID[assignment.CourseID]
+		events = append(events, ical.Event{
+			UID:         s.uid("assignment", assignment.ID),
+			Summary:     fmt.Sprintf("%s: %s due", course.Code, assignment.Title),
+			Description: assignment.Description,
+			Start:       assignment.DueAt,
+			End:         assignment.DueAt,
+		})
+	}
+
+	for _, term := range terms {
+		events = append(events,
+			termDay(s.uid("term-start", term.ID), term.Name+" begins", term.StartDate),
+			termDay(s.uid("term-end", term.ID), term.Name+" ends", term.EndDate),
+		)
+		if forStudent {
+			events = append(events, ical.Event{
+				UID:     s.uid("term-add-drop", term.ID),
+				Summary: term.Name + " add/drop deadline",
+				Start:   term.AddDropDeadline,
+				End:     term.AddDropDeadline,
+			})
+		}
+		if forTeacher {
+			events = append(events, ical.Event{
+				UID:     s.uid("term-grading", term.ID),
+				Summary: term.Name + " grading deadline",
+				Start:   term.GradingDeadline,
+				End:     term.GradingDeadline,
+			})
+		}
+	}
+
+	return events, nil
+}
+
+// meetingEvent repeats a meeting weekly from the first meeting day on or after
+// the course start until the course end. Times are floating, so calendars show
+// them in the reader's time zone as written in the timetable.
+func (s *CalendarService) meetingEvent(course *domain.Course, meeting *domain.CourseMeeting) (ical.Event, bool) {
+	days := schedule.Days(meeting.Days)
+	first := calendarDate(course.StartDate)
+	last := calendarDate(course.EndDate)
+	for i := 0; i < 7 && !days.Has(first.Weekday()); i++ {
+		first = first.AddDate(0, 0, 1)
+	}
+	if !days.Has(first.Weekday()) || first.After(last) {
+		return ical.Event{}, false
+	}
+
+	var weekdays []time.Weekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if days.Has(day) {
+			weekdays = append(weekdays, day)
+		}
+	}
+
+	description := ""
+	if course.Teacher.User.FirstName != "" {
+		description = "Teacher: " + course.Teacher.User.FirstName + " " + course.Teacher.User.LastName
+	}
+
+	return ical.Event{
+		UID:         s.uid("meeting", meeting.ID),
+		Summary:     course.Code + " " + course.Name,
+		Description: description,
+		Location:    meeting.RoomLabel(),
+		Start:       first.Add(time.Duration(meeting.StartMinute) * time.Minute),
+		End:         first.Add(time.Duration(meeting.EndMinute) * time.Minute),
+		Floating:    true,
+		Repeat: &ical.Weekly{
+			Days:  weekdays,
+			Until: last.Add(schedule.MinutesPerDay*time.Minute - time.Second),
+		},
+	}, true
+}
+
+func (s *CalendarService) uid(kind string, id uint) string {
+	return fmt.Sprintf("%s-%d@%s", kind, id, s.uidDomain)
+}
+
+func termDay(uid, summary string, date time.Time) ical.Event {
+	day := calendarDate(date)
+	return ical.Event{UID: uid, Summary: summary, Start: day, End: day.AddDate(0, 0, 1), AllDay: true}
+}
+
+// calendarDate returns midnight of the day of t as a zone-less date
+func calendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -5,29 +5,41 @@ import (
 
 	"github.com/Tretorhate/university-management-system/internal/domain"
 	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
 	"github.com/Tretorhate/university-management-system/internal/repository"
 	"github.com/Tretorhate/university-management-system/internal/service/factory"
 	"github.com/Tretorhate/university-management-system/internal/service/strategy"
+	appErrors "github.com/Tretorhate/university-management-system/pkg/errors"
 )
 
 type CourseService struct {
 	courseRepo           *repository.CourseRepository
 	teacherRepo          *repository.TeacherRepository
+	termRepo             *repository.TermRepository
+	departmentRepo       *repository.DepartmentRepository
 	enrollRepo           *repository.EnrollmentRepository
+	auditService         *AuditService
 	courseFactory        *factory.CourseFactory
 	courseDTOFactory     *factory.CourseResponseDTOFactory
 }
 
-func NewCourseService(courseRepo *repository.CourseRepository, teacherRepo *repository.TeacherRepository) *CourseService {
+func NewCourseService(courseRepo *repository.CourseRepository, teacherRepo *repository.TeacherRepository, termRepo *repository.TermRepository, departmentRepo *repository.DepartmentRepository, auditService *AuditService) *CourseService {
 	return &CourseService{
 		courseRepo:       courseRepo,
 		teacherRepo:      teacherRepo,
+		termRepo:         termRepo,
+		departmentRepo:   departmentRepo,
+		auditService:     auditService,
 		courseFactory:    factory.NewCourseFactory(),
 		courseDTOFactory: factory.NewCourseResponseDTOFactory(),
 	}
 }
 
-func (s *CourseService) Create(req *dto.CourseCreateDTO) (*dto.CourseResponseDTO, error) {
+func (s *CourseService) Create(subject policy.Subject, actor domain.Actor, req *dto.CourseCreateDTO) (*dto.CourseResponseDTO, error) {
+	if !policy.CanCreateCourse(subject, req.TeacherID) {
+		return nil, appErrors.Forbidden("You can only create courses you teach", nil)
+	}
+
 	// Check if course code already exists
 	existingCourse, _ := s.courseRepo.FindByCode(req.Code)
 	if existingCourse != nil {
@@ -43,18 +55,59 @@ func (s *CourseService) Create(req *dto.CourseCreateDTO) (*dto.CourseResponseDTO
 	// Create course using factory
 	course := s.courseFactory.CreateFromDTO(req)
 
-	if err := s.courseRepo.Create(course); err != nil {
-		return nil, err
+	// Verify term exists and covers the course dates
+	if req.TermID != nil {
+		term, err := s.termRepo.FindByID(*req.TermID)
+		if err != nil {
+			return nil, errors.New("term not found")
+		}
+		if !term.Contains(course.StartDate, course.EndDate) {
+			return nil, errors.New("course dates must fall within the term")
+		}
+		course.Term = term
 	}
 
-	// Set the Teacher field for the DTO conversion
-	course.Teacher = *teacher
-	
-	return s.courseDTOFactory.CreateFromEntity(course), nil
+	// Courses belong to the department whose subject code starts the course code
+	if req.DepartmentID != nil {
+		department, err := s.departmentRepo.FindByID(*req.DepartmentID)
+		if err != nil {
+			return nil, errors.New("department not found")
+		}
+		if !department.MatchesCourseCode(course.Code) {
+			return nil, appErrors.BadRequest("Course code must start with the subject code of the department", nil).
+				WithDetails(map[string]interface{}{"subjectCode": department.SubjectCode})
+		}
+	} else if department, err := s.departmentRepo.FindBySubjectCode(domain.CodePrefix(course.Code)); err == nil {
+		course.DepartmentID = &department.ID
+	}
+
+	var response *dto.CourseResponseDTO
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewCourseRepository(tx).Create(course); err != nil {
+			return err
+		}
+
+		// Set the Teacher field for the DTO conversion
+		course.Teacher = *teacher
+
+		response = s.courseDTOFactory.CreateFromEntity(course)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityCourse, course.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
-func (s *CourseService) GetAll(sortBy string) ([]dto.CourseResponseDTO, error) {
-	courses, err := s.courseRepo.FindAll()
+// GetAll returns all courses, or only the courses of the given term when termID is non-zero
+func (s *CourseService) GetAll(sortBy string, termID uint) ([]dto.CourseResponseDTO, error) {
+	var courses []domain.Course
+	var err error
+	if termID != 0 {
+		courses, err = s.courseRepo.FindByTermID(termID)
+	} else {
+		courses, err = s.courseRepo.FindAll()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -98,11 +151,20 @@ func (s *CourseService) GetByID(id uint) (*dto.CourseResponseDTO, error) {
 	return s.courseDTOFactory.CreateFromEntity(course), nil
 }
 
-func (s *CourseService) Update(id uint, req *dto.CourseUpdateDTO) (*dto.CourseResponseDTO, error) {
+func (s *CourseService) Update(subject policy.Subject, actor domain.Actor, id uint, req *dto.CourseUpdateDTO) (*dto.CourseResponseDTO, error) {
 	course, err := s.courseRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
+	before := s.courseDTOFactory.CreateFromEntity(course)
+	previous := *course
+
+	if !policy.CanManageCourse(subject, course) {
+		return nil, appErrors.Forbidden("You can only modify your own courses", nil)
+	}
+	if req.TeacherID != 0 && !policy.CanReassignCourse(subject, course, req.TeacherID) {
+		return nil, appErrors.Forbidden("You are not allowed to reassign a course to another teacher", nil)
+	}
 
 	// Update course info
 	if req.Name != "" {
@@ -114,6 +176,9 @@ func (s *CourseService) Update(id uint, req *dto.CourseUpdateDTO) (*dto.CourseRe
 	if req.Credits != 0 {
 		course.Credits = req.Credits
 	}
+	if req.Capacity != nil {
+		course.Capacity = *req.Capacity
+	}
 	if req.TeacherID != 0 && req.TeacherID != course.TeacherID {
 		// Verify new teacher exists
 		teacher, err := s.teacherRepo.FindByID(req.TeacherID)
@@ -129,14 +194,98 @@ func (s *CourseService) Update(id uint, req *dto.CourseUpdateDTO) (*dto.CourseRe
 	if !req.EndDate.IsZero() {
 		course.EndDate = req.EndDate
 	}
+	if req.TermID != nil && (course.TermID == nil || *req.TermID != *course.TermID) {
+		// Verify new term exists
+		term, err := s.termRepo.FindByID(*req.TermID)
+		if err != nil {
+			return nil, errors.New("term not found")
+		}
+		course.TermID = req.TermID
+		course.Term = term
+	}
+	if course.Term != nil && !course.Term.Contains(course.StartDate, course.EndDate) {
+		return nil, errors.New("course dates must fall within the term")
+	}
+	if req.DepartmentID != nil && (course.DepartmentID == nil || *req.DepartmentID != *course.DepartmentID) {
+		department, err := s.departmentRepo.FindByID(*req.DepartmentID)
+		if err != nil {
+			return nil, errors.New("department not found")
+		}
+		if !department.MatchesCourseCode(course.Code) {
+			return nil, appErrors.BadRequest("Course code must start with the subject code of the department", nil).
+				WithDetails(map[string]interface{}{"subjectCode": department.SubjectCode})
+		}
+		course.DepartmentID = req.DepartmentID
+	}
+
+	response := s.courseDTOFactory.CreateFromEntity(course)
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		if err := checkMeetingRooms(tx, course, &previous); err != nil {
+			return err
+		}
+		if err := repository.NewCourseRepository(tx).Update(course); err != nil {
+			return err
+		}
+		if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityCourse, course.ID, before, response); err != nil {
+			return err
+		}
 
-	if err := s.courseRepo.Update(course); err != nil {
+		// A larger seat limit may free seats for students on the waitlist
+		if req.Capacity == nil {
+			return nil
+		}
+		promotion, err := promoteFromWaitlist(tx, course.ID)
+		if err != nil {
+			return err
+		}
+		return recordPromotions(tx, s.auditService, actor, promotion)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return s.courseDTOFactory.CreateFromEntity(course), nil
+	return response, nil
 }
 
-func (s *CourseService) Delete(id uint) error {
-	return s.courseRepo.Delete(id)
+// checkMeetingRooms makes sure the rooms the course meets in still fit it: a
+// larger seat limit must not exceed their capacity, and new dates must not
+// clash with other meetings or bookings of the rooms.
+func checkMeetingRooms(tx *repository.Repository, course, previous *domain.Course) error {
+	grows := course.Capacity > previous.Capacity
+	moves := !course.StartDate.Equal(previous.StartDate) || !course.EndDate.Equal(previous.EndDate)
+	if !grows && !moves {
+		return nil
+	}
+
+	meetingRepo := repository.NewMeetingRepository(tx)
+	meetings, err := meetingRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return appErrors.InternalServerError("Failed to retrieve meetings", err)
+	}
+	if grows {
+		for _, meeting := range meetings {
+			if meeting.Location != nil && course.Capacity > meeting.Location.Capacity {
+				return appErrors.BadRequest("Room is too small for the course", nil).
+					WithDetails(map[string]interface{}{"roomId": meeting.Location.ID, "roomCapacity": meeting.Location.Capacity, "courseCapacity": course.Capacity})
+			}
+		}
+	}
+	if moves {
+		return checkRoomBookings(repository.NewRoomRepository(tx), meetingRepo, course, meetings)
+	}
+	return nil
+}
+
+func (s *CourseService) Delete(actor domain.Actor, id uint) error {
+	course, err := s.courseRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	return s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewCourseRepository(tx).Delete(id); err != nil {
+			return err
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityCourse, course.ID, s.courseDTOFactory.CreateFromEntity(course), nil)
+	})
 }
\ No newline at end of file
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+)
+
+// DepartmentService manages departments and lists their staff and courses
+type DepartmentService struct {
+	departmentRepo       *repository.DepartmentRepository
+	teacherRepo          *repository.TeacherRepository
+	courseRepo           *repository.CourseRepository
+	auditService         *AuditService
+	departmentDTOFactory *factory.DepartmentResponseDTOFactory
+	teacherDTOFactory    *factory.TeacherDTOFactory
+	courseDTOFactory     *factory.CourseResponseDTOFactory
+}
+
+func NewDepartmentService(departmentRepo *repository.DepartmentRepository, teacherRepo *repository.TeacherRepository, courseRepo *repository.CourseRepository, auditService *AuditService) *DepartmentService {
+	return &DepartmentService{
+		departmentRepo:       departmentRepo,
+		teacherRepo:          teacherRepo,
+		courseRepo:           courseRepo,
+		auditService:         auditService,
+		departmentDTOFactory: factory.NewDepartmentResponseDTOFactory(),
+		teacherDTOFactory:    factory.NewTeacherDTOFactory(),
+		courseDTOFactory:     factory.NewCourseResponseDTOFactory(),
+	}
+}
+
+func (s *DepartmentService) Create(actor domain.Actor, req *dto.DepartmentCreateDTO) (*dto.DepartmentResponseDTO, error) {
+	if existing, _ := s.departmentRepo.FindByCode(req.Code); existing != nil {
+		return nil, errors.Conflict("Department with this code already exists", nil)
+	}
+
+	department := &domain.Department{
+		Code: req.Code,
+		Name: req.Name,
+	}
+	if req.SubjectCode != nil && *req.SubjectCode != "" {
+		if existing, _ := s.departmentRepo.FindBySubjectCode(*req.SubjectCode); existing != nil {
+			return nil, errors.Conflict("Department with this subject code already exists", nil)
+		}
+		department.SubjectCode = req.SubjectCode
+	}
+
+	var response *dto.DepartmentResponseDTO
+	err := s.departmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewDepartmentRepository(tx).Create(department); err != nil {
+			return errors.InternalServerError("Failed to create department", err)
+		}
+		response = s.departmentDTOFactory.CreateFromEntity(department)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityDepartment, department.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *DepartmentService) GetAll() ([]dto.DepartmentResponseDTO, error) {
+	departments, err := s.departmentRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve departments", err)
+	}
+
+	var dtos []dto.DepartmentResponseDTO
+	for _, department := range departments {
+		dtos = append(dtos, *s.departmentDTOFactory.CreateFromEntity(&department))
+	}
+	return dtos, nil
+}
+
+func (s *DepartmentService) GetByID(id uint) (*dto.DepartmentResponseDTO, error) {
+	department, err := s.departmentRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Department not found", err)
+	}
+	return s.departmentDTOFactory.CreateFromEntity(department), nil
+}
+
+// Update changes a department. The subject code can only change while every
+// course of the department starts with the new one, and the head must be a
+// teacher of the department.
+func (s *DepartmentService) Update(actor domain.Actor, id uint, req *dto.DepartmentUpdateDTO) (*dto.DepartmentResponseDTO, error) {
+	department, err := s.departmentRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Department not found", err)
+	}
+	before := s.departmentDTOFactory.CreateFromEntity(department)
+
+	if req.Name != nil {
+		department.Name = *req.Name
+	}
+	if req.SubjectCode != nil {
+		if err := s.changeSubjectCode(department, *req.SubjectCode); err != nil {
+			return nil, err
+		}
+	}
+	if req.HeadTeacherID != nil {
+		if *req.HeadTeacherID == 0 {
+			department.HeadTeacherID = nil
+			department.HeadTeacher = nil
+		} else {
+			teacher, err := s.teacherRepo.FindByID(*req.HeadTeacherID)
+			if err != nil {
+				return nil, errors.NotFound("Teacher not found", err)
+			}
+			if teacher.DepartmentID != department.ID {
+				return nil, errors.BadRequest("The head must be a teacher of the department", nil)
+			}
+			department.HeadTeacherID = &teacher.ID
+			department.HeadTeacher = teacher
+		}
+	}
+
+	var response *dto.DepartmentResponseDTO
+	err = s.departmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewDepartmentRepository(tx).Update(department); err != nil {
+			return errors.InternalServerError("Failed to update department", err)
+		}
+		response = s.departmentDTOFactory.CreateFromEntity(department)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityDepartment, department.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete removes a department without teachers, courses or programs
+func (s *DepartmentService) Delete(actor domain.Actor, id uint) error {
+	department, err := s.departmentRepo.FindByID(id)
+	if err != nil {
+		return errors.NotFound("Department not found", err)
+	}
+
+	inUse, err := s.departmentRepo.IsInUse(department.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to check department usage", err)
+	}
+	if inUse {
+		return errors.Conflict("Department still has teachers, courses or programs", nil)
+	}
+
+	return s.departmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewDepartmentRepository(tx).Delete(department.ID); err != nil {
+			return errors.InternalServerError("Failed to delete department", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityDepartment, department.ID, s.departmentDTOFactory.CreateFromEntity(department), nil)
+	})
+}
+
+// GetTeachers lists the staff of a department
+func (s *DepartmentService) GetTeachers(id uint) ([]dto.TeacherResponseDTO, error) {
+	if _, err := s.departmentRepo.FindByID(id); err != nil {
+		return nil, errors.NotFound("Department not found", err)
+	}
+
+	teachers, err := s.teacherRepo.FindByDepartmentID(id)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve teachers", err)
+	}
+
+	var dtos []dto.TeacherResponseDTO
+	for _, teacher := range teachers {
+		dtos = append(dtos, *s.teacherDTOFactory.CreateFromEntity(&teacher))
+	}
+	return dtos, nil
+}
+
+func (s *DepartmentService) GetCourses(id uint) ([]dto.CourseResponseDTO, error) {
+	if _, err := s.departmentRepo.FindByID(id); err != nil {
+		return nil, errors.NotFound("Department not found", err)
+	}
+
+	courses, err := s.courseRepo.FindByDepartmentID(id)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve courses", err)
+	}
+
+	var dtos []dto.CourseResponseDTO
+	for _, course := range courses {
+		dtos = append(dtos, *s.courseDTOFactory.CreateFromEntity(&course))
+	}
+	return dtos, nil
+}
+
+// changeSubjectCode sets the subject code of a department, or removes it when
+// empty
+func (s *DepartmentService) changeSubjectCode(department *domain.Department, subjectCode string) error {
+	if subjectCode == "" {
+		department.SubjectCode = nil
+		return nil
+	}
+
+	if existing, _ := s.departmentRepo.FindBySubjectCode(subjectCode); existing != nil && existing.ID != department.ID {
+		return errors.Conflict("Department with this subject code already exists", nil)
+	}
+
+	courses, err := s.courseRepo.FindByDepartmentID(department.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to retrieve courses", err)
+	}
+	var mismatched []string
+	for _, course := range courses {
+		if domain.CodePrefix(course.Code) != subjectCode {
+			mismatched = append(mismatched, course.Code)
+		}
+	}
+	if len(mismatched) > 0 {
+		return errors.Conflict("Courses of the department have other subject codes", nil).
+			WithDetails(map[string]interface{}{"courses": mismatched})
+	}
+
+	department.SubjectCode = &subjectCode
+	return nil
+}
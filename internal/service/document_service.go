@@ -0,0 +1,247 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/pdf"
+)
+
+const (
+	institutionName = "University Management System"
+	documentMargin  = 50.0
+)
+
+// DocumentService renders official PDF documents and records them so that
+// third parties can verify their authenticity
+type DocumentService struct {
+	transcriptService *TranscriptService
+	studentRepo       *repository.StudentRepository
+	enrollmentRepo    *repository.EnrollmentRepository
+	documentRepo      *repository.IssuedDocumentRepository
+	baseURL           string
+}
+
+func NewDocumentService(transcriptService *TranscriptService, studentRepo *repository.StudentRepository, enrollmentRepo *repository.EnrollmentRepository, documentRepo *repository.IssuedDocumentRepository, baseURL string) *DocumentService {
+	return &DocumentService{
+		transcriptService: transcriptService,
+		studentRepo:       studentRepo,
+		enrollmentRepo:    enrollmentRepo,
+		documentRepo:      documentRepo,
+		baseURL:           strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// IssueTranscript renders the student's official transcript as a PDF
+func (s *DocumentService) IssueTranscript(studentID, issuerID uint) ([]byte, error) {
+	transcript, err := s.transcriptService.GetTranscript(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issue(domain.DocumentTypeTranscript, studentID, issuerID, func(code string, issuedAt time.Time) *pdf.Document {
+		doc := pdf.New("Official Transcript - " + transcript.StudentName)
+		flow := pdf.NewFlow(doc, documentMargin)
+
+		writeDocumentHeader(flow, "Official Academic Transcript", issuedAt)
+		flow.Row(pdf.FontRegular, 10, []pdf.Column{{X: 0, Text: "Student: " + transcript.StudentName}, {X: 280, Text: "Student ID: " + transcript.StudentNumber}})
+		flow.Line(pdf.FontRegular, 10, "Major: "+transcript.Major)
+		flow.Gap(10)
+
+		for _, term := range transcript.Terms {
+			flow.Line(pdf.FontBold, 11, term.TermName)
+			flow.Row(pdf.FontBold, 9, transcriptColumns("Code", "Course", "Credits", "Grade", "Letter", "Points"))
+			flow.Rule()
+			for _, course := range term.Courses {
+				grade, points := "-", "-"
+				if course.Grade != nil {
+					grade = fmt.Sprintf("%.1f", *course.Grade)
+				}
+				if course.GradePoints != nil {
+					points = fmt.Sprintf("%.2f", *course.GradePoints)
+				}
+				flow.Row(pdf.FontRegular, 9, transcriptColumns(course.CourseCode, truncate(course.CourseName, 42), fmt.Sprint(course.Credits), grade, course.LetterGrade, points))
+			}
+			flow.Row(pdf.FontRegular, 9, []pdf.Column{{X: 0, Text: fmt.Sprintf(
+				"Attempted: %d   Earned: %d   Term GPA: %.2f", term.AttemptedCredits, term.EarnedCredits, term.GPA,
+			)}})
+			flow.Gap(10)
+		}
+
+		flow.Rule()
+		flow.Line(pdf.FontBold, 10, fmt.Sprintf(
+			"Total attempted: %d   Total earned: %d   Cumulative GPA: %.2f",
+			transcript.AttemptedCredits, transcript.EarnedCredits, transcript.CumulativeGPA,
+		))
+
+		writeDocumentFooter(flow, code, s.verificationURL(code))
+		return doc
+	})
+}
+
+// IssueEnrollmentCertificate renders a certificate confirming the student's current enrollment
+func (s *DocumentService) IssueEnrollmentCertificate(studentID, issuerID uint) ([]byte, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, errors.NotFound("Student not found", err)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByStudentID(studentID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	var current []domain.Enrollment
+	for _, enrollment := range enrollments {
+		if enrollment.Status == domain.EnrollmentStatusEnrolled {
+			current = append(current, enrollment)
+		}
+	}
+	if len(current) == 0 {
+		return nil, errors.BadRequest("Student has no active enrollments", nil)
+	}
+
+	studentName := student.User.FirstName + " " + student.User.LastName
+
+	return s.issue(domain.DocumentTypeEnrollmentCertificate, studentID, issuerID, func(code string, issuedAt time.Time) *pdf.Document {
+		doc := pdf.New("Enrollment Certificate - " + studentName)
+		flow := pdf.NewFlow(doc, documentMargin)
+
+		writeDocumentHeader(flow, "Enrollment Certificate", issuedAt)
+		flow.Line(pdf.FontRegular, 11, fmt.Sprintf("This is to certify that %s (Student ID %s)", studentName, student.StudentID))
+		flow.Line(pdf.FontRegular, 11, fmt.Sprintf("is a student of %s, majoring in %s since %d,", institutionName, student.Major, student.EnrollYear))
+		flow.Line(pdf.FontRegular, 11, "and is currently enrolled in the following courses:")
+		flow.Gap(10)
+
+		flow.Row(pdf.FontBold, 9, []pdf.Column{{X: 0, Text: "Code"}, {X: 70, Text: "Course"}, {X: 300, Text: "Term"}, {X: 440, Text: "Credits"}})
+		flow.Rule()
+		totalCredits := 0
+		for _, enrollment := range current {
+			termName := "-"
+			if enrollment.Course.Term != nil {
+				termName = enrollment.Course.Term.Name
+			}
+			flow.Row(pdf.FontRegular, 9, []pdf.Column{
+				{X: 0, Text: enrollment.Course.Code},
+				{X: 70, Text: truncate(enrollment.Course.Name, 42)},
+				{X: 300, Text: truncate(termName, 25)},
+				{X: 440, Text: fmt.Sprint(enrollment.Course.Credits)},
+			})
+			totalCredits += enrollment.Course.Credits
+		}
+		flow.Rule()
+		flow.Line(pdf.FontBold, 10, fmt.Sprintf("Total credits: %d", totalCredits))
+
+		writeDocumentFooter(flow, code, s.verificationURL(code))
+		return doc
+	})
+}
+
+// Verify looks up an issued document by its verification code
+func (s *DocumentService) Verify(code string) (*dto.DocumentVerificationDTO, error) {
+	document, err := s.documentRepo.FindByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, errors.NotFound("No document was issued with this verification code", err)
+	}
+
+	return &dto.DocumentVerificationDTO{
+		Code:          document.Code,
+		Valid:         true,
+		Type:          string(document.Type),
+		StudentName:   document.Student.User.FirstName + " " + document.Student.User.LastName,
+		StudentNumber: document.Student.StudentID,
+		IssuedAt:      document.IssuedAt,
+		ContentHash:   document.ContentHash,
+	}, nil
+}
+
+// issue renders a document with a fresh verification code and records it
+func (s *DocumentService) issue(docType domain.DocumentType, studentID, issuerID uint, render func(code string, issuedAt time.Time) *pdf.Document) ([]byte, error) {
+	code, err := newVerificationCode()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to generate verification code", err)
+	}
+	issuedAt := time.Now().UTC()
+
+	content, err := render(code, issuedAt).Bytes()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to render document", err)
+	}
+
+	hash := sha256.Sum256(content)
+	document := &domain.IssuedDocument{
+		Code:        code,
+		Type:        docType,
+		StudentID:   studentID,
+		IssuedBy:    issuerID,
+		ContentHash: hex.EncodeToString(hash[:]),
+		IssuedAt:    issuedAt,
+	}
+	if err := s.documentRepo.Create(document); err != nil {
+		return nil, errors.InternalServerError("Failed to record issued document", err)
+	}
+
+	return content, nil
+}
+
+func (s *DocumentService) verificationURL(code string) string {
+	return s.baseURL + "/verify/" + code
+}
+
+func writeDocumentHeader(flow *pdf.Flow, title string, issuedAt time.Time) {
+	flow.Line(pdf.FontBold, 16, institutionName)
+	flow.Line(pdf.FontBold, 13, title)
+	flow.Line(pdf.FontRegular, 9, "Issued on "+issuedAt.Format("January 2, 2006 15:04 MST"))
+	flow.Rule()
+	flow.Gap(6)
+}
+
+func writeDocumentFooter(flow *pdf.Flow, code, url string) {
+	flow.Gap(24)
+	flow.Rule()
+	flow.Line(pdf.FontBold, 10, "Verification code: "+code)
+	flow.Line(pdf.FontRegular, 9, "The authenticity of this document can be confirmed at "+url)
+}
+
+func transcriptColumns(code, name, credits, grade, letter, points string) []pdf.Column {
+	return []pdf.Column{
+		{X: 0, Text: code},
+		{X: 70, Text: name},
+		{X: 300, Text: credits},
+		{X: 350, Text: grade},
+		{X: 400, Text: letter},
+		{X: 450, Text: points},
+	}
+}
+
+func truncate(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	return string(runes[:max-3]) + "..."
+}
+
+// newVerificationCode returns a random code formatted as XXXX-XXXX-XXXX-XXXX
+func newVerificationCode() (string, error) {
+	raw := make([]byte, 10)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw)
+	var groups []string
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, "-"), nil
+}
@@ -1,68 +1,190 @@
 package service
 
 import (
+	"time"
+
 	"github.com/Tretorhate/university-management-system/internal/domain"
 	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
 	"github.com/Tretorhate/university-management-system/internal/repository"
 	"github.com/Tretorhate/university-management-system/internal/service/factory"
 	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/grading"
 )
 
 type EnrollmentService struct {
 	enrollmentRepo           *repository.EnrollmentRepository
 	studentRepo              *repository.StudentRepository
 	courseRepo               *repository.CourseRepository
+	prerequisiteRepo         *repository.CoursePrerequisiteRepository
+	waitlistRepo             *repository.WaitlistRepository
+	assessmentRepo           *repository.AssessmentRepository
+	meetingRepo              *repository.MeetingRepository
+	auditService             *AuditService
+	scale                    *grading.Scale
 	enrollmentFactory        *factory.EnrollmentFactory
 	enrollmentDTOFactory     *factory.EnrollmentResponseDTOFactory
+	waitlistDTOFactory       *factory.WaitlistEntryResponseDTOFactory
 }
 
-func NewEnrollmentService(enrollmentRepo *repository.EnrollmentRepository, studentRepo *repository.StudentRepository, courseRepo *repository.CourseRepository) *EnrollmentService {
+func NewEnrollmentService(enrollmentRepo *repository.EnrollmentRepository, studentRepo *repository.StudentRepository, courseRepo *repository.CourseRepository, prerequisiteRepo *repository.CoursePrerequisiteRepository, waitlistRepo *repository.WaitlistRepository, assessmentRepo *repository.AssessmentRepository, meetingRepo *repository.MeetingRepository, auditService *AuditService, scale *grading.Scale) *EnrollmentService {
 	return &EnrollmentService{
 		enrollmentRepo:       enrollmentRepo,
 		studentRepo:          studentRepo,
 		courseRepo:           courseRepo,
+		prerequisiteRepo:     prerequisiteRepo,
+		waitlistRepo:         waitlistRepo,
+		assessmentRepo:       assessmentRepo,
+		meetingRepo:          meetingRepo,
+		auditService:         auditService,
+		scale:                scale,
 		enrollmentFactory:    factory.NewEnrollmentFactory(),
 		enrollmentDTOFactory: factory.NewEnrollmentResponseDTOFactory(),
+		waitlistDTOFactory:   factory.NewWaitlistEntryResponseDTOFactory(),
 	}
 }
 
-func (s *EnrollmentService) Create(req *dto.EnrollmentCreateDTO) (*dto.EnrollmentResponseDTO, error) {
+// Create enrolls the student in the course. When the course is full the student is
+// put on the course waitlist instead and the waitlist entry is returned.
+func (s *EnrollmentService) Create(subject policy.Subject, actor domain.Actor, req *dto.EnrollmentCreateDTO) (*dto.EnrollmentResponseDTO, *dto.WaitlistEntryResponseDTO, error) {
 	// Verify student exists
 	student, err := s.studentRepo.FindByID(req.StudentID)
 	if err != nil {
-		return nil, errors.NotFound("Student not found", err)
+		return nil, nil, errors.NotFound("Student not found", err)
 	}
 
 	// Verify course exists
 	course, err := s.courseRepo.FindByID(req.CourseID)
 	if err != nil {
-		return nil, errors.NotFound("Course not found", err)
+		return nil, nil, errors.NotFound("Course not found", err)
+	}
+
+	if !policy.CanManageEnrollment(subject, course) {
+		return nil, nil, errors.Forbidden("You can only enroll students in your own courses", nil)
 	}
 
-	// Check if enrollment already exists
+	// Check if enrollment already exists. Withdrawn and failed attempts may be retaken.
 	enrollments, _ := s.enrollmentRepo.FindByStudentID(req.StudentID)
 	for _, e := range enrollments {
-		if e.CourseID == req.CourseID {
-			return nil, errors.BadRequest("Student is already enrolled in this course", nil)
+		if e.CourseID != req.CourseID {
+			continue
+		}
+		if e.Status == domain.EnrollmentStatusEnrolled {
+			return nil, nil, errors.BadRequest("Student is already enrolled in this course", nil)
+		}
+		if passedCourse(s.scale, &e) {
+			return nil, nil, errors.BadRequest("Student has already passed this course", nil)
+		}
+	}
+
+	// Check if student is already waiting for a seat
+	if existing, _ := s.waitlistRepo.FindByStudentAndCourse(req.StudentID, req.CourseID); existing != nil {
+		return nil, nil, errors.Conflict("Student is already on the waitlist for this course", nil)
+	}
+
+	// Check that every prerequisite has been completed with a passing grade
+	unmet, err := findUnmetPrerequisites(s.prerequisiteRepo, req.CourseID, enrollments)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(unmet) > 0 {
+		return nil, nil, errors.BadRequest("Student has not met the prerequisites for this course", nil).
+			WithDetails(map[string]interface{}{"unmetPrerequisites": unmet})
+	}
+
+	// Check that the course fits into the student's timetable
+	if req.OverrideConflicts {
+		if !subject.Can(domain.PermissionEnrollmentOverride) {
+			return nil, nil, errors.Forbidden("You are not allowed to override timetable conflicts", nil)
+		}
+	} else {
+		var current []domain.Course
+		for _, e := range enrollments {
+			if e.Status == domain.EnrollmentStatusEnrolled {
+				current = append(current, e.Course)
+			}
+		}
+		conflicts, err := findScheduleConflicts(s.meetingRepo, course, current)
+		if err != nil {
+			return nil, nil, errors.InternalServerError("Failed to check the student's timetable", err)
+		}
+		if len(conflicts) > 0 {
+			return nil, nil, errors.Conflict("Course overlaps with the student's timetable", nil).
+				WithDetails(map[string]interface{}{"conflicts": conflicts})
 		}
 	}
 
 	// Create enrollment using factory
 	enrollment := s.enrollmentFactory.CreateFromDTO(req)
+	var response *dto.EnrollmentResponseDTO
+	var waitlisted *dto.WaitlistEntryResponseDTO
+
+	// Allocate the seat under a row lock so concurrent requests cannot overbook the course
+	err = s.enrollmentRepo.Transaction(func(tx *repository.Repository) error {
+		courseRepo := repository.NewCourseRepository(tx)
+		enrollmentRepo := repository.NewEnrollmentRepository(tx)
+		waitlistRepo := repository.NewWaitlistRepository(tx)
+
+		lockedCourse, err := courseRepo.FindByIDForUpdate(req.CourseID)
+		if err != nil {
+			return errors.NotFound("Course not found", err)
+		}
+
+		enrolled, err := enrollmentRepo.CountSeatedByCourseID(req.CourseID)
+		if err != nil {
+			return errors.InternalServerError("Failed to count enrollments", err)
+		}
+
+		if !lockedCourse.IsFull(enrolled) {
+			if err := enrollmentRepo.Create(enrollment); err != nil {
+				return errors.InternalServerError("Failed to create enrollment", err)
+			}
+			// Set the Student and Course fields for the DTO conversion
+			enrollment.Student = *student
+			enrollment.Course = *course
+			response = s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+			return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityEnrollment, enrollment.ID, nil, response)
+		}
 
-	if err := s.enrollmentRepo.Create(enrollment); err != nil {
-		return nil, errors.InternalServerError("Failed to create enrollment", err)
+		// Course is full, append the student to the end of the waitlist
+		position, err := waitlistRepo.MaxPosition(req.CourseID)
+		if err != nil {
+			return errors.InternalServerError("Failed to read waitlist", err)
+		}
+		entry := &domain.WaitlistEntry{
+			StudentID:         req.StudentID,
+			CourseID:          req.CourseID,
+			Position:          position + 1,
+			OverrideConflicts: req.OverrideConflicts,
+		}
+		if err := waitlistRepo.Create(entry); err != nil {
+			return errors.InternalServerError("Failed to add student to the waitlist", err)
+		}
+		entry.Student = *student
+		entry.Course = *course
+		waitlisted = s.waitlistDTOFactory.CreateFromEntity(entry)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityWaitlist, entry.ID, nil, waitlisted)
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Set the Student and Course fields for the DTO conversion
-	enrollment.Student = *student
-	enrollment.Course = *course
-	
-	return s.enrollmentDTOFactory.CreateFromEntity(enrollment), nil
+	if waitlisted != nil {
+		return nil, waitlisted, nil
+	}
+
+	return response, nil, nil
 }
 
-func (s *EnrollmentService) GetAll() ([]dto.EnrollmentResponseDTO, error) {
-	enrollments, err := s.enrollmentRepo.FindAll()
+// GetAll returns all enrollments, or only those in courses of the given term when termID is non-zero
+func (s *EnrollmentService) GetAll(termID uint) ([]dto.EnrollmentResponseDTO, error) {
+	var enrollments []domain.Enrollment
+	var err error
+	if termID != 0 {
+		enrollments, err = s.enrollmentRepo.FindByTermID(termID)
+	} else {
+		enrollments, err = s.enrollmentRepo.FindAll()
+	}
 	if err != nil {
 		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
 	}
@@ -75,51 +197,233 @@ func (s *EnrollmentService) GetAll() ([]dto.EnrollmentResponseDTO, error) {
 	return dtos, nil
 }
 
-func (s *EnrollmentService) GetByID(id uint) (*dto.EnrollmentResponseDTO, error) {
+func (s *EnrollmentService) GetByID(subject policy.Subject, id uint) (*dto.EnrollmentResponseDTO, error) {
 	enrollment, err := s.enrollmentRepo.FindByID(id)
 	if err != nil {
 		return nil, errors.NotFound("Enrollment not found", err)
 	}
 
+	if !policy.CanViewEnrollment(subject, enrollment) {
+		return nil, errors.Forbidden("You can only view your own enrollments", nil)
+	}
+
 	return s.enrollmentDTOFactory.CreateFromEntity(enrollment), nil
 }
 
-func (s *EnrollmentService) Update(id uint, req *dto.EnrollmentUpdateDTO) (*dto.EnrollmentResponseDTO, error) {
+func (s *EnrollmentService) Update(subject policy.Subject, actor domain.Actor, id uint, req *dto.EnrollmentUpdateDTO) (*dto.EnrollmentResponseDTO, error) {
 	enrollment, err := s.enrollmentRepo.FindByID(id)
 	if err != nil {
 		return nil, errors.NotFound("Enrollment not found", err)
 	}
+	before := s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+
+	if !policy.CanGradeEnrollment(subject, enrollment) {
+		return nil, errors.Forbidden("You can only grade enrollments in your own courses", nil)
+	}
+
+	if !enrollment.HoldsSeat() {
+		return nil, errors.BadRequest("Dropped or withdrawn enrollments cannot be updated", nil)
+	}
+
+	if (req.Grade != nil || req.Status != "") && enrollment.Course.GradesLocked(time.Now()) {
+		return nil, errors.Conflict("Grades of this course are locked, submit a grade change request instead", nil)
+	}
+
+	// Courses with assessment components compute grades from the scores
+	if req.Grade != nil {
+		components, err := s.assessmentRepo.CountComponents(enrollment.CourseID)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to check assessment components", err)
+		}
+		if components > 0 {
+			return nil, errors.BadRequest("Grades of this course are computed from assessment scores", nil)
+		}
+	}
 
 	// Update enrollment info
+	oldGrade := enrollment.Grade
 	if req.Grade != nil {
 		enrollment.Grade = req.Grade
 	}
 	if !req.EnrollDate.IsZero() {
 		enrollment.EnrollDate = req.EnrollDate
 	}
-
-	if err := s.enrollmentRepo.Update(enrollment); err != nil {
-		return nil, errors.InternalServerError("Failed to update enrollment", err)
+	if req.Status != "" {
+		status := domain.EnrollmentStatus(req.Status)
+		if status == domain.EnrollmentStatusCompleted && enrollment.Grade == nil {
+			return nil, errors.BadRequest("A grade is required to complete an enrollment", nil)
+		}
+		enrollment.Status = status
 	}
 
-	return s.enrollmentDTOFactory.CreateFromEntity(enrollment), nil
+	var response *dto.EnrollmentResponseDTO
+	err = s.enrollmentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewEnrollmentRepository(tx).Update(enrollment); err != nil {
+			return errors.InternalServerError("Failed to update enrollment", err)
+		}
+		if gradeChanged(oldGrade, enrollment.Grade) {
+			entry := &domain.GradeHistory{
+				EnrollmentID: enrollment.ID,
+				OldGrade:     oldGrade,
+				NewGrade:     enrollment.Grade,
+				Source:       domain.GradeSourceGrading,
+				ChangedBy:    actor.UserID,
+			}
+			if err := repository.NewGradeChangeRepository(tx).CreateHistory(entry); err != nil {
+				return errors.InternalServerError("Failed to record grade history", err)
+			}
+		}
+		response = s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityEnrollment, enrollment.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
-func (s *EnrollmentService) Delete(id uint) error {
+// Delete drops the student from the course. Before the term's add/drop deadline the
+// enrollment is removed from the student's record; afterwards it is kept as a
+// withdrawal ("W") on the transcript. Either way the seat goes to the waitlist.
+func (s *EnrollmentService) Delete(subject policy.Subject, actor domain.Actor, id uint) (*dto.EnrollmentResponseDTO, error) {
 	// Check if enrollment exists
-	_, err := s.enrollmentRepo.FindByID(id)
+	enrollment, err := s.enrollmentRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Enrollment not found", err)
+	}
+	before := s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+
+	if !policy.CanManageEnrollment(subject, &enrollment.Course) {
+		return nil, errors.Forbidden("You can only drop enrollments in your own courses", nil)
+	}
+
+	if enrollment.Status != domain.EnrollmentStatusEnrolled {
+		return nil, errors.BadRequest("Only active enrollments can be dropped", nil)
+	}
+
+	now := time.Now()
+	enrollment.DroppedAt = &now
+	if enrollment.Course.Term == nil || enrollment.Course.Term.IsAddDropOpen(now) {
+		enrollment.Status = domain.EnrollmentStatusDropped
+	} else {
+		enrollment.Status = domain.EnrollmentStatusWithdrawn
+	}
+
+	return s.releaseSeat(actor, enrollment, before)
+}
+
+// LateDrop removes an enrollment from the student's record after the add/drop
+// deadline has passed. It also converts an existing withdrawal into a drop.
+// The approving user and the reason are stored with the dropped enrollment.
+func (s *EnrollmentService) LateDrop(actor domain.Actor, id uint, req *dto.LateDropDTO) (*dto.EnrollmentResponseDTO, error) {
+	enrollment, err := s.enrollmentRepo.FindByID(id)
 	if err != nil {
-		return errors.NotFound("Enrollment not found", err)
+		return nil, errors.NotFound("Enrollment not found", err)
+	}
+	before := s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+
+	if enrollment.Status != domain.EnrollmentStatusEnrolled && enrollment.Status != domain.EnrollmentStatusWithdrawn {
+		return nil, errors.BadRequest("Only active or withdrawn enrollments can be late-dropped", nil)
 	}
 
-	if err := s.enrollmentRepo.Delete(id); err != nil {
-		return errors.InternalServerError("Failed to delete enrollment", err)
+	now := time.Now()
+	if enrollment.DroppedAt == nil {
+		enrollment.DroppedAt = &now
 	}
+	enrollment.Status = domain.EnrollmentStatusDropped
+	enrollment.LateDropApprovedBy = &actor.UserID
+	enrollment.LateDropReason = req.Reason
 
-	return nil
+	return s.releaseSeat(actor, enrollment, before)
 }
 
-func (s *EnrollmentService) GetByStudentID(studentID uint) ([]dto.EnrollmentResponseDTO, error) {
+// releaseSeat persists a dropped or withdrawn enrollment, removes drops from the
+// record and hands the freed seat to the head of the waitlist in one transaction,
+// together with the audit entries of the drop and the promotions.
+func (s *EnrollmentService) releaseSeat(actor domain.Actor, enrollment *domain.Enrollment, before *dto.EnrollmentResponseDTO) (*dto.EnrollmentResponseDTO, error) {
+	var response *dto.EnrollmentResponseDTO
+	err := s.enrollmentRepo.Transaction(func(tx *repository.Repository) error {
+		enrollmentRepo := repository.NewEnrollmentRepository(tx)
+
+		if err := enrollmentRepo.Update(enrollment); err != nil {
+			return errors.InternalServerError("Failed to update enrollment", err)
+		}
+
+		if enrollment.Status == domain.EnrollmentStatusDropped {
+			if err := enrollmentRepo.Delete(enrollment.ID); err != nil {
+				return errors.InternalServerError("Failed to delete enrollment", err)
+			}
+		}
+
+		promotion, err := promoteFromWaitlist(tx, enrollment.CourseID)
+		if err != nil {
+			return errors.InternalServerError("Failed to promote students from the waitlist", err)
+		}
+
+		response = s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+		// Drops disappear from the record, withdrawals stay on it
+		action := domain.AuditActionUpdate
+		if enrollment.Status == domain.EnrollmentStatusDropped {
+			action = domain.AuditActionDelete
+		}
+		if err := s.auditService.Record(tx, actor, action, domain.AuditEntityEnrollment, enrollment.ID, before, response); err != nil {
+			return err
+		}
+		return recordPromotions(tx, s.auditService, actor, promotion)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (s *EnrollmentService) GetWaitlist(subject policy.Subject, courseID uint) ([]dto.WaitlistEntryResponseDTO, error) {
+	// Verify course exists
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	if !policy.CanViewWaitlist(subject, course) {
+		return nil, errors.Forbidden("You can only view the waitlist of your own courses", nil)
+	}
+
+	entries, err := s.waitlistRepo.FindByCourseID(courseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve waitlist", err)
+	}
+
+	var dtos []dto.WaitlistEntryResponseDTO
+	for _, entry := range entries {
+		dtos = append(dtos, *s.waitlistDTOFactory.CreateFromEntity(&entry))
+	}
+
+	return dtos, nil
+}
+
+func (s *EnrollmentService) GetWaitlistPosition(courseID, studentID uint) (*dto.WaitlistEntryResponseDTO, error) {
+	entry, err := s.waitlistRepo.FindByStudentAndCourse(studentID, courseID)
+	if err != nil {
+		return nil, errors.NotFound("Student is not on the waitlist for this course", err)
+	}
+
+	return s.waitlistDTOFactory.CreateFromEntity(entry), nil
+}
+
+// GetOwnWaitlistPosition resolves the student profile of the given user and
+// returns their position on the course waitlist
+func (s *EnrollmentService) GetOwnWaitlistPosition(courseID, userID uint) (*dto.WaitlistEntryResponseDTO, error) {
+	student, err := s.studentRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.NotFound("Student profile not found", err)
+	}
+
+	return s.GetWaitlistPosition(courseID, student.ID)
+}
+
+// GetByStudentID returns the student's enrollments, optionally restricted to a term when termID is non-zero
+func (s *EnrollmentService) GetByStudentID(studentID, termID uint) ([]dto.EnrollmentResponseDTO, error) {
 	// Verify student exists
 	_, err := s.studentRepo.FindByID(studentID)
 	if err != nil {
@@ -133,6 +437,9 @@ func (s *EnrollmentService) GetByStudentID(studentID uint) ([]dto.EnrollmentResp
 
 	var dtos []dto.EnrollmentResponseDTO
 	for _, enrollment := range enrollments {
+		if termID != 0 && (enrollment.Course.TermID == nil || *enrollment.Course.TermID != termID) {
+			continue
+		}
 		dtos = append(dtos, *s.enrollmentDTOFactory.CreateFromEntity(&enrollment))
 	}
 
@@ -192,4 +499,48 @@ func (s *EnrollmentService) GetByStudentAndCourseID(studentID, courseID uint) ([
 	}
 
 	return dtos, nil
-}
\ No newline at end of file
+}
+
+// findUnmetPrerequisites compares the course prerequisites against the student's
+// enrollment history and returns every requirement that is not yet satisfied
+func findUnmetPrerequisites(prerequisiteRepo *repository.CoursePrerequisiteRepository, courseID uint, history []domain.Enrollment) ([]dto.UnmetPrerequisiteDTO, error) {
+	prerequisites, err := prerequisiteRepo.FindByCourseID(courseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve prerequisites", err)
+	}
+
+	unmet := []dto.UnmetPrerequisiteDTO{}
+	for _, prerequisite := range prerequisites {
+		required := prerequisite.RequiredGrade()
+
+		// Find the best grade the student achieved in the prerequisite course
+		var best *float64
+		for _, e := range history {
+			if e.CourseID != prerequisite.PrerequisiteID || e.Status != domain.EnrollmentStatusCompleted || e.Grade == nil {
+				continue
+			}
+			if best == nil || *e.Grade > *best {
+				best = e.Grade
+			}
+		}
+
+		if best != nil && *best >= required {
+			continue
+		}
+
+		reason := "Prerequisite course not completed"
+		if best != nil {
+			reason = "Grade below the required minimum"
+		}
+		unmet = append(unmet, dto.UnmetPrerequisiteDTO{
+			CourseID:      prerequisite.PrerequisiteID,
+			CourseCode:    prerequisite.Prerequisite.Code,
+			CourseName:    prerequisite.Prerequisite.Name,
+			RequiredGrade: required,
+			BestGrade:     best,
+			Reason:        reason,
+		})
+	}
+
+	return unmet, nil
+}
@@ -0,0 +1,530 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+	"github.com/Tretorhate/university-management-system/pkg/timetabling"
+)
+
+// Types of exam conflicts
+const (
+	ExamConflictClash       = timetabling.ReasonClash
+	ExamConflictDailyLimit  = timetabling.ReasonDailyLimit
+	ExamConflictNoSlot      = timetabling.ReasonNoSlot
+	ExamConflictUnscheduled = "UNSCHEDULED"
+)
+
+const (
+	defaultExamSlotLength = 180
+	maxExamPeriodDays     = 120
+)
+
+// ExamService manages the exams of courses and plans exam periods so that no
+// student sits two exams at once or too many on one day
+type ExamService struct {
+	examRepo       *repository.ExamRepository
+	courseRepo     *repository.CourseRepository
+	termRepo       *repository.TermRepository
+	enrollmentRepo *repository.EnrollmentRepository
+	studentRepo    *repository.StudentRepository
+	auditService   *AuditService
+	maxPerDay      int // Exams a student may sit on one day, 0 for no limit
+	examDTOFactory *factory.ExamResponseDTOFactory
+}
+
+func NewExamService(examRepo *repository.ExamRepository, courseRepo *repository.CourseRepository, termRepo *repository.TermRepository, enrollmentRepo *repository.EnrollmentRepository, studentRepo *repository.StudentRepository, auditService *AuditService, maxPerDay int) *ExamService {
+	return &ExamService{
+		examRepo:       examRepo,
+		courseRepo:     courseRepo,
+		termRepo:       termRepo,
+		enrollmentRepo: enrollmentRepo,
+		studentRepo:    studentRepo,
+		auditService:   auditService,
+		maxPerDay:      maxPerDay,
+		examDTOFactory: factory.NewExamResponseDTOFactory(),
+	}
+}
+
+func (s *ExamService) Create(actor domain.Actor, req *dto.ExamCreateDTO) (*dto.ExamResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(req.CourseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if req.Locked && req.StartsAt == nil {
+		return nil, errors.BadRequest("Only scheduled exams can be locked", nil)
+	}
+
+	exam := &domain.Exam{
+		CourseID: course.ID,
+		Course:   *course,
+		Title:    req.Title,
+		Duration: req.Duration,
+		StartsAt: req.StartsAt,
+		Locked:   req.Locked,
+	}
+	var response *dto.ExamResponseDTO
+	err = s.examRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewExamRepository(tx).Create(exam); err != nil {
+			return errors.InternalServerError("Failed to create exam", err)
+		}
+		response = s.examDTOFactory.CreateFromEntity(exam)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityExam, exam.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *ExamService) GetByID(id uint) (*dto.ExamResponseDTO, error) {
+	exam, err := s.examRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Exam not found", err)
+	}
+	return s.examDTOFactory.CreateFromEntity(exam), nil
+}
+
+func (s *ExamService) GetByCourse(courseID uint) ([]dto.ExamResponseDTO, error) {
+	if _, err := s.courseRepo.FindByID(courseID); err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	exams, err := s.examRepo.FindByCourseIDs([]uint{courseID})
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve exams", err)
+	}
+	return s.examDTOs(exams), nil
+}
+
+func (s *ExamService) GetByTerm(termID uint) ([]dto.ExamResponseDTO, error) {
+	if _, err := s.termRepo.FindByID(termID); err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	exams, err := s.examRepo.FindByTermID(termID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve exams", err)
+	}
+	return s.examDTOs(exams), nil
+}
+
+func (s *ExamService) Update(actor domain.Actor, id uint, req *dto.ExamUpdateDTO) (*dto.ExamResponseDTO, error) {
+	exam, err := s.examRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Exam not found", err)
+	}
+	before := s.examDTOFactory.CreateFromEntity(exam)
+
+	if req.Title != nil {
+		exam.Title = *req.Title
+	}
+	if req.Duration != nil {
+		exam.Duration = *req.Duration
+	}
+	if req.StartsAt != nil {
+		exam.StartsAt = req.StartsAt
+	}
+	if req.Locked != nil {
+		exam.Locked = *req.Locked
+	}
+	if exam.Locked && exam.StartsAt == nil {
+		return nil, errors.BadRequest("Only scheduled exams can be locked", nil)
+	}
+
+	var response *dto.ExamResponseDTO
+	err = s.examRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewExamRepository(tx).Update(exam); err != nil {
+			return errors.InternalServerError("Failed to update exam", err)
+		}
+		response = s.examDTOFactory.CreateFromEntity(exam)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityExam, exam.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *ExamService) Delete(actor domain.Actor, id uint) error {
+	exam, err := s.examRepo.FindByID(id)
+	if err != nil {
+		return errors.NotFound("Exam not found", err)
+	}
+
+	return s.examRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewExamRepository(tx).Delete(exam.ID); err != nil {
+			return errors.InternalServerError("Failed to delete exam", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityExam, exam.ID, s.examDTOFactory.CreateFromEntity(exam), nil)
+	})
+}
+
+// Plan assigns the unlocked exams of a term to slots of the exam period.
+// Locked exams keep their time and count towards clashes and daily limits.
+// Exams that cannot be placed without affecting students are left unscheduled
+// and reported with the students affected in the least bad slot, together with
+// the conflicts among the locked exams. With DryRun nothing is saved.
+func (s *ExamService) Plan(actor domain.Actor, termID uint, req *dto.ExamPlanRequestDTO) (*dto.ExamPlanResultDTO, error) {
+	if _, err := s.termRepo.FindByID(termID); err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	slots, err := examSlots(req)
+	if err != nil {
+		return nil, errors.BadRequest(err.Error(), err)
+	}
+	maxPerDay := s.maxPerDay
+	if req.MaxPerDay != nil {
+		maxPerDay = *req.MaxPerDay
+	}
+
+	exams, err := s.examRepo.FindByTermID(termID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve exams", err)
+	}
+	if len(exams) == 0 {
+		return nil, errors.BadRequest("Term has no exams to plan", nil)
+	}
+	seated, students, err := s.examStudents(exams)
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []timetabling.Exam
+	for _, exam := range exams {
+		entry := timetabling.Exam{
+			ID:       exam.ID,
+			Duration: time.Duration(exam.Duration) * time.Minute,
+			Students: seated[exam.ID],
+			Fixed:    -1,
+		}
+		if exam.Locked && exam.StartsAt != nil {
+			entry.Fixed = len(slots)
+			slots = append(slots, timetabling.ExamSlot{Start: *exam.StartsAt, End: *exam.EndsAt(), Day: dayNumber(*exam.StartsAt), Reserved: true})
+		}
+		planned = append(planned, entry)
+	}
+
+	plan := timetabling.PlanExams(planned, slots, maxPerDay)
+
+	result := &dto.ExamPlanResultDTO{Exams: []dto.ExamResponseDTO{}, Unresolved: []dto.ExamConflictDTO{}, Applied: !req.DryRun}
+	befores := make(map[uint]*dto.ExamResponseDTO)
+	var changed []*domain.Exam
+	for i := range exams {
+		exam := &exams[i]
+		if exam.Locked && exam.StartsAt != nil {
+			result.Exams = append(result.Exams, *s.examDTOFactory.CreateFromEntity(exam))
+			continue
+		}
+		befores[exam.ID] = s.examDTOFactory.CreateFromEntity(exam)
+		exam.StartsAt = nil
+		if slot, ok := plan.Slots[exam.ID]; ok {
+			start := slots[slot].Start
+			exam.StartsAt = &start
+		}
+		changed = append(changed, exam)
+		result.Exams = append(result.Exams, *s.examDTOFactory.CreateFromEntity(exam))
+	}
+	sortExamDTOs(result.Exams)
+
+	for _, unplaced := range plan.Unplaced {
+		conflict := dto.ExamConflictDTO{Type: unplaced.Reason, ExamIDs: []uint{unplaced.ExamID}, Students: []dto.ExamStudentDTO{}}
+		if unplaced.Slot >= 0 {
+			conflict.Date = slots[unplaced.Slot].Start.Format(time.DateOnly)
+		}
+		for _, studentID := range unplaced.Students {
+			conflict.Students = append(conflict.Students, students[studentID])
+		}
+		result.Unresolved = append(result.Unresolved, conflict)
+	}
+	// Locked exams keep their time even when they clash with each other or
+	// exceed the daily limit, so the resulting schedule is checked as a whole.
+	// Unplaced exams have no time and are not reported twice.
+	result.Unresolved = append(result.Unresolved, examConflicts(exams, seated, students, maxPerDay)...)
+
+	if req.DryRun {
+		return result, nil
+	}
+
+	err = s.examRepo.Transaction(func(tx *repository.Repository) error {
+		examRepo := repository.NewExamRepository(tx)
+		for _, exam := range changed {
+			if err := examRepo.UpdateStartsAt(exam.ID, exam.StartsAt); err != nil {
+				return errors.InternalServerError("Failed to save exam schedule", err)
+			}
+			if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityExam, exam.ID, befores[exam.ID], s.examDTOFactory.CreateFromEntity(exam)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetConflicts reports the clashes, days over the limit and unscheduled exams
+// of a term with the students affected
+func (s *ExamService) GetConflicts(termID uint) ([]dto.ExamConflictDTO, error) {
+	if _, err := s.termRepo.FindByID(termID); err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	exams, err := s.examRepo.FindByTermID(termID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve exams", err)
+	}
+	seated, students, err := s.examStudents(exams)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := examConflicts(exams, seated, students, s.maxPerDay)
+	for _, exam := range exams {
+		if exam.StartsAt == nil {
+			conflicts = append(conflicts, dto.ExamConflictDTO{Type: ExamConflictUnscheduled, ExamIDs: []uint{exam.ID}, Students: []dto.ExamStudentDTO{}})
+		}
+	}
+	return conflicts, nil
+}
+
+// GetStudentSchedule lists the exams of the courses a student holds a seat in,
+// with the student's conflicts
+func (s *ExamService) GetStudentSchedule(studentID uint) (*dto.StudentExamScheduleDTO, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, errors.NotFound("Student not found", err)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByStudentID(student.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+	var courseIDs []uint
+	for _, enrollment := range enrollments {
+		if enrollment.HoldsSeat() {
+			courseIDs = append(courseIDs, enrollment.CourseID)
+		}
+	}
+
+	exams, err := s.examRepo.FindByCourseIDs(courseIDs)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve exams", err)
+	}
+
+	seated := make(map[uint][]uint)
+	for _, exam := range exams {
+		seated[exam.ID] = []uint{student.ID}
+	}
+	students := map[uint]dto.ExamStudentDTO{student.ID: examStudent(student)}
+
+	result := &dto.StudentExamScheduleDTO{
+		Exams:     s.examDTOs(exams),
+		Conflicts: examConflicts(exams, seated, students, s.maxPerDay),
+	}
+	if result.Exams == nil {
+		result.Exams = []dto.ExamResponseDTO{}
+	}
+	return result, nil
+}
+
+// examStudents loads the students holding a seat in the course of each exam
+func (s *ExamService) examStudents(exams []domain.Exam) (map[uint][]uint, map[uint]dto.ExamStudentDTO, error) {
+	seated := make(map[uint][]uint)
+	students := make(map[uint]dto.ExamStudentDTO)
+	byCourse := make(map[uint][]uint)
+	for _, exam := range exams {
+		ids, ok := byCourse[exam.CourseID]
+		if !ok {
+			enrollments, err := s.enrollmentRepo.FindByCourseID(exam.CourseID)
+			if err != nil {
+				return nil, nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+			}
+			for _, enrollment := range enrollments {
+				if !enrollment.HoldsSeat() {
+					continue
+				}
+				ids = append(ids, enrollment.StudentID)
+				students[enrollment.StudentID] = examStudent(&enrollment.Student)
+			}
+			byCourse[exam.CourseID] = ids
+		}
+		seated[exam.ID] = ids
+	}
+	return seated, students, nil
+}
+
+func (s *ExamService) examDTOs(exams []domain.Exam) []dto.ExamResponseDTO {
+	var dtos []dto.ExamResponseDTO
+	for _, exam := range exams {
+		dtos = append(dtos, *s.examDTOFactory.CreateFromEntity(&exam))
+	}
+	return dtos
+}
+
+// examConflicts finds students with overlapping exams and students with more
+// exams on a day than allowed, grouping the students by the exams involved
+func examConflicts(exams []domain.Exam, seated map[uint][]uint, students map[uint]dto.ExamStudentDTO, maxPerDay int) []dto.ExamConflictDTO {
+	byStudent := make(map[uint][]*domain.Exam)
+	for i := range exams {
+		exam := &exams[i]
+		if exam.StartsAt == nil {
+			continue
+		}
+		for _, studentID := range seated[exam.ID] {
+			byStudent[studentID] = append(byStudent[studentID], exam)
+		}
+	}
+
+	grouped := make(map[string]*dto.ExamConflictDTO)
+	var keys []string
+	add := func(kind string, date string, involved []*domain.Exam, studentID uint) {
+		var ids []uint
+		for _, exam := range involved {
+			ids = append(ids, exam.ID)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		key := fmt.Sprint(kind, date, ids)
+		conflict := grouped[key]
+		if conflict == nil {
+			conflict = &dto.ExamConflictDTO{Type: kind, ExamIDs: ids, Date: date}
+			grouped[key] = conflict
+			keys = append(keys, key)
+		}
+		conflict.Students = append(conflict.Students, students[studentID])
+	}
+
+	studentIDs := make([]uint, 0, len(byStudent))
+	for studentID := range byStudent {
+		studentIDs = append(studentIDs, studentID)
+	}
+	sort.Slice(studentIDs, func(i, j int) bool { return studentIDs[i] < studentIDs[j] })
+
+	for _, studentID := range studentIDs {
+		own := byStudent[studentID]
+		sort.Slice(own, func(i, j int) bool { return own[i].StartsAt.Before(*own[j].StartsAt) })
+
+		byDay := make(map[string][]*domain.Exam)
+		var days []string
+		for i, exam := range own {
+			date := exam.StartsAt.In(time.Local).Format(time.DateOnly)
+			if byDay[date] == nil {
+				days = append(days, date)
+			}
+			byDay[date] = append(byDay[date], exam)
+			for _, other := range own[i+1:] {
+				if !other.StartsAt.Before(*exam.EndsAt()) {
+					break
+				}
+				add(ExamConflictClash, date, []*domain.Exam{exam, other}, studentID)
+			}
+		}
+		if maxPerDay <= 0 {
+			continue
+		}
+		for _, date := range days {
+			if len(byDay[date]) > maxPerDay {
+				add(ExamConflictDailyLimit, date, byDay[date], studentID)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	conflicts := []dto.ExamConflictDTO{}
+	for _, key := range keys {
+		conflicts = append(conflicts, *grouped[key])
+	}
+	return conflicts
+}
+
+// examSlots lists the slots of an exam period in chronological order
+func examSlots(req *dto.ExamPlanRequestDTO) ([]timetabling.ExamSlot, error) {
+	names := req.Days
+	if len(names) == 0 {
+		names = defaultScheduleDays
+	}
+	days, err := schedule.ParseDays(names)
+	if err != nil {
+		return nil, err
+	}
+	length := req.SlotLength
+	if length == 0 {
+		length = defaultExamSlotLength
+	}
+
+	var starts []int
+	for _, value := range req.SlotTimes {
+		start, err := schedule.ParseClock(value)
+		if err != nil {
+			return nil, err
+		}
+		starts = append(starts, start)
+	}
+	sort.Ints(starts)
+
+	first := localDate(req.StartDate)
+	last := localDate(req.EndDate)
+	if last.Before(first) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+	if last.Sub(first) > maxExamPeriodDays*24*time.Hour {
+		return nil, fmt.Errorf("exam period cannot be longer than %d days", maxExamPeriodDays)
+	}
+
+	var slots []timetabling.ExamSlot
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !days.Has(day.Weekday()) {
+			continue
+		}
+		for _, start := range starts {
+			begin := day.Add(time.Duration(start) * time.Minute)
+			slots = append(slots, timetabling.ExamSlot{
+				Start: begin,
+				End:   begin.Add(time.Duration(length) * time.Minute),
+				Day:   dayNumber(day),
+			})
+		}
+	}
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("exam period has no slots")
+	}
+	return slots, nil
+}
+
+// localDate returns local midnight of the calendar day of t
+func localDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// dayNumber identifies the local calendar day of t
+func dayNumber(t time.Time) int {
+	local := t.In(time.Local)
+	return int(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+func examStudent(student *domain.Student) dto.ExamStudentDTO {
+	return dto.ExamStudentDTO{
+		StudentID:     student.ID,
+		StudentNumber: student.StudentID,
+		Name:          student.User.FirstName + " " + student.User.LastName,
+	}
+}
+
+func sortExamDTOs(exams []dto.ExamResponseDTO) {
+	sort.SliceStable(exams, func(i, j int) bool {
+		a, b := exams[i].StartsAt, exams[j].StartsAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+}
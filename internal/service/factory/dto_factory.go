@@ -1,8 +1,11 @@
 package factory
 
 import (
+	"time"
+
 	"github.com/Tretorhate/university-management-system/internal/domain"
 	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
 )
 
 // UserDTOFactory is a factory for creating UserDTO objects
@@ -14,11 +17,12 @@ func NewUserDTOFactory() *UserDTOFactory {
 
 func (f *UserDTOFactory) CreateFromEntity(user *domain.User) *dto.UserDTO {
 	return &dto.UserDTO{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Role:      string(user.Role),
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Role:          string(user.Role),
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}
 }
 
@@ -39,6 +43,7 @@ func (f *StudentDTOFactory) CreateFromEntity(student *domain.Student) *dto.Stude
 		StudentID:  student.StudentID,
 		EnrollYear: student.EnrollYear,
 		Major:      student.Major,
+		ProgramID:  student.ProgramID,
 	}
 }
 
@@ -51,15 +56,16 @@ func NewTeacherDTOFactory() *TeacherDTOFactory {
 
 func (f *TeacherDTOFactory) CreateFromEntity(teacher *domain.Teacher) *dto.TeacherResponseDTO {
 	return &dto.TeacherResponseDTO{
-		ID:          teacher.ID,
-		UserID:      teacher.UserID,
-		Email:       teacher.User.Email,
-		FirstName:   teacher.User.FirstName,
-		LastName:    teacher.User.LastName,
-		EmployeeID:  teacher.EmployeeID,
-		Department:  teacher.Department,
-		Speciality:  teacher.Speciality,
-		JoiningDate: teacher.JoiningDate,
+		ID:           teacher.ID,
+		UserID:       teacher.UserID,
+		Email:        teacher.User.Email,
+		FirstName:    teacher.User.FirstName,
+		LastName:     teacher.User.LastName,
+		EmployeeID:   teacher.EmployeeID,
+		DepartmentID: teacher.DepartmentID,
+		Department:   teacher.Department.Name,
+		Speciality:   teacher.Speciality,
+		JoiningDate:  teacher.JoiningDate,
 	}
 }
 
@@ -75,17 +81,27 @@ func (f *CourseResponseDTOFactory) CreateFromEntity(course *domain.Course) *dto.
 	if course.Teacher.User.FirstName != "" {
 		teacherName = course.Teacher.User.FirstName + " " + course.Teacher.User.LastName
 	}
+
+	termName := ""
+	if course.Term != nil {
+		termName = course.Term.Name
+	}
 	
 	return &dto.CourseResponseDTO{
-		ID:          course.ID,
-		Code:        course.Code,
-		Name:        course.Name,
-		Description: course.Description,
-		Credits:     course.Credits,
-		TeacherID:   course.TeacherID,
-		TeacherName: teacherName,
-		StartDate:   course.StartDate,
-		EndDate:     course.EndDate,
+		ID:                course.ID,
+		Code:              course.Code,
+		Name:              course.Name,
+		Description:       course.Description,
+		Credits:           course.Credits,
+		Capacity:          course.Capacity,
+		TeacherID:         course.TeacherID,
+		TeacherName:       teacherName,
+		StartDate:         course.StartDate,
+		EndDate:           course.EndDate,
+		TermID:            course.TermID,
+		TermName:          termName,
+		DepartmentID:      course.DepartmentID,
+		GradesFinalizedAt: course.GradesFinalizedAt,
 	}
 }
 
@@ -98,6 +114,11 @@ func NewEnrollmentResponseDTOFactory() *EnrollmentResponseDTOFactory {
 
 func (f *EnrollmentResponseDTOFactory) CreateFromEntity(enrollment *domain.Enrollment) *dto.EnrollmentResponseDTO {
 	studentName := enrollment.Student.User.FirstName + " " + enrollment.Student.User.LastName
+
+	termName := ""
+	if enrollment.Course.Term != nil {
+		termName = enrollment.Course.Term.Name
+	}
 	
 	return &dto.EnrollmentResponseDTO{
 		ID:          enrollment.ID,
@@ -106,8 +127,12 @@ func (f *EnrollmentResponseDTOFactory) CreateFromEntity(enrollment *domain.Enrol
 		CourseID:    enrollment.CourseID,
 		CourseName:  enrollment.Course.Name,
 		CourseCode:  enrollment.Course.Code,
+		TermID:      enrollment.Course.TermID,
+		TermName:    termName,
+		Status:      string(enrollment.Status),
 		Grade:       enrollment.Grade,
 		EnrollDate:  enrollment.EnrollDate,
+		DroppedAt:   enrollment.DroppedAt,
 	}
 }
 
@@ -120,13 +145,16 @@ func NewCourseFactory() *CourseFactory {
 
 func (f *CourseFactory) CreateFromDTO(dto *dto.CourseCreateDTO) *domain.Course {
 	return &domain.Course{
-		Code:        dto.Code,
-		Name:        dto.Name,
-		Description: dto.Description,
-		Credits:     dto.Credits,
-		TeacherID:   dto.TeacherID,
-		StartDate:   dto.StartDate,
-		EndDate:     dto.EndDate,
+		Code:         dto.Code,
+		Name:         dto.Name,
+		Description:  dto.Description,
+		Credits:      dto.Credits,
+		Capacity:     dto.Capacity,
+		TeacherID:    dto.TeacherID,
+		StartDate:    dto.StartDate,
+		EndDate:      dto.EndDate,
+		TermID:       dto.TermID,
+		DepartmentID: dto.DepartmentID,
 	}
 }
 
@@ -157,7 +185,6 @@ func (f *TeacherFactory) CreateFromDTO(dto *dto.TeacherCreateDTO, userID uint) *
 	return &domain.Teacher{
 		UserID:      userID,
 		EmployeeID:  dto.EmployeeID,
-		Department:  dto.Department,
 		Speciality:  dto.Speciality,
 		JoiningDate: dto.JoiningDate,
 	}
@@ -174,6 +201,516 @@ func (f *EnrollmentFactory) CreateFromDTO(dto *dto.EnrollmentCreateDTO) *domain.
 	return &domain.Enrollment{
 		StudentID:  dto.StudentID,
 		CourseID:   dto.CourseID,
+		Status:     domain.EnrollmentStatusEnrolled,
 		EnrollDate: dto.EnrollDate,
 	}
-}
\ No newline at end of file
+}
+
+// PrerequisiteResponseDTOFactory is a factory for creating PrerequisiteResponseDTO objects
+type PrerequisiteResponseDTOFactory struct{}
+
+func NewPrerequisiteResponseDTOFactory() *PrerequisiteResponseDTOFactory {
+	return &PrerequisiteResponseDTOFactory{}
+}
+
+func (f *PrerequisiteResponseDTOFactory) CreateFromEntity(prerequisite *domain.CoursePrerequisite) *dto.PrerequisiteResponseDTO {
+	return &dto.PrerequisiteResponseDTO{
+		CourseID:         prerequisite.CourseID,
+		PrerequisiteID:   prerequisite.PrerequisiteID,
+		PrerequisiteCode: prerequisite.Prerequisite.Code,
+		PrerequisiteName: prerequisite.Prerequisite.Name,
+		MinGrade:         prerequisite.MinGrade,
+	}
+}
+
+// WaitlistEntryResponseDTOFactory is a factory for creating WaitlistEntryResponseDTO objects
+type WaitlistEntryResponseDTOFactory struct{}
+
+func NewWaitlistEntryResponseDTOFactory() *WaitlistEntryResponseDTOFactory {
+	return &WaitlistEntryResponseDTOFactory{}
+}
+
+func (f *WaitlistEntryResponseDTOFactory) CreateFromEntity(entry *domain.WaitlistEntry) *dto.WaitlistEntryResponseDTO {
+	studentName := entry.Student.User.FirstName + " " + entry.Student.User.LastName
+
+	return &dto.WaitlistEntryResponseDTO{
+		ID:          entry.ID,
+		StudentID:   entry.StudentID,
+		StudentName: studentName,
+		CourseID:    entry.CourseID,
+		CourseName:  entry.Course.Name,
+		CourseCode:  entry.Course.Code,
+		Position:    entry.Position,
+		CreatedAt:   entry.CreatedAt,
+	}
+}
+
+// TermResponseDTOFactory is a factory for creating TermResponseDTO objects
+type TermResponseDTOFactory struct{}
+
+func NewTermResponseDTOFactory() *TermResponseDTOFactory {
+	return &TermResponseDTOFactory{}
+}
+
+func (f *TermResponseDTOFactory) CreateFromEntity(term *domain.Term) *dto.TermResponseDTO {
+	return &dto.TermResponseDTO{
+		ID:              term.ID,
+		Name:            term.Name,
+		StartDate:       term.StartDate,
+		EndDate:         term.EndDate,
+		AddDropDeadline: term.AddDropDeadline,
+		GradingDeadline: term.GradingDeadline,
+	}
+}
+
+// TermFactory is a factory for creating Term entities from DTOs
+type TermFactory struct{}
+
+func NewTermFactory() *TermFactory {
+	return &TermFactory{}
+}
+
+func (f *TermFactory) CreateFromDTO(dto *dto.TermCreateDTO) *domain.Term {
+	return &domain.Term{
+		Name:            dto.Name,
+		StartDate:       dto.StartDate,
+		EndDate:         dto.EndDate,
+		AddDropDeadline: dto.AddDropDeadline,
+		GradingDeadline: dto.GradingDeadline,
+	}
+}
+
+// InvitationResponseDTOFactory is a factory for creating InvitationResponseDTO objects
+type InvitationResponseDTOFactory struct{}
+
+func NewInvitationResponseDTOFactory() *InvitationResponseDTOFactory {
+	return &InvitationResponseDTOFactory{}
+}
+
+func (f *InvitationResponseDTOFactory) CreateFromEntity(invitation *domain.Invitation) *dto.InvitationResponseDTO {
+	return &dto.InvitationResponseDTO{
+		ID:         invitation.ID,
+		Email:      invitation.Email,
+		Role:       string(invitation.Role),
+		Status:     string(invitation.Status(time.Now())),
+		ExpiresAt:  invitation.ExpiresAt,
+		AcceptedAt: invitation.AcceptedAt,
+		UserID:     invitation.UserID,
+		RevokedAt:  invitation.RevokedAt,
+		CreatedBy:  invitation.CreatedBy,
+		CreatedAt:  invitation.CreatedAt,
+	}
+}
+
+// SecurityEventResponseDTOFactory is a factory for creating SecurityEventResponseDTO objects
+type SecurityEventResponseDTOFactory struct{}
+
+func NewSecurityEventResponseDTOFactory() *SecurityEventResponseDTOFactory {
+	return &SecurityEventResponseDTOFactory{}
+}
+
+func (f *SecurityEventResponseDTOFactory) CreateFromEntity(event *domain.SecurityEvent) *dto.SecurityEventResponseDTO {
+	return &dto.SecurityEventResponseDTO{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		UserID:    event.UserID,
+		Email:     event.Email,
+		IP:        event.IP,
+		ActorID:   event.ActorID,
+		Details:   event.Details,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// RoleResponseDTOFactory is a factory for creating RoleResponseDTO objects
+type RoleResponseDTOFactory struct{}
+
+func NewRoleResponseDTOFactory() *RoleResponseDTOFactory {
+	return &RoleResponseDTOFactory{}
+}
+
+// CreateFromEntity lists the given permissions, which for ADMIN are not stored
+// with the role
+func (f *RoleResponseDTOFactory) CreateFromEntity(role *domain.RoleDefinition, permissions []domain.Permission) *dto.RoleResponseDTO {
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, string(permission))
+	}
+	return &dto.RoleResponseDTO{
+		Name:        string(role.Name),
+		Description: role.Description,
+		BuiltIn:     role.BuiltIn,
+		Permissions: names,
+		CreatedAt:   role.CreatedAt,
+		UpdatedAt:   role.UpdatedAt,
+	}
+}
+
+// AuditLogResponseDTOFactory is a factory for creating AuditLogResponseDTO objects
+type AuditLogResponseDTOFactory struct{}
+
+func NewAuditLogResponseDTOFactory() *AuditLogResponseDTOFactory {
+	return &AuditLogResponseDTOFactory{}
+}
+
+func (f *AuditLogResponseDTOFactory) CreateFromEntity(entry *domain.AuditLog) *dto.AuditLogResponseDTO {
+	return &dto.AuditLogResponseDTO{
+		ID:         entry.ID,
+		ActorID:    entry.ActorID,
+		ActorRole:  string(entry.ActorRole),
+		Action:     string(entry.Action),
+		EntityType: string(entry.EntityType),
+		EntityID:   entry.EntityID,
+		Before:     entry.Before,
+		After:      entry.After,
+		Diff:       entry.Diff,
+		RequestID:  entry.RequestID,
+		IP:         entry.IP,
+		CreatedAt:  entry.CreatedAt,
+	}
+}
+
+// GradeChangeRequestResponseDTOFactory is a factory for creating GradeChangeRequestResponseDTO objects
+type GradeChangeRequestResponseDTOFactory struct{}
+
+func NewGradeChangeRequestResponseDTOFactory() *GradeChangeRequestResponseDTOFactory {
+	return &GradeChangeRequestResponseDTOFactory{}
+}
+
+func (f *GradeChangeRequestResponseDTOFactory) CreateFromEntity(request *domain.GradeChangeRequest) *dto.GradeChangeRequestResponseDTO {
+	enrollment := request.Enrollment
+	return &dto.GradeChangeRequestResponseDTO{
+		ID:            request.ID,
+		EnrollmentID:  request.EnrollmentID,
+		StudentID:     enrollment.StudentID,
+		StudentName:   enrollment.Student.User.FirstName + " " + enrollment.Student.User.LastName,
+		CourseID:      enrollment.CourseID,
+		CourseCode:    enrollment.Course.Code,
+		OldGrade:      request.OldGrade,
+		NewGrade:      request.NewGrade,
+		Reason:        request.Reason,
+		Status:        string(request.Status),
+		RequestedBy:   request.RequestedBy,
+		ReviewedBy:    request.ReviewedBy,
+		ReviewedAt:    request.ReviewedAt,
+		ReviewComment: request.ReviewComment,
+		CreatedAt:     request.CreatedAt,
+	}
+}
+
+// GradeHistoryResponseDTOFactory is a factory for creating GradeHistoryResponseDTO objects
+type GradeHistoryResponseDTOFactory struct{}
+
+func NewGradeHistoryResponseDTOFactory() *GradeHistoryResponseDTOFactory {
+	return &GradeHistoryResponseDTOFactory{}
+}
+
+func (f *GradeHistoryResponseDTOFactory) CreateFromEntity(entry *domain.GradeHistory) *dto.GradeHistoryResponseDTO {
+	return &dto.GradeHistoryResponseDTO{
+		ID:              entry.ID,
+		EnrollmentID:    entry.EnrollmentID,
+		OldGrade:        entry.OldGrade,
+		NewGrade:        entry.NewGrade,
+		Source:          string(entry.Source),
+		ChangeRequestID: entry.ChangeRequestID,
+		ChangedBy:       entry.ChangedBy,
+		CreatedAt:       entry.CreatedAt,
+	}
+}
+
+// AssessmentComponentResponseDTOFactory is a factory for creating AssessmentComponentResponseDTO objects
+type AssessmentComponentResponseDTOFactory struct{}
+
+func NewAssessmentComponentResponseDTOFactory() *AssessmentComponentResponseDTOFactory {
+	return &AssessmentComponentResponseDTOFactory{}
+}
+
+func (f *AssessmentComponentResponseDTOFactory) CreateFromEntity(component *domain.AssessmentComponent) *dto.AssessmentComponentResponseDTO {
+	return &dto.AssessmentComponentResponseDTO{
+		ID:         component.ID,
+		CourseID:   component.CourseID,
+		Name:       component.Name,
+		Weight:     component.Weight,
+		MaxScore:   component.MaxScore,
+		ItemCount:  component.ItemCount,
+		DropLowest: component.DropLowest,
+	}
+}
+
+// AssessmentScoreResponseDTOFactory is a factory for creating AssessmentScoreResponseDTO objects
+type AssessmentScoreResponseDTOFactory struct{}
+
+func NewAssessmentScoreResponseDTOFactory() *AssessmentScoreResponseDTOFactory {
+	return &AssessmentScoreResponseDTOFactory{}
+}
+
+func (f *AssessmentScoreResponseDTOFactory) CreateFromEntity(score *domain.AssessmentScore) *dto.AssessmentScoreResponseDTO {
+	return &dto.AssessmentScoreResponseDTO{
+		ID:           score.ID,
+		ComponentID:  score.ComponentID,
+		EnrollmentID: score.EnrollmentID,
+		Item:         score.Item,
+		Score:        score.Score,
+		GradedBy:     score.GradedBy,
+	}
+}
+
+// AssignmentResponseDTOFactory is a factory for creating AssignmentResponseDTO objects
+type AssignmentResponseDTOFactory struct{}
+
+func NewAssignmentResponseDTOFactory() *AssignmentResponseDTOFactory {
+	return &AssignmentResponseDTOFactory{}
+}
+
+func (f *AssignmentResponseDTOFactory) CreateFromEntity(assignment *domain.Assignment) *dto.AssignmentResponseDTO {
+	return &dto.AssignmentResponseDTO{
+		ID:                 assignment.ID,
+		CourseID:           assignment.CourseID,
+		Title:              assignment.Title,
+		Description:        assignment.Description,
+		DueAt:              assignment.DueAt,
+		MaxPoints:          assignment.MaxPoints,
+		LatePolicy:         string(assignment.LatePolicy),
+		LatePenaltyPercent: assignment.LatePenaltyPercent,
+		MaxLateDays:        assignment.MaxLateDays,
+		CreatedAt:          assignment.CreatedAt,
+	}
+}
+
+// SubmissionResponseDTOFactory is a factory for creating SubmissionResponseDTO objects
+type SubmissionResponseDTOFactory struct{}
+
+func NewSubmissionResponseDTOFactory() *SubmissionResponseDTOFactory {
+	return &SubmissionResponseDTOFactory{}
+}
+
+func (f *SubmissionResponseDTOFactory) CreateFromEntity(submission *domain.Submission) *dto.SubmissionResponseDTO {
+	studentName := ""
+	if submission.Student.User.FirstName != "" {
+		studentName = submission.Student.User.FirstName + " " + submission.Student.User.LastName
+	}
+
+	return &dto.SubmissionResponseDTO{
+		ID:             submission.ID,
+		AssignmentID:   submission.AssignmentID,
+		StudentID:      submission.StudentID,
+		StudentName:    studentName,
+		FileName:       submission.FileName,
+		ContentType:    submission.ContentType,
+		Size:           submission.Size,
+		SubmittedAt:    submission.SubmittedAt,
+		DaysLate:       submission.DaysLate,
+		PenaltyPercent: submission.PenaltyPercent,
+		Score:          submission.Score,
+		FinalScore:     submission.FinalScore,
+		Feedback:       submission.Feedback,
+		GradedBy:       submission.GradedBy,
+		GradedAt:       submission.GradedAt,
+	}
+}
+
+// ClassSessionResponseDTOFactory is a factory for creating ClassSessionResponseDTO objects
+type ClassSessionResponseDTOFactory struct{}
+
+func NewClassSessionResponseDTOFactory() *ClassSessionResponseDTOFactory {
+	return &ClassSessionResponseDTOFactory{}
+}
+
+func (f *ClassSessionResponseDTOFactory) CreateFromEntity(session *domain.ClassSession) *dto.ClassSessionResponseDTO {
+	return &dto.ClassSessionResponseDTO{
+		ID:        session.ID,
+		CourseID:  session.CourseID,
+		StartsAt:  session.StartsAt,
+		EndsAt:    session.EndsAt,
+		Room:      session.Room,
+		Topic:     session.Topic,
+		CreatedBy: session.CreatedBy,
+		CreatedAt: session.CreatedAt,
+	}
+}
+
+// AttendanceRecordResponseDTOFactory is a factory for creating AttendanceRecordResponseDTO objects
+type AttendanceRecordResponseDTOFactory struct{}
+
+func NewAttendanceRecordResponseDTOFactory() *AttendanceRecordResponseDTOFactory {
+	return &AttendanceRecordResponseDTOFactory{}
+}
+
+func (f *AttendanceRecordResponseDTOFactory) CreateFromEntity(record *domain.AttendanceRecord) *dto.AttendanceRecordResponseDTO {
+	return &dto.AttendanceRecordResponseDTO{
+		ID:           record.ID,
+		SessionID:    record.SessionID,
+		EnrollmentID: record.EnrollmentID,
+		Status:       string(record.Status),
+		Note:         record.Note,
+		MarkedBy:     record.MarkedBy,
+		UpdatedAt:    record.UpdatedAt,
+	}
+}
+
+// CourseMeetingResponseDTOFactory is a factory for creating CourseMeetingResponseDTO objects
+type CourseMeetingResponseDTOFactory struct{}
+
+func NewCourseMeetingResponseDTOFactory() *CourseMeetingResponseDTOFactory {
+	return &CourseMeetingResponseDTOFactory{}
+}
+
+func (f *CourseMeetingResponseDTOFactory) CreateFromEntity(meeting *domain.CourseMeeting) *dto.CourseMeetingResponseDTO {
+	return &dto.CourseMeetingResponseDTO{
+		ID:        meeting.ID,
+		CourseID:  meeting.CourseID,
+		Days:      schedule.Days(meeting.Days).Names(),
+		StartTime: schedule.FormatClock(meeting.StartMinute),
+		EndTime:   schedule.FormatClock(meeting.EndMinute),
+		RoomID:    meeting.RoomID,
+		Room:      meeting.RoomLabel(),
+	}
+}
+
+// RoomResponseDTOFactory is a factory for creating RoomResponseDTO objects
+type RoomResponseDTOFactory struct{}
+
+func NewRoomResponseDTOFactory() *RoomResponseDTOFactory {
+	return &RoomResponseDTOFactory{}
+}
+
+func (f *RoomResponseDTOFactory) CreateFromEntity(room *domain.Room) *dto.RoomResponseDTO {
+	features := []string{}
+	for _, feature := range room.Features {
+		features = append(features, feature.Feature)
+	}
+	return &dto.RoomResponseDTO{
+		ID:       room.ID,
+		Building: room.Building,
+		Number:   room.Number,
+		Label:    room.Label(),
+		Capacity: room.Capacity,
+		Features: features,
+	}
+}
+
+// RoomBookingResponseDTOFactory is a factory for creating RoomBookingResponseDTO objects
+type RoomBookingResponseDTOFactory struct{}
+
+func NewRoomBookingResponseDTOFactory() *RoomBookingResponseDTOFactory {
+	return &RoomBookingResponseDTOFactory{}
+}
+
+func (f *RoomBookingResponseDTOFactory) CreateFromEntity(booking *domain.RoomBooking) *dto.RoomBookingResponseDTO {
+	return &dto.RoomBookingResponseDTO{
+		ID:        booking.ID,
+		RoomID:    booking.RoomID,
+		RoomLabel: booking.Room.Label(),
+		Title:     booking.Title,
+		Purpose:   string(booking.Purpose),
+		CourseID:  booking.CourseID,
+		StartsAt:  booking.StartsAt,
+		EndsAt:    booking.EndsAt,
+		BookedBy:  booking.BookedBy,
+		CreatedAt: booking.CreatedAt,
+	}
+}
+
+// ScheduleJobResponseDTOFactory is a factory for creating ScheduleJobResponseDTO objects
+type ScheduleJobResponseDTOFactory struct{}
+
+func NewScheduleJobResponseDTOFactory() *ScheduleJobResponseDTOFactory {
+	return &ScheduleJobResponseDTOFactory{}
+}
+
+func (f *ScheduleJobResponseDTOFactory) CreateFromEntity(job *domain.ScheduleJob) *dto.ScheduleJobResponseDTO {
+	return &dto.ScheduleJobResponseDTO{
+		ID:         job.ID,
+		TermID:     job.TermID,
+		Status:     string(job.Status),
+		Progress:   job.Progress,
+		Error:      job.Error,
+		CreatedBy:  job.CreatedBy,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+		AppliedAt:  job.AppliedAt,
+	}
+}
+
+// ExamResponseDTOFactory is a factory for creating ExamResponseDTO objects
+type ExamResponseDTOFactory struct{}
+
+func NewExamResponseDTOFactory() *ExamResponseDTOFactory {
+	return &ExamResponseDTOFactory{}
+}
+
+func (f *ExamResponseDTOFactory) CreateFromEntity(exam *domain.Exam) *dto.ExamResponseDTO {
+	return &dto.ExamResponseDTO{
+		ID:         exam.ID,
+		CourseID:   exam.CourseID,
+		CourseCode: exam.Course.Code,
+		CourseName: exam.Course.Name,
+		Title:      exam.Title,
+		Duration:   exam.Duration,
+		StartsAt:   exam.StartsAt,
+		EndsAt:     exam.EndsAt(),
+		Locked:     exam.Locked,
+	}
+}
+
+// ProgramResponseDTOFactory is a factory for creating ProgramResponseDTO objects
+type ProgramResponseDTOFactory struct{}
+
+func NewProgramResponseDTOFactory() *ProgramResponseDTOFactory {
+	return &ProgramResponseDTOFactory{}
+}
+
+func (f *ProgramResponseDTOFactory) CreateFromEntity(program *domain.Program) *dto.ProgramResponseDTO {
+	response := &dto.ProgramResponseDTO{
+		ID:           program.ID,
+		Code:         program.Code,
+		Name:         program.Name,
+		CatalogYear:  program.CatalogYear,
+		TotalCredits: program.TotalCredits,
+		DepartmentID: program.DepartmentID,
+		Groups:       []dto.RequirementGroupResponseDTO{},
+	}
+	for _, group := range program.Groups {
+		groupDTO := dto.RequirementGroupResponseDTO{
+			ID:         group.ID,
+			Name:       group.Name,
+			Type:       string(group.Type),
+			MinCredits: group.MinCredits,
+			Courses:    []dto.RequirementCourseDTO{},
+		}
+		for _, course := range group.Courses {
+			groupDTO.Courses = append(groupDTO.Courses, dto.RequirementCourseDTO{
+				CourseID:   course.CourseID,
+				CourseCode: course.Course.Code,
+				CourseName: course.Course.Name,
+				Credits:    course.Course.Credits,
+			})
+		}
+		response.Groups = append(response.Groups, groupDTO)
+	}
+	return response
+}
+
+// DepartmentResponseDTOFactory is a factory for creating DepartmentResponseDTO objects
+type DepartmentResponseDTOFactory struct{}
+
+func NewDepartmentResponseDTOFactory() *DepartmentResponseDTOFactory {
+	return &DepartmentResponseDTOFactory{}
+}
+
+func (f *DepartmentResponseDTOFactory) CreateFromEntity(department *domain.Department) *dto.DepartmentResponseDTO {
+	headTeacherName := ""
+	if department.HeadTeacher != nil {
+		headTeacherName = department.HeadTeacher.User.FirstName + " " + department.HeadTeacher.User.LastName
+	}
+
+	return &dto.DepartmentResponseDTO{
+		ID:              department.ID,
+		Code:            department.Code,
+		SubjectCode:     department.SubjectCode,
+		Name:            department.Name,
+		HeadTeacherID:   department.HeadTeacherID,
+		HeadTeacherName: headTeacherName,
+	}
+}
@@ -0,0 +1,537 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/grading"
+)
+
+// GradebookService manages the assessment components of a course and the
+// scores of its students. Once a course has components, enrollment grades are
+// computed from the scores instead of being entered by hand.
+type GradebookService struct {
+	assessmentRepo       *repository.AssessmentRepository
+	courseRepo           *repository.CourseRepository
+	enrollmentRepo       *repository.EnrollmentRepository
+	auditService         *AuditService
+	componentDTOFactory  *factory.AssessmentComponentResponseDTOFactory
+	scoreDTOFactory      *factory.AssessmentScoreResponseDTOFactory
+	courseDTOFactory     *factory.CourseResponseDTOFactory
+	enrollmentDTOFactory *factory.EnrollmentResponseDTOFactory
+}
+
+func NewGradebookService(assessmentRepo *repository.AssessmentRepository, courseRepo *repository.CourseRepository, enrollmentRepo *repository.EnrollmentRepository, auditService *AuditService) *GradebookService {
+	return &GradebookService{
+		assessmentRepo:       assessmentRepo,
+		courseRepo:           courseRepo,
+		enrollmentRepo:       enrollmentRepo,
+		auditService:         auditService,
+		componentDTOFactory:  factory.NewAssessmentComponentResponseDTOFactory(),
+		scoreDTOFactory:      factory.NewAssessmentScoreResponseDTOFactory(),
+		courseDTOFactory:     factory.NewCourseResponseDTOFactory(),
+		enrollmentDTOFactory: factory.NewEnrollmentResponseDTOFactory(),
+	}
+}
+
+// GetScheme returns the components and rounding of a course
+func (s *GradebookService) GetScheme(courseID uint) (*dto.GradingSchemeResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	components, err := s.assessmentRepo.FindComponentsByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve assessment components", err)
+	}
+
+	return s.schemeResponse(course, components), nil
+}
+
+// UpdateScheme changes how computed grades are rounded and recomputes them
+func (s *GradebookService) UpdateScheme(subject policy.Subject, actor domain.Actor, courseID uint, req *dto.GradingSchemeUpdateDTO) (*dto.GradingSchemeResponseDTO, error) {
+	course, err := s.editableCourse(subject, courseID)
+	if err != nil {
+		return nil, err
+	}
+	before := s.courseDTOFactory.CreateFromEntity(course)
+
+	course.GradeRounding = req.Rounding
+	course.GradeDecimals = *req.Decimals
+	if err := s.rounding(course).Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error(), err)
+	}
+
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewCourseRepository(tx).Update(course); err != nil {
+			return errors.InternalServerError("Failed to update grading scheme", err)
+		}
+		if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityCourse, course.ID, before, s.courseDTOFactory.CreateFromEntity(course)); err != nil {
+			return err
+		}
+		return s.recompute(tx, actor, course)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetScheme(course.ID)
+}
+
+func (s *GradebookService) CreateComponent(subject policy.Subject, actor domain.Actor, courseID uint, req *dto.AssessmentComponentCreateDTO) (*dto.AssessmentComponentResponseDTO, error) {
+	course, err := s.editableCourse(subject, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	component := &domain.AssessmentComponent{
+		CourseID:  course.ID,
+		Name:      req.Name,
+		Weight:    req.Weight,
+		MaxScore:  100,
+		ItemCount: 1,
+	}
+	if req.MaxScore != nil {
+		component.MaxScore = *req.MaxScore
+	}
+	if req.ItemCount != nil {
+		component.ItemCount = *req.ItemCount
+	}
+	if req.DropLowest != nil {
+		component.DropLowest = *req.DropLowest
+	}
+
+	var response *dto.AssessmentComponentResponseDTO
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		assessmentRepo := repository.NewAssessmentRepository(tx)
+		if err := s.validateComponent(tx, component); err != nil {
+			return err
+		}
+		if err := assessmentRepo.CreateComponent(component); err != nil {
+			return errors.InternalServerError("Failed to create assessment component", err)
+		}
+		response = s.componentDTOFactory.CreateFromEntity(component)
+		if err := s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityAssessment, component.ID, nil, response); err != nil {
+			return err
+		}
+		return s.recompute(tx, actor, course)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// UpdateComponent changes a component. Scores of items the component no longer
+// has are removed.
+func (s *GradebookService) UpdateComponent(subject policy.Subject, actor domain.Actor, courseID, componentID uint, req *dto.AssessmentComponentUpdateDTO) (*dto.AssessmentComponentResponseDTO, error) {
+	course, err := s.editableCourse(subject, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	component, err := s.assessmentRepo.FindComponentByID(componentID)
+	if err != nil || component.CourseID != course.ID {
+		return nil, errors.NotFound("Assessment component not found", err)
+	}
+	before := s.componentDTOFactory.CreateFromEntity(component)
+
+	if req.Name != "" {
+		component.Name = req.Name
+	}
+	if req.Weight != nil {
+		component.Weight = *req.Weight
+	}
+	if req.MaxScore != nil {
+		component.MaxScore = *req.MaxScore
+	}
+	if req.ItemCount != nil {
+		component.ItemCount = *req.ItemCount
+	}
+	if req.DropLowest != nil {
+		component.DropLowest = *req.DropLowest
+	}
+
+	response := s.componentDTOFactory.CreateFromEntity(component)
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		assessmentRepo := repository.NewAssessmentRepository(tx)
+		if err := s.validateComponent(tx, component); err != nil {
+			return err
+		}
+		if err := assessmentRepo.UpdateComponent(component); err != nil {
+			return errors.InternalServerError("Failed to update assessment component", err)
+		}
+		if err := assessmentRepo.DeleteScoresBeyond(component.ID, component.ItemCount); err != nil {
+			return errors.InternalServerError("Failed to remove scores", err)
+		}
+		if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityAssessment, component.ID, before, response); err != nil {
+			return err
+		}
+		return s.recompute(tx, actor, course)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteComponent removes a component and all of its scores
+func (s *GradebookService) DeleteComponent(subject policy.Subject, actor domain.Actor, courseID, componentID uint) error {
+	course, err := s.editableCourse(subject, courseID)
+	if err != nil {
+		return err
+	}
+
+	component, err := s.assessmentRepo.FindComponentByID(componentID)
+	if err != nil || component.CourseID != course.ID {
+		return errors.NotFound("Assessment component not found", err)
+	}
+
+	return s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		if _, err := repository.NewCourseRepository(tx).FindByIDForUpdate(course.ID); err != nil {
+			return errors.InternalServerError("Failed to lock course", err)
+		}
+		if err := repository.NewAssessmentRepository(tx).DeleteComponent(component.ID); err != nil {
+			return errors.InternalServerError("Failed to delete assessment component", err)
+		}
+		if err := s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityAssessment, component.ID, s.componentDTOFactory.CreateFromEntity(component), nil); err != nil {
+			return err
+		}
+		return s.recompute(tx, actor, course)
+	})
+}
+
+// RecordScores sets or clears item scores and recomputes the affected grades
+func (s *GradebookService) RecordScores(subject policy.Subject, actor domain.Actor, courseID uint, req *dto.ScoresUpdateDTO) (*dto.GradebookResponseDTO, error) {
+	course, err := s.editableCourse(subject, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := s.assessmentRepo.FindComponentsByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve assessment components", err)
+	}
+	enrollments, err := s.enrollmentRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	componentsByID := make(map[uint]domain.AssessmentComponent)
+	for _, component := range components {
+		componentsByID[component.ID] = component
+	}
+	seats := make(map[uint]bool)
+	for _, enrollment := range enrollments {
+		seats[enrollment.ID] = enrollment.HoldsSeat()
+	}
+
+	for i, entry := range req.Scores {
+		details := map[string]interface{}{"index": i}
+		component, ok := componentsByID[entry.ComponentID]
+		if !ok {
+			return nil, errors.BadRequest("Assessment component does not belong to the course", nil).WithDetails(details)
+		}
+		if !seats[entry.EnrollmentID] {
+			return nil, errors.BadRequest("Enrollment is not active in the course", nil).WithDetails(details)
+		}
+		if entry.Item > component.ItemCount {
+			return nil, errors.BadRequest("Component has fewer items", nil).WithDetails(details)
+		}
+		if entry.Score != nil && *entry.Score > component.MaxScore {
+			return nil, errors.BadRequest("Score exceeds the maximum score of the component", nil).WithDetails(details)
+		}
+	}
+
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		if _, err := repository.NewCourseRepository(tx).FindByIDForUpdate(course.ID); err != nil {
+			return errors.InternalServerError("Failed to lock course", err)
+		}
+
+		assessmentRepo := repository.NewAssessmentRepository(tx)
+		for _, entry := range req.Scores {
+			item := entry.Item
+			if item == 0 {
+				item = 1
+			}
+
+			var before *dto.AssessmentScoreResponseDTO
+			existing, _ := assessmentRepo.FindScore(entry.ComponentID, entry.EnrollmentID, item)
+			if existing != nil {
+				before = s.scoreDTOFactory.CreateFromEntity(existing)
+			}
+
+			if entry.Score == nil {
+				if existing == nil {
+					continue
+				}
+				if err := assessmentRepo.DeleteScore(existing.ID); err != nil {
+					return errors.InternalServerError("Failed to clear score", err)
+				}
+				if err := s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityScore, existing.ID, before, nil); err != nil {
+					return err
+				}
+				continue
+			}
+
+			score := &domain.AssessmentScore{
+				ComponentID:  entry.ComponentID,
+				EnrollmentID: entry.EnrollmentID,
+				Item:         item,
+				Score:        *entry.Score,
+				GradedBy:     actor.UserID,
+			}
+			if err := assessmentRepo.SaveScore(score); err != nil {
+				return errors.InternalServerError("Failed to record score", err)
+			}
+			action := domain.AuditActionCreate
+			if existing != nil {
+				action = domain.AuditActionUpdate
+			}
+			if err := s.auditService.Record(tx, actor, action, domain.AuditEntityScore, score.ID, before, s.scoreDTOFactory.CreateFromEntity(score)); err != nil {
+				return err
+			}
+		}
+
+		return s.recompute(tx, actor, course)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetGradebook(subject, course.ID)
+}
+
+// GetGradebook returns the scores of every student holding a seat in the
+// course on every component, together with their computed grades
+func (s *GradebookService) GetGradebook(subject policy.Subject, courseID uint) (*dto.GradebookResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if !policy.CanGradeCourse(subject, course) {
+		return nil, errors.Forbidden("You can only view the gradebook of your own courses", nil)
+	}
+
+	components, err := s.assessmentRepo.FindComponentsByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve assessment components", err)
+	}
+	scores, err := s.assessmentRepo.FindScoresByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve scores", err)
+	}
+	enrollments, err := s.enrollmentRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	scheme := s.schemeResponse(course, components)
+	gradebook := &dto.GradebookResponseDTO{
+		CourseID:    course.ID,
+		CourseCode:  course.Code,
+		CourseName:  course.Name,
+		Rounding:    scheme.Rounding,
+		Decimals:    scheme.Decimals,
+		TotalWeight: scheme.TotalWeight,
+		Locked:      course.GradesLocked(time.Now()),
+		Components:  scheme.Components,
+		Rows:        []dto.GradebookRowDTO{},
+	}
+
+	scoresByEnrollment := groupScores(scores)
+	rounding := s.rounding(course)
+	for _, enrollment := range enrollments {
+		if !enrollment.HoldsSeat() {
+			continue
+		}
+
+		enrollmentScores := scoresByEnrollment[enrollment.ID]
+		result := grading.Calculate(gradingComponents(components, enrollmentScores), rounding)
+		row := dto.GradebookRowDTO{
+			EnrollmentID:  enrollment.ID,
+			StudentID:     enrollment.StudentID,
+			StudentName:   enrollment.Student.User.FirstName + " " + enrollment.Student.User.LastName,
+			Status:        string(enrollment.Status),
+			CurrentGrade:  result.Current,
+			ComputedGrade: result.Grade,
+			Grade:         enrollment.Grade,
+		}
+		for i, component := range components {
+			cell := dto.GradebookCellDTO{
+				ComponentID: component.ID,
+				Items:       make([]*float64, component.ItemCount),
+				Percentage:  result.Percentages[i],
+			}
+			for _, score := range enrollmentScores {
+				if score.ComponentID == component.ID && score.Item <= component.ItemCount {
+					value := score.Score
+					cell.Items[score.Item-1] = &value
+				}
+			}
+			row.Scores = append(row.Scores, cell)
+		}
+		gradebook.Rows = append(gradebook.Rows, row)
+	}
+
+	return gradebook, nil
+}
+
+// editableCourse loads a course whose assessments the subject may change
+func (s *GradebookService) editableCourse(subject policy.Subject, courseID uint) (*domain.Course, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if !policy.CanGradeCourse(subject, course) {
+		return nil, errors.Forbidden("You can only grade your own courses", nil)
+	}
+	if course.GradesLocked(time.Now()) {
+		return nil, errors.Conflict("Grades of this course are locked, submit a grade change request instead", nil)
+	}
+	return course, nil
+}
+
+// validateComponent checks the item rules and that the weights of the course
+// do not exceed 100. It locks the course so that concurrent changes cannot
+// together exceed the limit.
+func (s *GradebookService) validateComponent(tx *repository.Repository, component *domain.AssessmentComponent) error {
+	if component.DropLowest >= component.ItemCount {
+		return errors.BadRequest("At least one item must count after dropping the lowest scores", nil)
+	}
+
+	if _, err := repository.NewCourseRepository(tx).FindByIDForUpdate(component.CourseID); err != nil {
+		return errors.InternalServerError("Failed to lock course", err)
+	}
+	components, err := repository.NewAssessmentRepository(tx).FindComponentsByCourseID(component.CourseID)
+	if err != nil {
+		return errors.InternalServerError("Failed to retrieve assessment components", err)
+	}
+
+	total := component.Weight
+	for _, other := range components {
+		if other.ID == component.ID {
+			continue
+		}
+		if other.Name == component.Name {
+			return errors.Conflict("Course already has a component with this name", nil)
+		}
+		total += other.Weight
+	}
+	if total > 100+1e-9 {
+		return errors.BadRequest("Component weights of a course cannot exceed 100", nil).
+			WithDetails(map[string]interface{}{"totalWeight": total})
+	}
+	return nil
+}
+
+// recompute updates the grade of every student holding a seat to the grade
+// computed from their scores and audits each change. Courses without
+// components keep hand-entered grades.
+func (s *GradebookService) recompute(tx *repository.Repository, actor domain.Actor, course *domain.Course) error {
+	assessmentRepo := repository.NewAssessmentRepository(tx)
+	components, err := assessmentRepo.FindComponentsByCourseID(course.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to retrieve assessment components", err)
+	}
+	if len(components) == 0 {
+		return nil
+	}
+	scores, err := assessmentRepo.FindScoresByCourseID(course.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to retrieve scores", err)
+	}
+
+	enrollmentRepo := repository.NewEnrollmentRepository(tx)
+	enrollments, err := enrollmentRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	scoresByEnrollment := groupScores(scores)
+	rounding := s.rounding(course)
+	gradeChangeRepo := repository.NewGradeChangeRepository(tx)
+	for i := range enrollments {
+		enrollment := &enrollments[i]
+		if !enrollment.HoldsSeat() {
+			continue
+		}
+
+		grade := grading.Calculate(gradingComponents(components, scoresByEnrollment[enrollment.ID]), rounding).Grade
+		if !gradeChanged(enrollment.Grade, grade) {
+			continue
+		}
+
+		before := s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+		oldGrade := enrollment.Grade
+		enrollment.Grade = grade
+		if err := enrollmentRepo.Update(enrollment); err != nil {
+			return errors.InternalServerError("Failed to update grade", err)
+		}
+		entry := &domain.GradeHistory{
+			EnrollmentID: enrollment.ID,
+			OldGrade:     oldGrade,
+			NewGrade:     grade,
+			Source:       domain.GradeSourceGrading,
+			ChangedBy:    actor.UserID,
+		}
+		if err := gradeChangeRepo.CreateHistory(entry); err != nil {
+			return errors.InternalServerError("Failed to record grade history", err)
+		}
+		if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityEnrollment, enrollment.ID, before, s.enrollmentDTOFactory.CreateFromEntity(enrollment)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *GradebookService) rounding(course *domain.Course) grading.Rounding {
+	return grading.Rounding{Mode: grading.RoundingMode(course.GradeRounding), Decimals: course.GradeDecimals}
+}
+
+func (s *GradebookService) schemeResponse(course *domain.Course, components []domain.AssessmentComponent) *dto.GradingSchemeResponseDTO {
+	scheme := &dto.GradingSchemeResponseDTO{
+		CourseID:   course.ID,
+		Rounding:   course.GradeRounding,
+		Decimals:   course.GradeDecimals,
+		Components: []dto.AssessmentComponentResponseDTO{},
+	}
+	for _, component := range components {
+		scheme.TotalWeight += component.Weight
+		scheme.Components = append(scheme.Components, *s.componentDTOFactory.CreateFromEntity(&component))
+	}
+	return scheme
+}
+
+func groupScores(scores []domain.AssessmentScore) map[uint][]domain.AssessmentScore {
+	grouped := make(map[uint][]domain.AssessmentScore)
+	for _, score := range scores {
+		grouped[score.EnrollmentID] = append(grouped[score.EnrollmentID], score)
+	}
+	return grouped
+}
+
+// gradingComponents converts the components of a course and the scores of one
+// enrollment into calculator input, in the order of components
+func gradingComponents(components []domain.AssessmentComponent, scores []domain.AssessmentScore) []grading.Component {
+	result := make([]grading.Component, len(components))
+	for i, component := range components {
+		result[i] = grading.Component{
+			Weight:     component.Weight,
+			MaxScore:   component.MaxScore,
+			Items:      component.ItemCount,
+			DropLowest: component.DropLowest,
+		}
+		for _, score := range scores {
+			if score.ComponentID == component.ID && score.Item <= component.ItemCount {
+				result[i].Scores = append(result[i].Scores, score.Score)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+)
+
+// GradingService finalizes the grades of a course and handles the requests to
+// change a grade afterwards. Every applied change is kept in the grade history.
+type GradingService struct {
+	gradeChangeRepo      *repository.GradeChangeRepository
+	enrollmentRepo       *repository.EnrollmentRepository
+	courseRepo           *repository.CourseRepository
+	auditService         *AuditService
+	requestDTOFactory    *factory.GradeChangeRequestResponseDTOFactory
+	historyDTOFactory    *factory.GradeHistoryResponseDTOFactory
+	enrollmentDTOFactory *factory.EnrollmentResponseDTOFactory
+	courseDTOFactory     *factory.CourseResponseDTOFactory
+}
+
+func NewGradingService(gradeChangeRepo *repository.GradeChangeRepository, enrollmentRepo *repository.EnrollmentRepository, courseRepo *repository.CourseRepository, auditService *AuditService) *GradingService {
+	return &GradingService{
+		gradeChangeRepo:      gradeChangeRepo,
+		enrollmentRepo:       enrollmentRepo,
+		courseRepo:           courseRepo,
+		auditService:         auditService,
+		requestDTOFactory:    factory.NewGradeChangeRequestResponseDTOFactory(),
+		historyDTOFactory:    factory.NewGradeHistoryResponseDTOFactory(),
+		enrollmentDTOFactory: factory.NewEnrollmentResponseDTOFactory(),
+		courseDTOFactory:     factory.NewCourseResponseDTOFactory(),
+	}
+}
+
+// FinalizeCourse submits the grades of a course. Every student holding a seat
+// must have a grade; active enrollments are completed and the grades are locked.
+func (s *GradingService) FinalizeCourse(subject policy.Subject, actor domain.Actor, courseID uint) (*dto.CourseResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	before := s.courseDTOFactory.CreateFromEntity(course)
+
+	if !policy.CanGradeCourse(subject, course) {
+		return nil, errors.Forbidden("You can only finalize grades of your own courses", nil)
+	}
+	if course.GradesFinalizedAt != nil {
+		return nil, errors.Conflict("Grades of this course are already finalized", nil)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	var ungraded []uint
+	var completing []domain.Enrollment
+	for _, enrollment := range enrollments {
+		if !enrollment.HoldsSeat() {
+			continue
+		}
+		if enrollment.Grade == nil {
+			ungraded = append(ungraded, enrollment.ID)
+			continue
+		}
+		if enrollment.Status == domain.EnrollmentStatusEnrolled {
+			completing = append(completing, enrollment)
+		}
+	}
+	if len(ungraded) > 0 {
+		return nil, errors.BadRequest("Every enrolled student needs a grade before grades can be finalized", nil).
+			WithDetails(map[string]interface{}{"ungradedEnrollmentIds": ungraded})
+	}
+
+	now := time.Now()
+	finalizedBy := actor.UserID
+	var response *dto.CourseResponseDTO
+	err = s.courseRepo.Transaction(func(tx *repository.Repository) error {
+		finalized, err := repository.NewCourseRepository(tx).MarkGradesFinalized(course.ID, actor.UserID, now)
+		if err != nil {
+			return errors.InternalServerError("Failed to finalize grades", err)
+		}
+		if !finalized {
+			return errors.Conflict("Grades of this course are already finalized", nil)
+		}
+
+		enrollmentRepo := repository.NewEnrollmentRepository(tx)
+		for i := range completing {
+			enrollment := &completing[i]
+			enrollmentBefore := s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+			enrollment.Status = domain.EnrollmentStatusCompleted
+			if err := enrollmentRepo.Update(enrollment); err != nil {
+				return errors.InternalServerError("Failed to complete enrollment", err)
+			}
+			if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityEnrollment, enrollment.ID, enrollmentBefore, s.enrollmentDTOFactory.CreateFromEntity(enrollment)); err != nil {
+				return err
+			}
+		}
+
+		course.GradesFinalizedAt = &now
+		course.GradesFinalizedBy = &finalizedBy
+		response = s.courseDTOFactory.CreateFromEntity(course)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityCourse, course.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// RequestChange asks for a locked grade to be changed. Only one request per
+// enrollment can be pending at a time.
+func (s *GradingService) RequestChange(subject policy.Subject, actor domain.Actor, enrollmentID uint, req *dto.GradeChangeRequestCreateDTO) (*dto.GradeChangeRequestResponseDTO, error) {
+	enrollment, err := s.enrollmentRepo.FindByID(enrollmentID)
+	if err != nil {
+		return nil, errors.NotFound("Enrollment not found", err)
+	}
+
+	if !policy.CanGradeEnrollment(subject, enrollment) {
+		return nil, errors.Forbidden("You can only request grade changes in your own courses", nil)
+	}
+	if !enrollment.HoldsSeat() {
+		return nil, errors.BadRequest("Dropped or withdrawn enrollments cannot be graded", nil)
+	}
+	if !enrollment.Course.GradesLocked(time.Now()) {
+		return nil, errors.BadRequest("Grades of this course are not locked, update the enrollment instead", nil)
+	}
+	if !gradeChanged(enrollment.Grade, req.NewGrade) {
+		return nil, errors.BadRequest("The new grade equals the current grade", nil)
+	}
+
+	pending, err := s.gradeChangeRepo.HasPending(enrollment.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to check pending requests", err)
+	}
+	if pending {
+		return nil, errors.Conflict("A grade change request for this enrollment is already pending", nil)
+	}
+
+	request := &domain.GradeChangeRequest{
+		EnrollmentID: enrollment.ID,
+		OldGrade:     enrollment.Grade,
+		NewGrade:     *req.NewGrade,
+		Reason:       req.Reason,
+		Status:       domain.GradeChangePending,
+		RequestedBy:  actor.UserID,
+	}
+	var response *dto.GradeChangeRequestResponseDTO
+	err = s.gradeChangeRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewGradeChangeRepository(tx).Create(request); err != nil {
+			return errors.InternalServerError("Failed to create grade change request", err)
+		}
+		request.Enrollment = *enrollment
+		response = s.requestDTOFactory.CreateFromEntity(request)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityGradeChange, request.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetRequests lists grade change requests. grade:approve-change-all holders see
+// every request, department heads the requests in their department and their
+// own, other users only the requests they made.
+func (s *GradingService) GetRequests(subject policy.Subject, status string) ([]dto.GradeChangeRequestResponseDTO, error) {
+	filter := domain.GradeChangeStatus(status)
+	switch filter {
+	case "", domain.GradeChangePending, domain.GradeChangeApproved, domain.GradeChangeRejected:
+	default:
+		return nil, errors.BadRequest("Invalid status", nil)
+	}
+
+	var requestedBy, headTeacherID uint
+	if !subject.Can(domain.PermissionGradeApproveChangeAll) {
+		requestedBy = subject.UserID
+		if subject.Can(domain.PermissionGradeApproveChange) {
+			headTeacherID = subject.TeacherID
+		}
+	}
+
+	requests, err := s.gradeChangeRepo.FindAll(filter, requestedBy, headTeacherID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve grade change requests", err)
+	}
+
+	var dtos []dto.GradeChangeRequestResponseDTO
+	for _, request := range requests {
+		dtos = append(dtos, *s.requestDTOFactory.CreateFromEntity(&request))
+	}
+
+	return dtos, nil
+}
+
+// Approve applies the requested grade and records it in the grade history
+func (s *GradingService) Approve(subject policy.Subject, actor domain.Actor, id uint, req *dto.GradeChangeReviewDTO) (*dto.GradeChangeRequestResponseDTO, error) {
+	return s.review(subject, actor, id, req, domain.GradeChangeApproved)
+}
+
+// Reject declines the request and leaves the grade unchanged
+func (s *GradingService) Reject(subject policy.Subject, actor domain.Actor, id uint, req *dto.GradeChangeReviewDTO) (*dto.GradeChangeRequestResponseDTO, error) {
+	return s.review(subject, actor, id, req, domain.GradeChangeRejected)
+}
+
+func (s *GradingService) review(subject policy.Subject, actor domain.Actor, id uint, req *dto.GradeChangeReviewDTO, decision domain.GradeChangeStatus) (*dto.GradeChangeRequestResponseDTO, error) {
+	request, err := s.gradeChangeRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Grade change request not found", err)
+	}
+	before := s.requestDTOFactory.CreateFromEntity(request)
+
+	if !policy.CanReviewGradeChange(subject, request) {
+		return nil, errors.Forbidden("You cannot review this grade change request", nil)
+	}
+	if request.Status != domain.GradeChangePending {
+		return nil, errors.Conflict("Grade change request was already reviewed", nil)
+	}
+
+	now := time.Now()
+	reviewer := actor.UserID
+	request.Status = decision
+	request.ReviewedBy = &reviewer
+	request.ReviewedAt = &now
+	request.ReviewComment = req.Comment
+
+	enrollment := &request.Enrollment
+	enrollmentBefore := s.enrollmentDTOFactory.CreateFromEntity(enrollment)
+	var response *dto.GradeChangeRequestResponseDTO
+	err = s.gradeChangeRepo.Transaction(func(tx *repository.Repository) error {
+		gradeChangeRepo := repository.NewGradeChangeRepository(tx)
+		reviewed, err := gradeChangeRepo.MarkReviewed(request, now)
+		if err != nil {
+			return errors.InternalServerError("Failed to review grade change request", err)
+		}
+		if !reviewed {
+			return errors.Conflict("Grade change request was already reviewed", nil)
+		}
+		response = s.requestDTOFactory.CreateFromEntity(request)
+		if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityGradeChange, request.ID, before, response); err != nil {
+			return err
+		}
+		if decision != domain.GradeChangeApproved {
+			return nil
+		}
+
+		oldGrade := enrollment.Grade
+		newGrade := request.NewGrade
+		enrollment.Grade = &newGrade
+		if err := repository.NewEnrollmentRepository(tx).Update(enrollment); err != nil {
+			return errors.InternalServerError("Failed to update grade", err)
+		}
+		entry := &domain.GradeHistory{
+			EnrollmentID:    enrollment.ID,
+			OldGrade:        oldGrade,
+			NewGrade:        enrollment.Grade,
+			Source:          domain.GradeSourceChangeRequest,
+			ChangeRequestID: &request.ID,
+			ChangedBy:       actor.UserID,
+		}
+		if err := gradeChangeRepo.CreateHistory(entry); err != nil {
+			return errors.InternalServerError("Failed to record grade history", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityEnrollment, enrollment.ID, enrollmentBefore, s.enrollmentDTOFactory.CreateFromEntity(enrollment))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetHistory lists every change of an enrollment's grade, oldest first
+func (s *GradingService) GetHistory(subject policy.Subject, enrollmentID uint) ([]dto.GradeHistoryResponseDTO, error) {
+	enrollment, err := s.enrollmentRepo.FindByID(enrollmentID)
+	if err != nil {
+		return nil, errors.NotFound("Enrollment not found", err)
+	}
+	if !policy.CanViewEnrollment(subject, enrollment) {
+		return nil, errors.Forbidden("You can only view your own grades", nil)
+	}
+
+	history, err := s.gradeChangeRepo.FindHistory(enrollment.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve grade history", err)
+	}
+
+	var dtos []dto.GradeHistoryResponseDTO
+	for _, entry := range history {
+		dtos = append(dtos, *s.historyDTOFactory.CreateFromEntity(&entry))
+	}
+
+	return dtos, nil
+}
+
+// gradeChanged reports whether two optional grades differ
+func gradeChanged(from, to *float64) bool {
+	if from == nil || to == nil {
+		return from != to
+	}
+	return *from != *to
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/auth"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/mailer"
+)
+
+// DefaultInvitationTTL is how long an invitation stays valid when no expiry is requested
+const DefaultInvitationTTL = 72 * time.Hour
+
+type InvitationService struct {
+	invitationRepo       *repository.InvitationRepository
+	roleRepo             *repository.RoleRepository
+	userRepo             *repository.UserRepository
+	mailer               mailer.Mailer
+	baseURL              string
+	invitationDTOFactory *factory.InvitationResponseDTOFactory
+}
+
+func NewInvitationService(invitationRepo *repository.InvitationRepository, roleRepo *repository.RoleRepository, userRepo *repository.UserRepository, mailer mailer.Mailer, baseURL string) *InvitationService {
+	return &InvitationService{
+		invitationRepo:       invitationRepo,
+		roleRepo:             roleRepo,
+		userRepo:             userRepo,
+		mailer:               mailer,
+		baseURL:              baseURL,
+		invitationDTOFactory: factory.NewInvitationResponseDTOFactory(),
+	}
+}
+
+// Create issues a single-use invitation and emails it to the invitee. The
+// returned DTO carries the token, which cannot be retrieved again, so it can
+// also be handed over manually if the email does not arrive. Students sign up
+// on their own, so every other role can be invited.
+func (s *InvitationService) Create(createdBy uint, req *dto.InvitationCreateDTO) (*dto.InvitationResponseDTO, error) {
+	role, err := s.roleRepo.FindByName(domain.Role(strings.ToUpper(req.Role)))
+	if err != nil {
+		return nil, errors.BadRequest("Role does not exist", err)
+	}
+	if role.Name == domain.RoleStudent {
+		return nil, errors.BadRequest("Students register themselves and cannot be invited", nil)
+	}
+
+	existingUser, _ := s.userRepo.FindByEmail(req.Email)
+	if existingUser != nil {
+		return nil, errors.Conflict("User with this email already exists", nil)
+	}
+
+	token, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to generate invitation token", err)
+	}
+
+	ttl := DefaultInvitationTTL
+	if req.ExpiresInHours > 0 {
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	invitation := &domain.Invitation{
+		TokenHash: auth.HashToken(token),
+		Email:     req.Email,
+		Role:      role.Name,
+		ExpiresAt: time.Now().Add(ttl),
+		CreatedBy: createdBy,
+	}
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, errors.InternalServerError("Failed to create invitation", err)
+	}
+
+	body := fmt.Sprintf(
+		"Hello,\n\nYou have been invited to create a %s account. Register with POST %s/auth/register/invite using the token below:\n\n%s\n\nThe invitation expires on %s.\n",
+		invitation.Role, s.baseURL, token, invitation.ExpiresAt.Format(time.RFC1123),
+	)
+	if err := s.mailer.Send(mailer.Message{To: invitation.Email, Subject: "You have been invited", Body: body}); err != nil {
+		log.Printf("Failed to send invitation to %s: %v", invitation.Email, err)
+	}
+
+	response := s.invitationDTOFactory.CreateFromEntity(invitation)
+	response.Token = token
+	return response, nil
+}
+
+func (s *InvitationService) GetAll() ([]dto.InvitationResponseDTO, error) {
+	invitations, err := s.invitationRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve invitations", err)
+	}
+
+	var dtos []dto.InvitationResponseDTO
+	for _, invitation := range invitations {
+		dtos = append(dtos, *s.invitationDTOFactory.CreateFromEntity(&invitation))
+	}
+
+	return dtos, nil
+}
+
+// Revoke invalidates a pending invitation
+func (s *InvitationService) Revoke(id uint) (*dto.InvitationResponseDTO, error) {
+	invitation, err := s.invitationRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Invitation not found", err)
+	}
+
+	if status := invitation.Status(time.Now()); status != domain.InvitationPending {
+		return nil, errors.Conflict("Only pending invitations can be revoked", nil).
+			WithDetails(map[string]interface{}{"status": status})
+	}
+
+	now := time.Now()
+	revoked, err := s.invitationRepo.MarkRevoked(invitation.ID, now)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to revoke invitation", err)
+	}
+	// Accepted or revoked by a concurrent request after the check above
+	if !revoked {
+		return nil, errors.Conflict("Only pending invitations can be revoked", nil)
+	}
+	invitation.RevokedAt = &now
+
+	return s.invitationDTOFactory.CreateFromEntity(invitation), nil
+}
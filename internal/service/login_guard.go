@@ -0,0 +1,189 @@
+package service
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/throttle"
+)
+
+// LoginGuard slows down password guessing by tracking failed logins per account
+// and per client IP, and records lockouts as security events
+type LoginGuard struct {
+	accounts        *throttle.Limiter
+	ips             *throttle.Limiter
+	eventRepo       *repository.SecurityEventRepository
+	userRepo        *repository.UserRepository
+	eventDTOFactory *factory.SecurityEventResponseDTOFactory
+}
+
+func NewLoginGuard(
+	store throttle.Store,
+	accountPolicy throttle.Policy,
+	ipPolicy throttle.Policy,
+	eventRepo *repository.SecurityEventRepository,
+	userRepo *repository.UserRepository,
+) *LoginGuard {
+	return &LoginGuard{
+		accounts:        throttle.NewLimiter(store, accountPolicy),
+		ips:             throttle.NewLimiter(store, ipPolicy),
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		eventDTOFactory: factory.NewSecurityEventResponseDTOFactory(),
+	}
+}
+
+// DefaultAccountPolicy allows a few typos, then doubles the delay between
+// attempts and locks the account after lockoutAfter failures
+func DefaultAccountPolicy(lockoutAfter int, lockoutDuration time.Duration) throttle.Policy {
+	if lockoutAfter <= 0 {
+		lockoutAfter = 10
+	}
+	if lockoutDuration <= 0 {
+		lockoutDuration = 15 * time.Minute
+	}
+	return throttle.Policy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxDelay:        30 * time.Second,
+		LockoutAfter:    lockoutAfter,
+		LockoutDuration: lockoutDuration,
+		Window:          time.Hour,
+	}
+}
+
+// DefaultIPPolicy is more lenient than the account policy since many users can
+// share one address, but still stops a single client from spraying passwords
+func DefaultIPPolicy(lockoutAfter int, lockoutDuration time.Duration) throttle.Policy {
+	policy := DefaultAccountPolicy(lockoutAfter, lockoutDuration)
+	policy.FreeAttempts = 10
+	policy.LockoutAfter *= 5
+	return policy
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Check rejects the attempt with 429 Too Many Requests while the account or the
+// IP address is backing off or locked
+func (g *LoginGuard) Check(email, ip string) error {
+	now := time.Now()
+	for _, check := range []struct {
+		limiter *throttle.Limiter
+		key     string
+	}{
+		{g.accounts, accountKey(email)},
+		{g.ips, ipKey(ip)},
+	} {
+		err := check.limiter.Check(check.key, now)
+		var blocked *throttle.BlockedError
+		if stdErrors.As(err, &blocked) {
+			return errors.TooManyRequests("Too many failed login attempts, try again later", nil).
+				WithDetails(map[string]interface{}{
+					"retryAfterSeconds": int(math.Ceil(blocked.RetryAfter.Seconds())),
+					"locked":            blocked.Locked,
+				})
+		}
+		if err != nil {
+			return errors.InternalServerError("Failed to check login attempts", err)
+		}
+	}
+	return nil
+}
+
+// Failure records a failed attempt. The user is nil when the email is unknown.
+func (g *LoginGuard) Failure(email, ip string, user *domain.User) {
+	now := time.Now()
+
+	locked, err := g.accounts.Fail(accountKey(email), now)
+	if err != nil {
+		log.Printf("Failed to record failed login for %s: %v", email, err)
+	} else if locked {
+		event := &domain.SecurityEvent{
+			Type:    domain.SecurityEventAccountLocked,
+			Email:   email,
+			IP:      ip,
+			Details: fmt.Sprintf("Locked for %s after repeated failed logins", g.accounts.Policy().LockoutDuration),
+		}
+		if user != nil {
+			event.UserID = &user.ID
+		}
+		g.record(event)
+	}
+
+	locked, err = g.ips.Fail(ipKey(ip), now)
+	if err != nil {
+		log.Printf("Failed to record failed login from %s: %v", ip, err)
+	} else if locked {
+		g.record(&domain.SecurityEvent{
+			Type:    domain.SecurityEventIPLocked,
+			Email:   email,
+			IP:      ip,
+			Details: fmt.Sprintf("Locked for %s after repeated failed logins", g.ips.Policy().LockoutDuration),
+		})
+	}
+}
+
+// Success forgets the failures of an account after a completed login
+func (g *LoginGuard) Success(email string) {
+	if err := g.accounts.Reset(accountKey(email)); err != nil {
+		log.Printf("Failed to reset login attempts for %s: %v", email, err)
+	}
+}
+
+// Unlock lifts the lockout of an account and/or an IP address
+func (g *LoginGuard) Unlock(actorID uint, req *dto.UnlockRequest) error {
+	if req.Email != "" {
+		if err := g.accounts.Reset(accountKey(req.Email)); err != nil {
+			return errors.InternalServerError("Failed to unlock account", err)
+		}
+		event := &domain.SecurityEvent{Type: domain.SecurityEventAccountUnlocked, Email: req.Email, ActorID: &actorID}
+		if user, err := g.userRepo.FindByEmail(req.Email); err == nil {
+			event.UserID = &user.ID
+		}
+		g.record(event)
+	}
+
+	if req.IP != "" {
+		if err := g.ips.Reset(ipKey(req.IP)); err != nil {
+			return errors.InternalServerError("Failed to unlock IP address", err)
+		}
+		g.record(&domain.SecurityEvent{Type: domain.SecurityEventIPUnlocked, IP: req.IP, ActorID: &actorID})
+	}
+	return nil
+}
+
+// GetEvents lists security events, newest first
+func (g *LoginGuard) GetEvents(eventType string, from, to *time.Time) ([]dto.SecurityEventResponseDTO, error) {
+	events, err := g.eventRepo.FindAll(eventType, from, to, 500)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve security events", err)
+	}
+
+	var dtos []dto.SecurityEventResponseDTO
+	for _, event := range events {
+		dtos = append(dtos, *g.eventDTOFactory.CreateFromEntity(&event))
+	}
+
+	return dtos, nil
+}
+
+func (g *LoginGuard) record(event *domain.SecurityEvent) {
+	if err := g.eventRepo.Create(event); err != nil {
+		log.Printf("Failed to record security event %s: %v", event.Type, err)
+	}
+}
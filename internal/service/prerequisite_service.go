@@ -0,0 +1,140 @@
+package service
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+)
+
+type PrerequisiteService struct {
+	prerequisiteRepo       *repository.CoursePrerequisiteRepository
+	courseRepo             *repository.CourseRepository
+	prerequisiteDTOFactory *factory.PrerequisiteResponseDTOFactory
+}
+
+func NewPrerequisiteService(prerequisiteRepo *repository.CoursePrerequisiteRepository, courseRepo *repository.CourseRepository) *PrerequisiteService {
+	return &PrerequisiteService{
+		prerequisiteRepo:       prerequisiteRepo,
+		courseRepo:             courseRepo,
+		prerequisiteDTOFactory: factory.NewPrerequisiteResponseDTOFactory(),
+	}
+}
+
+func (s *PrerequisiteService) GetByCourseID(courseID uint) ([]dto.PrerequisiteResponseDTO, error) {
+	// Verify course exists
+	if _, err := s.courseRepo.FindByID(courseID); err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	prerequisites, err := s.prerequisiteRepo.FindByCourseID(courseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve prerequisites", err)
+	}
+
+	var dtos []dto.PrerequisiteResponseDTO
+	for _, prerequisite := range prerequisites {
+		dtos = append(dtos, *s.prerequisiteDTOFactory.CreateFromEntity(&prerequisite))
+	}
+
+	return dtos, nil
+}
+
+func (s *PrerequisiteService) Add(courseID uint, req *dto.PrerequisiteCreateDTO) (*dto.PrerequisiteResponseDTO, error) {
+	if courseID == req.PrerequisiteID {
+		return nil, errors.BadRequest("A course cannot be a prerequisite of itself", nil)
+	}
+
+	// Verify both courses exist
+	if _, err := s.courseRepo.FindByID(courseID); err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	prerequisiteCourse, err := s.courseRepo.FindByID(req.PrerequisiteID)
+	if err != nil {
+		return nil, errors.NotFound("Prerequisite course not found", err)
+	}
+
+	prerequisite := &domain.CoursePrerequisite{
+		CourseID:       courseID,
+		PrerequisiteID: req.PrerequisiteID,
+		MinGrade:       req.MinGrade,
+	}
+
+	err = s.prerequisiteRepo.Transaction(func(tx *repository.Repository) error {
+		prerequisiteRepo := repository.NewCoursePrerequisiteRepository(tx)
+
+		// Serialize graph changes so the cycle check below stays valid until commit
+		if err := prerequisiteRepo.LockGraph(); err != nil {
+			return errors.InternalServerError("Failed to lock prerequisites", err)
+		}
+
+		if existing, _ := prerequisiteRepo.FindByCourseAndPrerequisite(courseID, req.PrerequisiteID); existing != nil {
+			return errors.Conflict("Prerequisite already exists for this course", nil)
+		}
+
+		edges, err := prerequisiteRepo.FindAll()
+		if err != nil {
+			return errors.InternalServerError("Failed to retrieve prerequisites", err)
+		}
+		if path := findPrerequisitePath(edges, req.PrerequisiteID, courseID); path != nil {
+			return errors.Conflict("Adding this prerequisite would create a cycle", nil).
+				WithDetails(map[string]interface{}{"cycle": append([]uint{courseID}, path...)})
+		}
+
+		if err := prerequisiteRepo.Create(prerequisite); err != nil {
+			return errors.InternalServerError("Failed to create prerequisite", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisite.Prerequisite = *prerequisiteCourse
+
+	return s.prerequisiteDTOFactory.CreateFromEntity(prerequisite), nil
+}
+
+func (s *PrerequisiteService) Remove(courseID, prerequisiteID uint) error {
+	// Check if prerequisite exists
+	if _, err := s.prerequisiteRepo.FindByCourseAndPrerequisite(courseID, prerequisiteID); err != nil {
+		return errors.NotFound("Prerequisite not found", err)
+	}
+
+	if err := s.prerequisiteRepo.DeleteByCourseAndPrerequisite(courseID, prerequisiteID); err != nil {
+		return errors.InternalServerError("Failed to delete prerequisite", err)
+	}
+
+	return nil
+}
+
+// findPrerequisitePath returns the chain of course IDs leading from `from` to `to`
+// by following prerequisite edges, or nil if `to` is unreachable. Adding the edge
+// to -> from creates a cycle exactly when such a path exists.
+func findPrerequisitePath(edges []domain.CoursePrerequisite, from, to uint) []uint {
+	graph := make(map[uint][]uint)
+	for _, edge := range edges {
+		graph[edge.CourseID] = append(graph[edge.CourseID], edge.PrerequisiteID)
+	}
+
+	visited := make(map[uint]bool)
+	var visit func(node uint) []uint
+	visit = func(node uint) []uint {
+		if node == to {
+			return []uint{node}
+		}
+		if visited[node] {
+			return nil
+		}
+		visited[node] = true
+		for _, next := range graph[node] {
+			if path := visit(next); path != nil {
+				return append([]uint{node}, path...)
+			}
+		}
+		return nil
+	}
+
+	return visit(from)
+}
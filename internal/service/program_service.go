@@ -0,0 +1,446 @@
+package service
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/grading"
+)
+
+// Statuses of the requirements in a degree audit
+const (
+	RequirementSatisfied  = "SATISFIED"
+	RequirementInProgress = "IN_PROGRESS"
+	RequirementMissing    = "MISSING"
+)
+
+// ProgramService manages degree programs and audits students' progress
+// towards the requirements of their program
+type ProgramService struct {
+	programRepo       *repository.ProgramRepository
+	courseRepo        *repository.CourseRepository
+	departmentRepo    *repository.DepartmentRepository
+	studentRepo       *repository.StudentRepository
+	enrollmentRepo    *repository.EnrollmentRepository
+	auditService      *AuditService
+	scale             *grading.Scale
+	programDTOFactory *factory.ProgramResponseDTOFactory
+	studentDTOFactory *factory.StudentDTOFactory
+}
+
+func NewProgramService(programRepo *repository.ProgramRepository, courseRepo *repository.CourseRepository, departmentRepo *repository.DepartmentRepository, studentRepo *repository.StudentRepository, enrollmentRepo *repository.EnrollmentRepository, auditService *AuditService, scale *grading.Scale) *ProgramService {
+	return &ProgramService{
+		programRepo:       programRepo,
+		courseRepo:        courseRepo,
+		departmentRepo:    departmentRepo,
+		studentRepo:       studentRepo,
+		enrollmentRepo:    enrollmentRepo,
+		auditService:      auditService,
+		scale:             scale,
+		programDTOFactory: factory.NewProgramResponseDTOFactory(),
+		studentDTOFactory: factory.NewStudentDTOFactory(),
+	}
+}
+
+func (s *ProgramService) Create(actor domain.Actor, req *dto.ProgramCreateDTO) (*dto.ProgramResponseDTO, error) {
+	if existing, _ := s.programRepo.FindByCodeAndCatalogYear(req.Code, req.CatalogYear); existing != nil {
+		return nil, errors.Conflict("Program already exists for this catalog year", nil)
+	}
+
+	if req.DepartmentID != nil {
+		if _, err := s.departmentRepo.FindByID(*req.DepartmentID); err != nil {
+			return nil, errors.NotFound("Department not found", err)
+		}
+	}
+
+	groups, err := s.requirementGroups(req.Groups)
+	if err != nil {
+		return nil, err
+	}
+
+	program := &domain.Program{
+		Code:         req.Code,
+		Name:         req.Name,
+		CatalogYear:  req.CatalogYear,
+		TotalCredits: req.TotalCredits,
+		DepartmentID: req.DepartmentID,
+		Groups:       groups,
+	}
+	var response *dto.ProgramResponseDTO
+	err = s.programRepo.Transaction(func(tx *repository.Repository) error {
+		programRepo := repository.NewProgramRepository(tx)
+		if err := programRepo.Create(program); err != nil {
+			return errors.InternalServerError("Failed to create program", err)
+		}
+
+		// Reload to include the course details of the requirements
+		created, err := programRepo.FindByID(program.ID)
+		if err != nil {
+			return errors.InternalServerError("Failed to retrieve program", err)
+		}
+
+		response = s.programDTOFactory.CreateFromEntity(created)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityProgram, created.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *ProgramService) GetAll() ([]dto.ProgramResponseDTO, error) {
+	programs, err := s.programRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve programs", err)
+	}
+
+	var dtos []dto.ProgramResponseDTO
+	for _, program := range programs {
+		dtos = append(dtos, *s.programDTOFactory.CreateFromEntity(&program))
+	}
+	return dtos, nil
+}
+
+func (s *ProgramService) GetByID(id uint) (*dto.ProgramResponseDTO, error) {
+	program, err := s.programRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Program not found", err)
+	}
+	return s.programDTOFactory.CreateFromEntity(program), nil
+}
+
+// Update changes a program. New requirements apply to the audits of every
+// student following the program.
+func (s *ProgramService) Update(actor domain.Actor, id uint, req *dto.ProgramUpdateDTO) (*dto.ProgramResponseDTO, error) {
+	program, err := s.programRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Program not found", err)
+	}
+	before := s.programDTOFactory.CreateFromEntity(program)
+
+	if req.Name != nil {
+		program.Name = *req.Name
+	}
+	if req.TotalCredits != nil {
+		program.TotalCredits = *req.TotalCredits
+	}
+	if req.DepartmentID != nil {
+		if _, err := s.departmentRepo.FindByID(*req.DepartmentID); err != nil {
+			return nil, errors.NotFound("Department not found", err)
+		}
+		program.DepartmentID = req.DepartmentID
+	}
+	var groups []domain.RequirementGroup
+	if req.Groups != nil {
+		if groups, err = s.requirementGroups(*req.Groups); err != nil {
+			return nil, err
+		}
+	}
+
+	var response *dto.ProgramResponseDTO
+	err = s.programRepo.Transaction(func(tx *repository.Repository) error {
+		programRepo := repository.NewProgramRepository(tx)
+		if err := programRepo.Update(program); err != nil {
+			return errors.InternalServerError("Failed to update program", err)
+		}
+		if req.Groups != nil {
+			if err := programRepo.ReplaceGroups(program.ID, groups); err != nil {
+				return errors.InternalServerError("Failed to update program requirements", err)
+			}
+		}
+
+		updated, err := programRepo.FindByID(program.ID)
+		if err != nil {
+			return errors.InternalServerError("Failed to retrieve program", err)
+		}
+
+		response = s.programDTOFactory.CreateFromEntity(updated)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityProgram, updated.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete removes a program no student follows
+func (s *ProgramService) Delete(actor domain.Actor, id uint) error {
+	program, err := s.programRepo.FindByID(id)
+	if err != nil {
+		return errors.NotFound("Program not found", err)
+	}
+
+	inUse, err := s.programRepo.IsInUse(program.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to check program usage", err)
+	}
+	if inUse {
+		return errors.Conflict("Program is followed by students", nil)
+	}
+
+	return s.programRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewProgramRepository(tx).Delete(program.ID); err != nil {
+			return errors.InternalServerError("Failed to delete program", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityProgram, program.ID, s.programDTOFactory.CreateFromEntity(program), nil)
+	})
+}
+
+// AssignStudent links a student to a program and with it to the catalog year
+// whose requirements the student follows
+func (s *ProgramService) AssignStudent(actor domain.Actor, studentID uint, req *dto.StudentProgramDTO) (*dto.StudentResponseDTO, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, errors.NotFound("Student not found", err)
+	}
+	before := s.studentDTOFactory.CreateFromEntity(student)
+
+	if req.ProgramID != nil {
+		if _, err := s.programRepo.FindByID(*req.ProgramID); err != nil {
+			return nil, errors.NotFound("Program not found", err)
+		}
+	}
+
+	var response *dto.StudentResponseDTO
+	err = s.studentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewStudentRepository(tx).UpdateProgram(student.ID, req.ProgramID); err != nil {
+			return errors.InternalServerError("Failed to update student program", err)
+		}
+		student.ProgramID = req.ProgramID
+
+		response = s.studentDTOFactory.CreateFromEntity(student)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityStudent, student.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetDegreeAudit evaluates the student's enrollments against the requirements
+// of their program. The credits of a course count towards the first group
+// listing it only, and every passed course counts towards the total credits.
+func (s *ProgramService) GetDegreeAudit(studentID uint) (*dto.DegreeAuditDTO, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, errors.NotFound("Student not found", err)
+	}
+	if student.ProgramID == nil {
+		return nil, errors.BadRequest("Student is not linked to a program", nil)
+	}
+
+	program, err := s.programRepo.FindByID(*student.ProgramID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve program", err)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByStudentID(student.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	audit := &dto.DegreeAuditDTO{
+		StudentID:       student.ID,
+		StudentNumber:   student.StudentID,
+		StudentName:     student.User.FirstName + " " + student.User.LastName,
+		ProgramID:       program.ID,
+		ProgramCode:     program.Code,
+		ProgramName:     program.Name,
+		CatalogYear:     program.CatalogYear,
+		RequiredCredits: program.TotalCredits,
+		Groups:          []dto.DegreeAuditGroupDTO{},
+	}
+
+	attempts := bestAttempts(s.scale, enrollments)
+	for _, attempt := range attempts {
+		switch attempt.status {
+		case RequirementSatisfied:
+			audit.EarnedCredits += attempt.enrollment.Course.Credits
+		case RequirementInProgress:
+			audit.InProgressCredits += attempt.enrollment.Course.Credits
+		}
+	}
+	audit.CreditsStatus = creditsStatus(audit.EarnedCredits, audit.InProgressCredits, audit.RequiredCredits)
+
+	statuses := []string{audit.CreditsStatus}
+	counted := make(map[uint]bool)
+	for _, group := range program.Groups {
+		groupDTO := auditGroup(&group, attempts, counted)
+		audit.Groups = append(audit.Groups, groupDTO)
+		statuses = append(statuses, groupDTO.Status)
+	}
+	audit.Status = overallStatus(statuses)
+
+	return audit, nil
+}
+
+// requirementGroups validates the requested groups and converts them, keeping
+// their order
+func (s *ProgramService) requirementGroups(requested []dto.RequirementGroupDTO) ([]domain.RequirementGroup, error) {
+	var groups []domain.RequirementGroup
+	for i, req := range requested {
+		group := domain.RequirementGroup{
+			Name:     req.Name,
+			Type:     domain.RequirementType(req.Type),
+			Position: i,
+		}
+
+		offered := 0
+		seen := make(map[uint]bool)
+		for _, courseID := range req.CourseIDs {
+			if seen[courseID] {
+				continue
+			}
+			seen[courseID] = true
+			course, err := s.courseRepo.FindByID(courseID)
+			if err != nil {
+				return nil, errors.NotFound("Course not found", err).
+					WithDetails(map[string]interface{}{"courseId": courseID})
+			}
+			offered += course.Credits
+			group.Courses = append(group.Courses, domain.RequirementCourse{CourseID: courseID})
+		}
+
+		if group.Type == domain.RequirementTypeElective {
+			if req.MinCredits == 0 {
+				return nil, errors.BadRequest("Elective groups need minCredits", nil).
+					WithDetails(map[string]interface{}{"group": req.Name})
+			}
+			if req.MinCredits > offered {
+				return nil, errors.BadRequest("Elective group asks for more credits than its courses offer", nil).
+					WithDetails(map[string]interface{}{"group": req.Name, "minCredits": req.MinCredits, "offeredCredits": offered})
+			}
+			group.MinCredits = req.MinCredits
+		}
+
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// courseAttempt is the enrollment of a student that counts for a course
+type courseAttempt struct {
+	enrollment *domain.Enrollment
+	status     string
+}
+
+// bestAttempts picks for every course the enrollment that brings the student
+// furthest: a pass with the highest grade, else a current enrollment. Failed,
+// dropped and withdrawn enrollments do not count.
+func bestAttempts(scale *grading.Scale, enrollments []domain.Enrollment) map[uint]courseAttempt {
+	attempts := make(map[uint]courseAttempt)
+	for i := range enrollments {
+		enrollment := &enrollments[i]
+
+		var status string
+		switch {
+		case enrollment.Status == domain.EnrollmentStatusCompleted && enrollment.Grade != nil:
+			if !passedCourse(scale, enrollment) {
+				continue
+			}
+			status = RequirementSatisfied
+		case enrollment.Status == domain.EnrollmentStatusEnrolled, enrollment.Status == domain.EnrollmentStatusCompleted:
+			status = RequirementInProgress
+		default:
+			continue
+		}
+
+		best, ok := attempts[enrollment.CourseID]
+		switch {
+		case !ok, best.status == RequirementInProgress && status == RequirementSatisfied:
+			attempts[enrollment.CourseID] = courseAttempt{enrollment: enrollment, status: status}
+		case best.status == RequirementSatisfied && status == RequirementSatisfied && *enrollment.Grade > *best.enrollment.Grade:
+			attempts[enrollment.CourseID] = courseAttempt{enrollment: enrollment, status: status}
+		}
+	}
+	return attempts
+}
+
+// auditGroup evaluates one requirement group. Courses whose credits an earlier
+// group counted still satisfy core requirements but add no credits.
+func auditGroup(group *domain.RequirementGroup, attempts map[uint]courseAttempt, counted map[uint]bool) dto.DegreeAuditGroupDTO {
+	groupDTO := dto.DegreeAuditGroupDTO{
+		GroupID: group.ID,
+		Name:    group.Name,
+		Type:    string(group.Type),
+		Courses: []dto.DegreeAuditCourseDTO{},
+	}
+
+	missing, inProgress := 0, 0
+	for _, requirement := range group.Courses {
+		course := dto.DegreeAuditCourseDTO{
+			CourseID:   requirement.CourseID,
+			CourseCode: requirement.Course.Code,
+			CourseName: requirement.Course.Name,
+			Credits:    requirement.Course.Credits,
+			Status:     RequirementMissing,
+		}
+
+		if attempt, ok := attempts[requirement.CourseID]; ok {
+			course.Status = attempt.status
+			course.Grade = attempt.enrollment.Grade
+			if attempt.enrollment.Course.Term != nil {
+				course.TermName = attempt.enrollment.Course.Term.Name
+			}
+			if !counted[requirement.CourseID] {
+				counted[requirement.CourseID] = true
+				if attempt.status == RequirementSatisfied {
+					groupDTO.EarnedCredits += course.Credits
+				} else {
+					groupDTO.InProgressCredits += course.Credits
+				}
+			}
+		}
+
+		switch course.Status {
+		case RequirementMissing:
+			missing++
+		case RequirementInProgress:
+			inProgress++
+		}
+		groupDTO.Courses = append(groupDTO.Courses, course)
+	}
+
+	switch group.Type {
+	case domain.RequirementTypeElective:
+		groupDTO.RequiredCredits = group.MinCredits
+		groupDTO.Status = creditsStatus(groupDTO.EarnedCredits, groupDTO.InProgressCredits, group.MinCredits)
+	default:
+		switch {
+		case missing > 0:
+			groupDTO.Status = RequirementMissing
+		case inProgress > 0:
+			groupDTO.Status = RequirementInProgress
+		default:
+			groupDTO.Status = RequirementSatisfied
+		}
+	}
+	return groupDTO
+}
+
+func creditsStatus(earned, inProgress, required int) string {
+	switch {
+	case earned >= required:
+		return RequirementSatisfied
+	case earned+inProgress >= required:
+		return RequirementInProgress
+	default:
+		return RequirementMissing
+	}
+}
+
+// overallStatus is the least advanced of the statuses
+func overallStatus(statuses []string) string {
+	result := RequirementSatisfied
+	for _, status := range statuses {
+		if status == RequirementMissing {
+			return RequirementMissing
+		}
+		if status == RequirementInProgress {
+			result = RequirementInProgress
+		}
+	}
+	return result
+}
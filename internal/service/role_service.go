@@ -0,0 +1,350 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+)
+
+// permissionCacheTTL bounds how long other instances may serve a stale
+// permission set after a role was changed
+const permissionCacheTTL = time.Minute
+
+var roleNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+type cachedPermissions struct {
+	permissions []domain.Permission
+	loadedAt    time.Time
+}
+
+// RoleService manages roles and their permission sets, and resolves the
+// permissions of a role for every authenticated request
+type RoleService struct {
+	roleRepo       *repository.RoleRepository
+	userRepo       *repository.UserRepository
+	auditService   *AuditService
+	roleDTOFactory *factory.RoleResponseDTOFactory
+
+	mu    sync.Mutex
+	cache map[domain.Role]cachedPermissions
+}
+
+func NewRoleService(roleRepo *repository.RoleRepository, userRepo *repository.UserRepository, auditService *AuditService) *RoleService {
+	return &RoleService{
+		roleRepo:       roleRepo,
+		userRepo:       userRepo,
+		auditService:   auditService,
+		roleDTOFactory: factory.NewRoleResponseDTOFactory(),
+		cache:          make(map[domain.Role]cachedPermissions),
+	}
+}
+
+// PermissionsFor returns the permissions granted to a role. ADMIN always holds
+// every permission; unknown roles hold none.
+func (s *RoleService) PermissionsFor(role domain.Role) ([]domain.Permission, error) {
+	if role == domain.RoleAdmin {
+		return domain.AllPermissions, nil
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[role]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < permissionCacheTTL {
+		return cached.permissions, nil
+	}
+
+	permissions, err := s.roleRepo.FindPermissions(role)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[role] = cachedPermissions{permissions: permissions, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return permissions, nil
+}
+
+func (s *RoleService) invalidate(role domain.Role) {
+	s.mu.Lock()
+	delete(s.cache, role)
+	s.mu.Unlock()
+}
+
+// GetPermissions lists every permission that can be granted
+func (s *RoleService) GetPermissions() []string {
+	names := make([]string, 0, len(domain.AllPermissions))
+	for _, permission := range domain.AllPermissions {
+		names = append(names, string(permission))
+	}
+	return names
+}
+
+// GetOwnPermissions lists the effective permissions of the subject
+func (s *RoleService) GetOwnPermissions(subject policy.Subject) *dto.PermissionsResponseDTO {
+	names := make([]string, 0, len(subject.Permissions))
+	for permission := range subject.Permissions {
+		names = append(names, string(permission))
+	}
+	sort.Strings(names)
+	return &dto.PermissionsResponseDTO{Role: string(subject.Role), Permissions: names}
+}
+
+func (s *RoleService) GetAll() ([]dto.RoleResponseDTO, error) {
+	roles, err := s.roleRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve roles", err)
+	}
+
+	var dtos []dto.RoleResponseDTO
+	for _, role := range roles {
+		dtos = append(dtos, *s.roleDTOFactory.CreateFromEntity(&role, rolePermissions(&role)))
+	}
+
+	return dtos, nil
+}
+
+func (s *RoleService) GetByName(name string) (*dto.RoleResponseDTO, error) {
+	role, err := s.roleRepo.FindByName(domain.Role(strings.ToUpper(name)))
+	if err != nil {
+		return nil, errors.NotFound("Role not found", err)
+	}
+	return s.roleDTOFactory.CreateFromEntity(role, rolePermissions(role)), nil
+}
+
+// Create defines a custom role. Callers cannot grant permissions they do not hold.
+func (s *RoleService) Create(subject policy.Subject, actor domain.Actor, req *dto.RoleCreateDTO) (*dto.RoleResponseDTO, error) {
+	name := domain.Role(strings.ToUpper(req.Name))
+	if !roleNamePattern.MatchString(string(name)) {
+		return nil, errors.BadRequest("Role name may only contain letters, digits and underscores", nil)
+	}
+	if existing, _ := s.roleRepo.FindByName(name); existing != nil {
+		return nil, errors.Conflict("Role already exists", nil)
+	}
+
+	permissions, err := s.parsePermissions(subject, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &domain.RoleDefinition{Name: name, Description: req.Description}
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, domain.RolePermission{RoleName: name, Permission: permission})
+	}
+	response := s.roleDTOFactory.CreateFromEntity(role, permissions)
+	err = s.roleRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoleRepository(tx).Create(role); err != nil {
+			return errors.InternalServerError("Failed to create role", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityRole, 0, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate(name)
+
+	return response, nil
+}
+
+// Update replaces the permission set of a role. ADMIN cannot be changed, and
+// callers can only change roles whose permissions they all hold.
+func (s *RoleService) Update(subject policy.Subject, actor domain.Actor, name string, req *dto.RoleUpdateDTO) (*dto.RoleResponseDTO, error) {
+	role, err := s.roleRepo.FindByName(domain.Role(strings.ToUpper(name)))
+	if err != nil {
+		return nil, errors.NotFound("Role not found", err)
+	}
+	if role.Name == domain.RoleAdmin {
+		return nil, errors.Forbidden("The ADMIN role always holds every permission", nil)
+	}
+	if missing := missingPermissions(subject, rolePermissions(role)); len(missing) > 0 {
+		return nil, errors.Forbidden("Cannot change a role with permissions you do not hold", nil).
+			WithDetails(map[string]interface{}{"missing": missing})
+	}
+
+	permissions, err := s.parsePermissions(subject, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	before := s.roleDTOFactory.CreateFromEntity(role, rolePermissions(role))
+	role.Description = req.Description
+	role.Permissions = nil
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, domain.RolePermission{RoleName: role.Name, Permission: permission})
+	}
+
+	response := s.roleDTOFactory.CreateFromEntity(role, permissions)
+	err = s.roleRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoleRepository(tx).Update(role); err != nil {
+			return errors.InternalServerError("Failed to update role", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityRole, 0, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate(role.Name)
+
+	return response, nil
+}
+
+// Delete removes a custom role that is not assigned to any user. Callers can
+// only delete roles whose permissions they all hold.
+func (s *RoleService) Delete(subject policy.Subject, actor domain.Actor, name string) error {
+	role, err := s.roleRepo.FindByName(domain.Role(strings.ToUpper(name)))
+	if err != nil {
+		return errors.NotFound("Role not found", err)
+	}
+	if role.BuiltIn {
+		return errors.Forbidden("Built-in roles cannot be deleted", nil)
+	}
+	if missing := missingPermissions(subject, rolePermissions(role)); len(missing) > 0 {
+		return errors.Forbidden("Cannot delete a role with permissions you do not hold", nil).
+			WithDetails(map[string]interface{}{"missing": missing})
+	}
+
+	count, err := s.userRepo.CountByRole(role.Name)
+	if err != nil {
+		return errors.InternalServerError("Failed to check role assignments", err)
+	}
+	if count > 0 {
+		return errors.Conflict("Role is still assigned to users", nil).
+			WithDetails(map[string]interface{}{"users": count})
+	}
+
+	before := s.roleDTOFactory.CreateFromEntity(role, rolePermissions(role))
+	err = s.roleRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoleRepository(tx).Delete(role.Name); err != nil {
+			return errors.InternalServerError("Failed to delete role", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityRole, 0, before, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.invalidate(role.Name)
+	return nil
+}
+
+// AssignRole changes the role of a user and signs them out, since access tokens
+// carry the role. Callers can neither assign roles more powerful than their own
+// nor change the role of users more powerful than themselves, and the last
+// admin cannot be demoted.
+func (s *RoleService) AssignRole(subject policy.Subject, actor domain.Actor, userID uint, req *dto.UserRoleUpdateDTO) (*dto.UserDTO, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.NotFound("User not found", err)
+	}
+
+	role, err := s.roleRepo.FindByName(domain.Role(strings.ToUpper(req.Role)))
+	if err != nil {
+		return nil, errors.BadRequest("Role does not exist", err)
+	}
+	if role.Name == user.Role {
+		return factory.NewUserDTOFactory().CreateFromEntity(user), nil
+	}
+
+	current, err := s.PermissionsFor(user.Role)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to load role permissions", err)
+	}
+	if missing := missingPermissions(subject, current); len(missing) > 0 {
+		return nil, errors.Forbidden("Cannot change the role of a user with permissions you do not hold", nil).
+			WithDetails(map[string]interface{}{"missing": missing})
+	}
+
+	permissions, err := s.PermissionsFor(role.Name)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to load role permissions", err)
+	}
+	if missing := missingPermissions(subject, permissions); len(missing) > 0 {
+		return nil, errors.Forbidden("Cannot assign a role with permissions you do not hold", nil).
+			WithDetails(map[string]interface{}{"missing": missing})
+	}
+
+	userDTOFactory := factory.NewUserDTOFactory()
+	before := userDTOFactory.CreateFromEntity(user)
+	var response *dto.UserDTO
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		userRepo := repository.NewUserRepository(tx)
+		if user.Role == domain.RoleAdmin {
+			// Locks the admin rows, so concurrent demotions cannot both pass
+			count, err := userRepo.CountByRoleForUpdate(domain.RoleAdmin)
+			if err != nil {
+				return errors.InternalServerError("Failed to count admins", err)
+			}
+			if count <= 1 {
+				return errors.Conflict("Cannot remove the last admin", nil)
+			}
+		}
+		if err := userRepo.UpdateRole(user.ID, role.Name); err != nil {
+			return errors.InternalServerError("Failed to assign role", err)
+		}
+		if err := repository.NewSessionRepository(tx).RevokeAllByUserID(user.ID); err != nil {
+			return errors.InternalServerError("Failed to revoke sessions", err)
+		}
+		user.Role = role.Name
+		response = userDTOFactory.CreateFromEntity(user)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityUser, user.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// parsePermissions validates requested permission names and removes duplicates
+func (s *RoleService) parsePermissions(subject policy.Subject, names []string) ([]domain.Permission, error) {
+	seen := make(map[domain.Permission]bool)
+	var permissions []domain.Permission
+	var unknown []string
+	for _, name := range names {
+		permission := domain.Permission(strings.ToLower(strings.TrimSpace(name)))
+		if !domain.IsKnownPermission(permission) {
+			unknown = append(unknown, name)
+			continue
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, errors.BadRequest("Unknown permissions", nil).
+			WithDetails(map[string]interface{}{"unknown": unknown})
+	}
+	if missing := missingPermissions(subject, permissions); len(missing) > 0 {
+		return nil, errors.Forbidden("Cannot grant permissions you do not hold", nil).
+			WithDetails(map[string]interface{}{"missing": missing})
+	}
+	return permissions, nil
+}
+
+func missingPermissions(subject policy.Subject, permissions []domain.Permission) []domain.Permission {
+	var missing []domain.Permission
+	for _, permission := range permissions {
+		if !subject.Can(permission) {
+			missing = append(missing, permission)
+		}
+	}
+	return missing
+}
+
+func rolePermissions(role *domain.RoleDefinition) []domain.Permission {
+	if role.Name == domain.RoleAdmin {
+		return domain.AllPermissions
+	}
+	permissions := make([]domain.Permission, 0, len(role.Permissions))
+	for _, rp := range role.Permissions {
+		permissions = append(permissions, rp.Permission)
+	}
+	return permissions
+}
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+)
+
+// RoomService manages rooms and their one-off bookings, rejecting bookings
+// that clash with course meetings or other bookings of the room
+type RoomService struct {
+	roomRepo          *repository.RoomRepository
+	meetingRepo       *repository.MeetingRepository
+	courseRepo        *repository.CourseRepository
+	auditService      *AuditService
+	roomDTOFactory    *factory.RoomResponseDTOFactory
+	bookingDTOFactory *factory.RoomBookingResponseDTOFactory
+}
+
+func NewRoomService(roomRepo *repository.RoomRepository, meetingRepo *repository.MeetingRepository, courseRepo *repository.CourseRepository, auditService *AuditService) *RoomService {
+	return &RoomService{
+		roomRepo:          roomRepo,
+		meetingRepo:       meetingRepo,
+		courseRepo:        courseRepo,
+		auditService:      auditService,
+		roomDTOFactory:    factory.NewRoomResponseDTOFactory(),
+		bookingDTOFactory: factory.NewRoomBookingResponseDTOFactory(),
+	}
+}
+
+func (s *RoomService) Create(actor domain.Actor, req *dto.RoomCreateDTO) (*dto.RoomResponseDTO, error) {
+	if existing, _ := s.roomRepo.FindByBuildingAndNumber(req.Building, req.Number); existing != nil {
+		return nil, errors.Conflict("Room already exists", nil)
+	}
+
+	room := &domain.Room{
+		Building: req.Building,
+		Number:   req.Number,
+		Capacity: req.Capacity,
+		Features: roomFeatures(req.Features),
+	}
+	var response *dto.RoomResponseDTO
+	err := s.roomRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoomRepository(tx).Create(room); err != nil {
+			return errors.InternalServerError("Failed to create room", err)
+		}
+		response = s.roomDTOFactory.CreateFromEntity(room)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityRoom, room.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *RoomService) GetAll() ([]dto.RoomResponseDTO, error) {
+	rooms, err := s.roomRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve rooms", err)
+	}
+
+	var dtos []dto.RoomResponseDTO
+	for _, room := range rooms {
+		dtos = append(dtos, *s.roomDTOFactory.CreateFromEntity(&room))
+	}
+	return dtos, nil
+}
+
+func (s *RoomService) GetByID(id uint) (*dto.RoomResponseDTO, error) {
+	room, err := s.roomRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Room not found", err)
+	}
+	return s.roomDTOFactory.CreateFromEntity(room), nil
+}
+
+// Update changes a room. The capacity cannot drop below the seat limit of a
+// course that has not ended yet and meets in the room.
+func (s *RoomService) Update(actor domain.Actor, id uint, req *dto.RoomUpdateDTO) (*dto.RoomResponseDTO, error) {
+	room, err := s.roomRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Room not found", err)
+	}
+	before := s.roomDTOFactory.CreateFromEntity(room)
+
+	if req.Building != nil {
+		room.Building = *req.Building
+	}
+	if req.Number != nil {
+		room.Number = *req.Number
+	}
+	if existing, _ := s.roomRepo.FindByBuildingAndNumber(room.Building, room.Number); existing != nil && existing.ID != room.ID {
+		return nil, errors.Conflict("Room already exists", nil)
+	}
+	if req.Capacity != nil {
+		meetings, err := s.meetingRepo.FindByRoomID(room.ID)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to retrieve meetings", err)
+		}
+		for _, meeting := range meetings {
+			if inTimetable(&meeting.Course, 0) && meeting.Course.Capacity > *req.Capacity {
+				return nil, errors.Conflict("Room is too small for a course that meets in it", nil).
+					WithDetails(map[string]interface{}{"courseId": meeting.CourseID, "capacity": meeting.Course.Capacity})
+			}
+		}
+		room.Capacity = *req.Capacity
+	}
+	if req.Features != nil {
+		room.Features = roomFeatures(*req.Features)
+		for i := range room.Features {
+			room.Features[i].RoomID = room.ID
+		}
+	}
+
+	var response *dto.RoomResponseDTO
+	err = s.roomRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoomRepository(tx).Update(room); err != nil {
+			return errors.InternalServerError("Failed to update room", err)
+		}
+		response = s.roomDTOFactory.CreateFromEntity(room)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityRoom, room.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete removes a room that no course meeting or booking uses
+func (s *RoomService) Delete(actor domain.Actor, id uint) error {
+	room, err := s.roomRepo.FindByID(id)
+	if err != nil {
+		return errors.NotFound("Room not found", err)
+	}
+
+	inUse, err := s.roomRepo.IsInUse(room.ID)
+	if err != nil {
+		return errors.InternalServerError("Failed to check room usage", err)
+	}
+	if inUse {
+		return errors.Conflict("Room is used by course meetings or bookings", nil)
+	}
+
+	return s.roomRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoomRepository(tx).Delete(room.ID); err != nil {
+			return errors.InternalServerError("Failed to delete room", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityRoom, room.ID, s.roomDTOFactory.CreateFromEntity(room), nil)
+	})
+}
+
+// FindAvailable lists the rooms with enough seats and the requested features
+// that are free on the date between the start and end times
+func (s *RoomService) FindAvailable(query *dto.RoomAvailabilityQueryDTO) ([]dto.RoomResponseDTO, error) {
+	slot := schedule.Slot{Days: schedule.DayOf(query.Date.Weekday())}
+	var err error
+	if slot.Start, err = schedule.ParseClock(query.Start); err != nil {
+		return nil, errors.BadRequest(err.Error(), err)
+	}
+	if slot.End, err = schedule.ParseClock(query.End); err != nil {
+		return nil, errors.BadRequest(err.Error(), err)
+	}
+	if err := slot.Validate(); err != nil {
+		return nil, errors.BadRequest(err.Error(), err)
+	}
+
+	day := time.Date(query.Date.Year(), query.Date.Month(), query.Date.Day(), 0, 0, 0, 0, time.Local)
+	start := day.Add(time.Duration(slot.Start) * time.Minute)
+	end := day.Add(time.Duration(slot.End) * time.Minute)
+
+	rooms, err := s.roomRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve rooms", err)
+	}
+
+	var features []string
+	for _, feature := range roomFeatures(query.Features) {
+		features = append(features, feature.Feature)
+	}
+
+	var candidates []domain.Room
+	var ids []uint
+	for _, room := range rooms {
+		if room.Capacity >= query.Capacity && room.HasFeatures(features) {
+			candidates = append(candidates, room)
+			ids = append(ids, room.ID)
+		}
+	}
+
+	occupancy, err := loadRoomOccupancy(s.roomRepo, s.meetingRepo, ids, start, end)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve room usage", err)
+	}
+
+	dtos := []dto.RoomResponseDTO{}
+	for _, room := range candidates {
+		if len(occupancy.intervalConflicts(room.ID, start, end)) == 0 {
+			dtos = append(dtos, *s.roomDTOFactory.CreateFromEntity(&room))
+		}
+	}
+	return dtos, nil
+}
+
+// CreateBooking reserves a room for an exam or event. The room is locked while
+// the booking is checked against its course meetings and other bookings.
+func (s *RoomService) CreateBooking(actor domain.Actor, roomID uint, req *dto.RoomBookingCreateDTO) (*dto.RoomBookingResponseDTO, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, errors.BadRequest("Booking must end after it starts", nil)
+	}
+
+	room, err := s.roomRepo.FindByID(roomID)
+	if err != nil {
+		return nil, errors.NotFound("Room not found", err)
+	}
+	if req.CourseID != nil {
+		course, err := s.courseRepo.FindByID(*req.CourseID)
+		if err != nil {
+			return nil, errors.NotFound("Course not found", err)
+		}
+		if course.Capacity > room.Capacity {
+			return nil, errors.BadRequest("Room is too small for the course", nil).
+				WithDetails(map[string]interface{}{"roomCapacity": room.Capacity, "courseCapacity": course.Capacity})
+		}
+	}
+
+	booking := &domain.RoomBooking{
+		RoomID:   room.ID,
+		Room:     *room,
+		Title:    req.Title,
+		Purpose:  domain.BookingPurpose(req.Purpose),
+		CourseID: req.CourseID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		BookedBy: actor.UserID,
+	}
+
+	var response *dto.RoomBookingResponseDTO
+	err = s.roomRepo.Transaction(func(tx *repository.Repository) error {
+		roomRepo := repository.NewRoomRepository(tx)
+		if _, err := roomRepo.FindByIDForUpdate(room.ID); err != nil {
+			return errors.NotFound("Room not found", err)
+		}
+
+		occupancy, err := loadRoomOccupancy(roomRepo, repository.NewMeetingRepository(tx), []uint{room.ID}, booking.StartsAt, booking.EndsAt)
+		if err != nil {
+			return errors.InternalServerError("Failed to retrieve room usage", err)
+		}
+		if conflicts := occupancy.intervalConflicts(room.ID, booking.StartsAt, booking.EndsAt); len(conflicts) > 0 {
+			return errors.Conflict("Room is already booked at that time", nil).
+				WithDetails(map[string]interface{}{"conflicts": conflicts})
+		}
+
+		if err := roomRepo.CreateBooking(booking); err != nil {
+			return errors.InternalServerError("Failed to create booking", err)
+		}
+		response = s.bookingDTOFactory.CreateFromEntity(booking)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityRoomBooking, booking.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetBookings lists the bookings of a room that overlap with [from, to)
+func (s *RoomService) GetBookings(roomID uint, from, to time.Time) ([]dto.RoomBookingResponseDTO, error) {
+	if _, err := s.roomRepo.FindByID(roomID); err != nil {
+		return nil, errors.NotFound("Room not found", err)
+	}
+
+	bookings, err := s.roomRepo.FindBookings([]uint{roomID}, from, to)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve bookings", err)
+	}
+
+	var dtos []dto.RoomBookingResponseDTO
+	for _, booking := range bookings {
+		dtos = append(dtos, *s.bookingDTOFactory.CreateFromEntity(&booking))
+	}
+	return dtos, nil
+}
+
+func (s *RoomService) CancelBooking(subject policy.Subject, actor domain.Actor, id uint) error {
+	booking, err := s.roomRepo.FindBookingByID(id)
+	if err != nil {
+		return errors.NotFound("Booking not found", err)
+	}
+	if !policy.CanCancelBooking(subject, booking) {
+		return errors.Forbidden("You can only cancel your own bookings", nil)
+	}
+
+	return s.roomRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewRoomRepository(tx).DeleteBooking(booking.ID); err != nil {
+			return errors.InternalServerError("Failed to cancel booking", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityRoomBooking, booking.ID, s.bookingDTOFactory.CreateFromEntity(booking), nil)
+	})
+}
+
+// roomOccupancy holds the course meetings and bookings of a set of rooms around
+// a time window. Clock times of meetings are compared in local time.
+type roomOccupancy struct {
+	meetings []domain.CourseMeeting
+	bookings []domain.RoomBooking
+}
+
+func loadRoomOccupancy(roomRepo *repository.RoomRepository, meetingRepo *repository.MeetingRepository, roomIDs []uint, from, to time.Time) (*roomOccupancy, error) {
+	// Course dates are whole days, so meetings are loaded a day either side
+	meetings, err := meetingRepo.FindByRoomIDs(roomIDs, from.AddDate(0, 0, -1), to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := roomRepo.FindBookings(roomIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &roomOccupancy{meetings: meetings, bookings: bookings}, nil
+}
+
+// recurringConflicts returns what occupies the room during a weekly meeting,
+// ignoring the meetings of the course being scheduled
+func (o *roomOccupancy) recurringConflicts(roomID uint, slot schedule.Recurring, excludeCourseID uint) []dto.RoomConflictDTO {
+	var conflicts []dto.RoomConflictDTO
+	for _, meeting := range o.meetings {
+		if *meeting.RoomID != roomID || meeting.CourseID == excludeCourseID {
+			continue
+		}
+		other := meetingRecurring(&meeting)
+		if slot.Overlaps(other) {
+			conflicts = append(conflicts, meetingConflict(&meeting, slot.Days&other.Days))
+		}
+	}
+	for _, booking := range o.bookings {
+		if booking.RoomID == roomID && slot.OverlapsInterval(booking.StartsAt.In(time.Local), booking.EndsAt.In(time.Local)) {
+			conflicts = append(conflicts, bookingConflict(&booking))
+		}
+	}
+	return conflicts
+}
+
+// intervalConflicts returns what occupies the room during [start, end)
+func (o *roomOccupancy) intervalConflicts(roomID uint, start, end time.Time) []dto.RoomConflictDTO {
+	start, end = start.In(time.Local), end.In(time.Local)
+	var conflicts []dto.RoomConflictDTO
+	for _, meeting := range o.meetings {
+		if *meeting.RoomID == roomID && meetingRecurring(&meeting).OverlapsInterval(start, end) {
+			conflicts = append(conflicts, meetingConflict(&meeting, schedule.Days(meeting.Days)))
+		}
+	}
+	for _, booking := range o.bookings {
+		if booking.RoomID == roomID && booking.StartsAt.Before(end) && start.Before(booking.EndsAt) {
+			conflicts = append(conflicts, bookingConflict(&booking))
+		}
+	}
+	return conflicts
+}
+
+func meetingRecurring(meeting *domain.CourseMeeting) schedule.Recurring {
+	return schedule.Recurring{Slot: meetingSlot(meeting), From: meeting.Course.StartDate, To: meeting.Course.EndDate}
+}
+
+func meetingConflict(meeting *domain.CourseMeeting, days schedule.Days) dto.RoomConflictDTO {
+	slot := meetingSlot(meeting)
+	slot.Days = days
+	return dto.RoomConflictDTO{
+		RoomID:    *meeting.RoomID,
+		RoomLabel: meeting.RoomLabel(),
+		CourseID:  meeting.CourseID,
+		Title:     meeting.Course.Code + " " + meeting.Course.Name,
+		When:      slot.String(),
+	}
+}
+
+func bookingConflict(booking *domain.RoomBooking) dto.RoomConflictDTO {
+	return dto.RoomConflictDTO{
+		RoomID:    booking.RoomID,
+		RoomLabel: booking.Room.Label(),
+		BookingID: booking.ID,
+		Title:     booking.Title,
+		When:      fmt.Sprintf("%s - %s", booking.StartsAt.Format(time.RFC3339), booking.EndsAt.Format(time.RFC3339)),
+	}
+}
+
+// roomFeatures normalizes feature names, dropping duplicates
+func roomFeatures(names []string) []domain.RoomFeature {
+	seen := make(map[string]bool)
+	var features []domain.RoomFeature
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		features = append(features, domain.RoomFeature{Feature: name})
+	}
+	sort.Slice(features, func(i, j int) bool { return features[i].Feature < features[j].Feature })
+	return features
+}
@@ -0,0 +1,685 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+	"github.com/Tretorhate/university-management-system/pkg/timetabling"
+)
+
+// Defaults of the timetable generator when a job does not set them
+var defaultScheduleDays = []string{"MON", "TUE", "WED", "THU", "FRI"}
+
+const (
+	defaultScheduleDayStart = "08:00"
+	defaultScheduleDayEnd   = "18:00"
+	defaultScheduleStep     = 30
+	defaultMeetingsPerWeek  = 2
+	defaultMeetingDuration  = 90
+)
+
+// SchedulerService generates term timetables in the background. A job proposes
+// meeting times and rooms for the courses of a term; the proposal can be
+// previewed and is only written to the course meetings when applied.
+type SchedulerService struct {
+	jobRepo           *repository.ScheduleJobRepository
+	termRepo          *repository.TermRepository
+	courseRepo        *repository.CourseRepository
+	meetingRepo       *repository.MeetingRepository
+	roomRepo          *repository.RoomRepository
+	preferenceRepo    *repository.TeacherPreferenceRepository
+	teacherRepo       *repository.TeacherRepository
+	auditService      *AuditService
+	timeout           time.Duration // Jobs running longer fail
+	jobDTOFactory     *factory.ScheduleJobResponseDTOFactory
+	meetingDTOFactory *factory.CourseMeetingResponseDTOFactory
+}
+
+func NewSchedulerService(jobRepo *repository.ScheduleJobRepository, termRepo *repository.TermRepository, courseRepo *repository.CourseRepository, meetingRepo *repository.MeetingRepository, roomRepo *repository.RoomRepository, preferenceRepo *repository.TeacherPreferenceRepository, teacherRepo *repository.TeacherRepository, auditService *AuditService, timeout time.Duration) *SchedulerService {
+	return &SchedulerService{
+		jobRepo:           jobRepo,
+		termRepo:          termRepo,
+		courseRepo:        courseRepo,
+		meetingRepo:       meetingRepo,
+		roomRepo:          roomRepo,
+		preferenceRepo:    preferenceRepo,
+		teacherRepo:       teacherRepo,
+		auditService:      auditService,
+		timeout:           timeout,
+		jobDTOFactory:     factory.NewScheduleJobResponseDTOFactory(),
+		meetingDTOFactory: factory.NewCourseMeetingResponseDTOFactory(),
+	}
+}
+
+// StartJob queues a timetable generator run for the courses of a term. Only
+// one job per term may run at a time.
+func (s *SchedulerService) StartJob(actor domain.Actor, termID uint, req *dto.ScheduleJobCreateDTO) (*dto.ScheduleJobResponseDTO, error) {
+	term, err := s.termRepo.FindByID(termID)
+	if err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	options := withScheduleDefaults(req)
+	if _, err := scheduleWindow(options); err != nil {
+		return nil, errors.BadRequest(err.Error(), err)
+	}
+
+	courses, err := s.courseRepo.FindByTermID(term.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve courses", err)
+	}
+	inTerm := make(map[uint]bool)
+	for _, course := range courses {
+		inTerm[course.ID] = true
+	}
+	for i, override := range options.Courses {
+		if !inTerm[override.CourseID] {
+			return nil, errors.BadRequest("Course is not part of the term", nil).
+				WithDetails(map[string]interface{}{"course": i, "courseId": override.CourseID})
+		}
+	}
+
+	active, err := s.jobRepo.HasActive(term.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to check running jobs", err)
+	}
+	if active {
+		return nil, errors.Conflict("A timetable is already being generated for the term", nil)
+	}
+
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to encode job options", err)
+	}
+	job := &domain.ScheduleJob{
+		TermID:    term.ID,
+		Status:    domain.ScheduleJobPending,
+		Options:   encoded,
+		CreatedBy: actor.UserID,
+	}
+	var response *dto.ScheduleJobResponseDTO
+	err = s.jobRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewScheduleJobRepository(tx).Create(job); err != nil {
+			// A concurrent request started a job after the check above
+			if repository.IsUniqueViolation(err) {
+				return errors.Conflict("A timetable is already being generated for the term", nil)
+			}
+			return errors.InternalServerError("Failed to create job", err)
+		}
+		response = s.jobDTOFactory.CreateFromEntity(job)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityScheduleJob, job.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go s.run(job.ID, term, options)
+	return response, nil
+}
+
+func (s *SchedulerService) GetJobs(termID uint) ([]dto.ScheduleJobResponseDTO, error) {
+	if _, err := s.termRepo.FindByID(termID); err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	jobs, err := s.jobRepo.FindByTermID(termID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve jobs", err)
+	}
+
+	var dtos []dto.ScheduleJobResponseDTO
+	for _, job := range jobs {
+		dtos = append(dtos, *s.jobDTOFactory.CreateFromEntity(&job))
+	}
+	return dtos, nil
+}
+
+// GetJob reports the status and progress of a job
+func (s *SchedulerService) GetJob(id uint) (*dto.ScheduleJobResponseDTO, error) {
+	job, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Job not found", err)
+	}
+	return s.jobDTOFactory.CreateFromEntity(job), nil
+}
+
+// GetPreview returns the timetable proposed by a finished job
+func (s *SchedulerService) GetPreview(id uint) (*dto.SchedulePreviewDTO, error) {
+	job, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Job not found", err)
+	}
+	return s.preview(job)
+}
+
+// Apply writes the proposal of a job to the course meetings. Courses the job
+// could not schedule keep their meetings. The proposal is checked again for
+// double-booked rooms and teachers, since meetings may have changed since the
+// job ran.
+func (s *SchedulerService) Apply(actor domain.Actor, id uint) (*dto.ScheduleJobResponseDTO, error) {
+	job, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Job not found", err)
+	}
+	preview, err := s.preview(job)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == domain.ScheduleJobApplied {
+		return nil, errors.Conflict("Job has already been applied", nil)
+	}
+
+	courses := make([]*domain.Course, len(preview.Courses))
+	meetings := make([][]domain.CourseMeeting, len(preview.Courses))
+	befores := make([][]dto.CourseMeetingResponseDTO, len(preview.Courses))
+	for i, proposed := range preview.Courses {
+		if courses[i], err = s.courseRepo.FindByID(proposed.CourseID); err != nil {
+			return nil, errors.NotFound("Course not found", err).WithDetails(map[string]interface{}{"courseId": proposed.CourseID})
+		}
+		if meetings[i], err = s.proposedMeetings(courses[i], proposed.Meetings); err != nil {
+			return nil, err
+		}
+		existing, err := s.meetingRepo.FindByCourseID(proposed.CourseID)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to retrieve meetings", err)
+		}
+		befores[i] = s.meetingDTOs(existing)
+	}
+
+	now := time.Now()
+	before := s.jobDTOFactory.CreateFromEntity(job)
+	var response *dto.ScheduleJobResponseDTO
+	err = s.jobRepo.Transaction(func(tx *repository.Repository) error {
+		applied, err := repository.NewScheduleJobRepository(tx).MarkApplied(job.ID, now)
+		if err != nil {
+			return errors.InternalServerError("Failed to apply job", err)
+		}
+		if !applied {
+			return errors.Conflict("Job has already been applied", nil)
+		}
+
+		// Every course is replaced before checking, so the old meetings of the
+		// other courses in the proposal do not count as conflicts
+		meetingRepo := repository.NewMeetingRepository(tx)
+		for i, course := range courses {
+			if err := meetingRepo.Replace(course.ID, meetings[i]); err != nil {
+				return errors.InternalServerError("Failed to save meetings", err)
+			}
+		}
+
+		roomRepo := repository.NewRoomRepository(tx)
+		for i, course := range courses {
+			if err := checkRoomBookings(roomRepo, meetingRepo, course, meetings[i]); err != nil {
+				return err
+			}
+			taught, err := s.courseRepo.FindByTeacherID(course.TeacherID)
+			if err != nil {
+				return errors.InternalServerError("Failed to retrieve courses", err)
+			}
+			conflicts, err := findScheduleConflicts(meetingRepo, course, taught)
+			if err != nil {
+				return errors.InternalServerError("Failed to check schedule conflicts", err)
+			}
+			if len(conflicts) > 0 {
+				return errors.Conflict("Teacher would be double-booked", nil).
+					WithDetails(map[string]interface{}{"courseId": course.ID, "conflicts": conflicts})
+			}
+		}
+
+		for i, course := range courses {
+			if err := s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityMeetings, course.ID, befores[i], s.meetingDTOs(meetings[i])); err != nil {
+				return err
+			}
+		}
+		job.Status = domain.ScheduleJobApplied
+		job.AppliedAt = &now
+		response = s.jobDTOFactory.CreateFromEntity(job)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityScheduleJob, job.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// FailInterrupted fails the jobs a previous run of the server left unfinished
+func (s *SchedulerService) FailInterrupted() (int, error) {
+	return s.jobRepo.FailUnfinished("Interrupted by a server restart", time.Now())
+}
+
+func (s *SchedulerService) GetPreferences(teacherID uint) ([]dto.TeacherPreferredSlotDTO, error) {
+	if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
+		return nil, errors.NotFound("Teacher not found", err)
+	}
+
+	slots, err := s.preferenceRepo.FindByTeacherID(teacherID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve preferred slots", err)
+	}
+	return preferredSlotDTOs(slots), nil
+}
+
+// UpdatePreferences replaces the preferred teaching times of a teacher
+func (s *SchedulerService) UpdatePreferences(subject policy.Subject, actor domain.Actor, teacherID uint, req *dto.TeacherPreferencesDTO) ([]dto.TeacherPreferredSlotDTO, error) {
+	if !policy.CanManagePreferences(subject, teacherID) {
+		return nil, errors.Forbidden("You can only set your own preferred times", nil)
+	}
+	if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
+		return nil, errors.NotFound("Teacher not found", err)
+	}
+
+	var slots []domain.TeacherPreferredSlot
+	for i, entry := range req.Slots {
+		slot, err := parseSlot(entry.Days, entry.StartTime, entry.EndTime)
+		if err != nil {
+			return nil, errors.BadRequest(err.Error(), err).WithDetails(map[string]interface{}{"slot": i})
+		}
+		slots = append(slots, domain.TeacherPreferredSlot{
+			TeacherID:   teacherID,
+			Days:        uint8(slot.Days),
+			StartMinute: slot.Start,
+			EndMinute:   slot.End,
+		})
+	}
+
+	existing, err := s.preferenceRepo.FindByTeacherID(teacherID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve preferred slots", err)
+	}
+
+	response := preferredSlotDTOs(slots)
+	err = s.preferenceRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewTeacherPreferenceRepository(tx).Replace(teacherID, slots); err != nil {
+			return errors.InternalServerError("Failed to save preferred slots", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityPreferences, teacherID, preferredSlotDTOs(existing), response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// run executes a job in the background and records its outcome
+func (s *SchedulerService) run(jobID uint, term *domain.Term, options *dto.ScheduleJobCreateDTO) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Schedule job %d panicked: %v", jobID, r)
+			s.finish(jobID, domain.ScheduleJobFailed, nil, "Internal error while generating the timetable")
+		}
+	}()
+
+	if err := s.jobRepo.MarkRunning(jobID, time.Now()); err != nil {
+		log.Printf("Failed to mark schedule job %d as running: %v", jobID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	preview, err := s.generate(ctx, jobID, term, options)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", s.timeout)
+		}
+		s.finish(jobID, domain.ScheduleJobFailed, nil, err.Error())
+		return
+	}
+
+	result, err := json.Marshal(preview)
+	if err != nil {
+		s.finish(jobID, domain.ScheduleJobFailed, nil, err.Error())
+		return
+	}
+	s.finish(jobID, domain.ScheduleJobSucceeded, result, "")
+}
+
+func (s *SchedulerService) finish(jobID uint, status domain.ScheduleJobStatus, result json.RawMessage, message string) {
+	if err := s.jobRepo.Finish(jobID, status, result, message, time.Now()); err != nil {
+		log.Printf("Failed to record outcome of schedule job %d: %v", jobID, err)
+	}
+}
+
+// generate builds the problem from the courses, rooms and existing meetings and
+// turns the solution into a preview
+func (s *SchedulerService) generate(ctx context.Context, jobID uint, term *domain.Term, options *dto.ScheduleJobCreateDTO) (*dto.SchedulePreviewDTO, error) {
+	window, err := scheduleWindow(options)
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.courseRepo.FindByTermID(term.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve courses: %w", err)
+	}
+	var ids []uint
+	for _, course := range courses {
+		ids = append(ids, course.ID)
+	}
+	existing, err := s.meetingRepo.FindByCourseIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve meetings: %w", err)
+	}
+	scheduled := make(map[uint]bool)
+	for _, meeting := range existing {
+		scheduled[meeting.CourseID] = true
+	}
+
+	overrides := make(map[uint]dto.CourseSchedulingDTO)
+	for _, override := range options.Courses {
+		overrides[override.CourseID] = override
+	}
+
+	problem := timetabling.Problem{
+		Days:        window.Days,
+		DayStart:    window.Start,
+		DayEnd:      window.End,
+		Step:        options.Step,
+		TeacherBusy: make(map[uint][]schedule.Slot),
+		RoomBusy:    make(map[uint][]schedule.Slot),
+		Preferred:   make(map[uint][]schedule.Slot),
+	}
+	byID := make(map[uint]*domain.Course)
+	selected := make(map[uint]bool)
+	var teacherIDs []uint
+	seenTeachers := make(map[uint]bool)
+	for i := range courses {
+		course := &courses[i]
+		override := overrides[course.ID]
+		if override.Exclude || (options.KeepExisting && scheduled[course.ID]) {
+			continue
+		}
+		byID[course.ID] = course
+		selected[course.ID] = true
+		if !seenTeachers[course.TeacherID] {
+			seenTeachers[course.TeacherID] = true
+			teacherIDs = append(teacherIDs, course.TeacherID)
+		}
+
+		entry := timetabling.Course{
+			ID:        course.ID,
+			TeacherID: course.TeacherID,
+			Size:      course.Capacity,
+			Meetings:  options.MeetingsPerWeek,
+			Duration:  options.Duration,
+		}
+		if override.MeetingsPerWeek > 0 {
+			entry.Meetings = override.MeetingsPerWeek
+		}
+		if override.Duration > 0 {
+			entry.Duration = override.Duration
+		}
+		for _, feature := range roomFeatures(override.Features) {
+			entry.Features = append(entry.Features, feature.Feature)
+		}
+		problem.Courses = append(problem.Courses, entry)
+	}
+
+	rooms, err := s.roomRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve rooms: %w", err)
+	}
+	labels := make(map[uint]string)
+	var roomIDs []uint
+	for _, room := range rooms {
+		entry := timetabling.Room{ID: room.ID, Capacity: room.Capacity}
+		for _, feature := range room.Features {
+			entry.Features = append(entry.Features, feature.Feature)
+		}
+		problem.Rooms = append(problem.Rooms, entry)
+		labels[room.ID] = room.Label()
+		roomIDs = append(roomIDs, room.ID)
+	}
+
+	// Rooms are busy with the meetings of courses that keep their schedule and
+	// with bookings during the term, which block the weekday every week
+	roomMeetings, err := s.meetingRepo.FindByRoomIDs(roomIDs, term.StartDate, term.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve room usage: %w", err)
+	}
+	for _, meeting := range roomMeetings {
+		if !selected[meeting.CourseID] {
+			problem.RoomBusy[*meeting.RoomID] = append(problem.RoomBusy[*meeting.RoomID], meetingSlot(&meeting))
+		}
+	}
+	bookings, err := s.roomRepo.FindBookings(roomIDs, term.StartDate, term.EndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve room bookings: %w", err)
+	}
+	for _, booking := range bookings {
+		problem.RoomBusy[booking.RoomID] = append(problem.RoomBusy[booking.RoomID], bookingSlots(&booking)...)
+	}
+
+	// Teachers are busy with their other courses running during the term
+	for _, teacherID := range teacherIDs {
+		taught, err := s.courseRepo.FindByTeacherID(teacherID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve courses: %w", err)
+		}
+		var others []uint
+		for _, course := range taught {
+			if !selected[course.ID] && !course.StartDate.After(term.EndDate) && !term.StartDate.After(course.EndDate) {
+				others = append(others, course.ID)
+			}
+		}
+		meetings, err := s.meetingRepo.FindByCourseIDs(others)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve meetings: %w", err)
+		}
+		for _, meeting := range meetings {
+			problem.TeacherBusy[teacherID] = append(problem.TeacherBusy[teacherID], meetingSlot(&meeting))
+		}
+	}
+
+	preferred, err := s.preferenceRepo.FindByTeacherIDs(teacherIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve preferred slots: %w", err)
+	}
+	for _, slot := range preferred {
+		problem.Preferred[slot.TeacherID] = append(problem.Preferred[slot.TeacherID],
+			schedule.Slot{Days: schedule.Days(slot.Days), Start: slot.StartMinute, End: slot.EndMinute})
+	}
+
+	// Progress stays below 100 until the result is stored
+	reported := 0
+	solution, err := timetabling.Solve(ctx, &problem, func(done, total int) {
+		percent := done * 99 / total
+		if percent == reported {
+			return
+		}
+		reported = percent
+		if err := s.jobRepo.UpdateProgress(jobID, percent); err != nil {
+			log.Printf("Failed to record progress of schedule job %d: %v", jobID, err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildPreview(jobID, term.ID, byID, labels, solution), nil
+}
+
+// buildPreview merges the meetings a course has at the same time in the same
+// room on several days into one meeting
+func (s *SchedulerService) buildPreview(jobID, termID uint, courses map[uint]*domain.Course, labels map[uint]string, solution *timetabling.Solution) *dto.SchedulePreviewDTO {
+	type key struct {
+		courseID, roomID uint
+		start, end       int
+	}
+	merged := make(map[key]schedule.Days)
+	var keys []key
+	for _, placement := range solution.Placements {
+		k := key{placement.CourseID, placement.RoomID, placement.Slot.Start, placement.Slot.End}
+		if _, ok := merged[k]; !ok {
+			keys = append(keys, k)
+		}
+		merged[k] |= placement.Slot.Days
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].courseID != keys[j].courseID {
+			return courses[keys[i].courseID].Code < courses[keys[j].courseID].Code
+		}
+		return merged[keys[i]] < merged[keys[j]]
+	})
+
+	preview := &dto.SchedulePreviewDTO{
+		JobID:       jobID,
+		TermID:      termID,
+		Courses:     []dto.ScheduledCourseDTO{},
+		Unscheduled: []dto.UnscheduledCourseDTO{},
+		Cost:        solution.Cost,
+	}
+	for _, k := range keys {
+		if n := len(preview.Courses); n == 0 || preview.Courses[n-1].CourseID != k.courseID {
+			course := courses[k.courseID]
+			preview.Courses = append(preview.Courses, dto.ScheduledCourseDTO{
+				CourseID:   course.ID,
+				CourseCode: course.Code,
+				CourseName: course.Name,
+				TeacherID:  course.TeacherID,
+			})
+		}
+		roomID := k.roomID
+		entry := &preview.Courses[len(preview.Courses)-1]
+		entry.Meetings = append(entry.Meetings, dto.CourseMeetingDTO{
+			Days:      merged[k].Names(),
+			StartTime: schedule.FormatClock(k.start),
+			EndTime:   schedule.FormatClock(k.end),
+			RoomID:    &roomID,
+			Room:      labels[k.roomID],
+		})
+	}
+	for _, unplaced := range solution.Unplaced {
+		preview.Unscheduled = append(preview.Unscheduled, dto.UnscheduledCourseDTO{
+			CourseID:   unplaced.CourseID,
+			CourseCode: courses[unplaced.CourseID].Code,
+			Reason:     unplaced.Reason,
+		})
+	}
+	sort.Slice(preview.Unscheduled, func(i, j int) bool {
+		return preview.Unscheduled[i].CourseCode < preview.Unscheduled[j].CourseCode
+	})
+	return preview
+}
+
+func (s *SchedulerService) preview(job *domain.ScheduleJob) (*dto.SchedulePreviewDTO, error) {
+	if job.Status != domain.ScheduleJobSucceeded && job.Status != domain.ScheduleJobApplied {
+		return nil, errors.Conflict("Job has not produced a timetable", nil).
+			WithDetails(map[string]interface{}{"status": job.Status, "progress": job.Progress})
+	}
+	var preview dto.SchedulePreviewDTO
+	if err := json.Unmarshal(job.Result, &preview); err != nil {
+		return nil, errors.InternalServerError("Failed to read job result", err)
+	}
+	return &preview, nil
+}
+
+// proposedMeetings turns the meetings of a preview back into course meetings
+func (s *SchedulerService) proposedMeetings(course *domain.Course, entries []dto.CourseMeetingDTO) ([]domain.CourseMeeting, error) {
+	var meetings []domain.CourseMeeting
+	for _, entry := range entries {
+		slot, err := parseMeeting(&entry)
+		if err != nil {
+			return nil, errors.InternalServerError("Failed to read job result", err)
+		}
+		meeting := domain.CourseMeeting{
+			CourseID:    course.ID,
+			Days:        uint8(slot.Days),
+			StartMinute: slot.Start,
+			EndMinute:   slot.End,
+		}
+		if entry.RoomID != nil {
+			room, err := s.roomRepo.FindByID(*entry.RoomID)
+			if err != nil {
+				return nil, errors.NotFound("Room not found", err).WithDetails(map[string]interface{}{"roomId": *entry.RoomID})
+			}
+			if course.Capacity > room.Capacity {
+				return nil, errors.Conflict("Room is too small for the course", nil).
+					WithDetails(map[string]interface{}{"courseId": course.ID, "roomCapacity": room.Capacity, "courseCapacity": course.Capacity})
+			}
+			meeting.RoomID = &room.ID
+			meeting.Location = room
+		}
+		meetings = append(meetings, meeting)
+	}
+	return meetings, nil
+}
+
+func (s *SchedulerService) meetingDTOs(meetings []domain.CourseMeeting) []dto.CourseMeetingResponseDTO {
+	var dtos []dto.CourseMeetingResponseDTO
+	for _, meeting := range meetings {
+		dtos = append(dtos, *s.meetingDTOFactory.CreateFromEntity(&meeting))
+	}
+	return dtos
+}
+
+// withScheduleDefaults fills in the options a job request leaves unset
+func withScheduleDefaults(req *dto.ScheduleJobCreateDTO) *dto.ScheduleJobCreateDTO {
+	options := *req
+	if len(options.Days) == 0 {
+		options.Days = defaultScheduleDays
+	}
+	if options.DayStart == "" {
+		options.DayStart = defaultScheduleDayStart
+	}
+	if options.DayEnd == "" {
+		options.DayEnd = defaultScheduleDayEnd
+	}
+	if options.Step == 0 {
+		options.Step = defaultScheduleStep
+	}
+	if options.MeetingsPerWeek == 0 {
+		options.MeetingsPerWeek = defaultMeetingsPerWeek
+	}
+	if options.Duration == 0 {
+		options.Duration = defaultMeetingDuration
+	}
+	return &options
+}
+
+// scheduleWindow is the days and hours meetings may be placed in
+func scheduleWindow(options *dto.ScheduleJobCreateDTO) (schedule.Slot, error) {
+	return parseSlot(options.Days, options.DayStart, options.DayEnd)
+}
+
+// bookingSlots blocks the times of a booking on its weekdays, split per day
+func bookingSlots(booking *domain.RoomBooking) []schedule.Slot {
+	start, end := booking.StartsAt.In(time.Local), booking.EndsAt.In(time.Local)
+	var slots []schedule.Slot
+	for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.Local); day.Before(end); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		lo, hi := 0, schedule.MinutesPerDay
+		if day.Before(start) {
+			lo = int(start.Sub(day) / time.Minute)
+		}
+		if end.Before(next) {
+			hi = int((end.Sub(day) + time.Minute - 1) / time.Minute)
+		}
+		if lo < hi {
+			slots = append(slots, schedule.Slot{Days: schedule.DayOf(day.Weekday()), Start: lo, End: hi})
+		}
+	}
+	return slots
+}
+
+func preferredSlotDTOs(slots []domain.TeacherPreferredSlot) []dto.TeacherPreferredSlotDTO {
+	dtos := []dto.TeacherPreferredSlotDTO{}
+	for _, slot := range slots {
+		dtos = append(dtos, dto.TeacherPreferredSlotDTO{
+			Days:      schedule.Days(slot.Days).Names(),
+			StartTime: schedule.FormatClock(slot.StartMinute),
+			EndTime:   schedule.FormatClock(slot.EndMinute),
+		})
+	}
+	return dtos
+}
@@ -13,20 +13,24 @@ import (
 type StudentService struct {
 	studentRepo     *repository.StudentRepository
 	userRepo        *repository.UserRepository
+	sessionRepo     *repository.SessionRepository
+	auditService    *AuditService
 	studentFactory  *factory.StudentFactory
 	studentDTOFactory *factory.StudentDTOFactory
 }
 
-func NewStudentService(studentRepo *repository.StudentRepository, userRepo *repository.UserRepository) *StudentService {
+func NewStudentService(studentRepo *repository.StudentRepository, userRepo *repository.UserRepository, sessionRepo *repository.SessionRepository, auditService *AuditService) *StudentService {
 	return &StudentService{
 		studentRepo:     studentRepo,
 		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		auditService:    auditService,
 		studentFactory:  factory.NewStudentFactory(),
 		studentDTOFactory: factory.NewStudentDTOFactory(),
 	}
 }
 
-func (s *StudentService) Create(req *dto.StudentCreateDTO) (*dto.StudentResponseDTO, error) {
+func (s *StudentService) Create(actor domain.Actor, req *dto.StudentCreateDTO) (*dto.StudentResponseDTO, error) {
 	// Check if email already exists
 	existingUser, _ := s.userRepo.FindByEmail(req.Email)
 	if existingUser != nil {
@@ -54,24 +58,30 @@ func (s *StudentService) Create(req *dto.StudentCreateDTO) (*dto.StudentResponse
 		Role:      domain.RoleStudent,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
-		return nil, err
-	}
+	var response *dto.StudentResponseDTO
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Create(user); err != nil {
+			return err
+		}
+
+		// Create student using factory
+		student := s.studentFactory.CreateFromDTO(req, user.ID)
 
-	// Create student using factory
-	student := s.studentFactory.CreateFromDTO(req, user.ID)
+		if err := repository.NewStudentRepository(tx).Create(student); err != nil {
+			return err
+		}
 
-	if err := s.studentRepo.Create(student); err != nil {
-		// Rollback user creation if student creation fails
-		_ = s.userRepo.Delete(user.ID)
+		// Set the User field for the DTO conversion
+		student.User = *user
+
+		// Use factory to create response DTO
+		response = s.studentDTOFactory.CreateFromEntity(student)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityStudent, student.ID, nil, response)
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	// Set the User field for the DTO conversion
-	student.User = *user
-	
-	// Use factory to create response DTO
-	return s.studentDTOFactory.CreateFromEntity(student), nil
+	return response, nil
 }
 
 func (s *StudentService) GetAll() ([]dto.StudentResponseDTO, error) {
@@ -97,11 +107,12 @@ func (s *StudentService) GetByID(id uint) (*dto.StudentResponseDTO, error) {
 	return s.studentDTOFactory.CreateFromEntity(student), nil
 }
 
-func (s *StudentService) Update(id uint, req *dto.StudentUpdateDTO) (*dto.StudentResponseDTO, error) {
+func (s *StudentService) Update(actor domain.Actor, id uint, req *dto.StudentUpdateDTO) (*dto.StudentResponseDTO, error) {
 	student, err := s.studentRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
+	before := s.studentDTOFactory.CreateFromEntity(student)
 
 	user, err := s.userRepo.FindByID(student.UserID)
 	if err != nil {
@@ -115,9 +126,6 @@ func (s *StudentService) Update(id uint, req *dto.StudentUpdateDTO) (*dto.Studen
 	if req.LastName != "" {
 		user.LastName = req.LastName
 	}
-	if err := s.userRepo.Update(user); err != nil {
-		return nil, err
-	}
 
 	// Update student info
 	if req.EnrollYear != 0 {
@@ -126,27 +134,48 @@ func (s *StudentService) Update(id uint, req *dto.StudentUpdateDTO) (*dto.Studen
 	if req.Major != "" {
 		student.Major = req.Major
 	}
-	if err := s.studentRepo.Update(student); err != nil {
-		return nil, err
-	}
 
 	// Update the User field for the DTO conversion
 	student.User = *user
-	
-	return s.studentDTOFactory.CreateFromEntity(student), nil
+
+	response := s.studentDTOFactory.CreateFromEntity(student)
+	err = s.studentRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return err
+		}
+		if err := repository.NewStudentRepository(tx).Update(student); err != nil {
+			return err
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityStudent, student.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
-func (s *StudentService) Delete(id uint) error {
+func (s *StudentService) Delete(actor domain.Actor, id uint) error {
 	student, err := s.studentRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// First delete student
-	if err := s.studentRepo.Delete(id); err != nil {
+	err = s.studentRepo.Transaction(func(tx *repository.Repository) error {
+		// First delete student
+		if err := repository.NewStudentRepository(tx).Delete(id); err != nil {
+			return err
+		}
+
+		// Then delete user
+		if err := repository.NewUserRepository(tx).Delete(student.UserID); err != nil {
+			return err
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityStudent, student.ID, s.studentDTOFactory.CreateFromEntity(student), nil)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Then delete user
-	return s.userRepo.Delete(student.UserID)
+	// Sign the deleted user out everywhere
+	return s.sessionRepo.RevokeAllByUserID(student.UserID)
 }
\ No newline at end of file
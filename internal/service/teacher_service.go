@@ -12,21 +12,27 @@ import (
 
 type TeacherService struct {
 	teacherRepo      *repository.TeacherRepository
+	departmentRepo   *repository.DepartmentRepository
 	userRepo         *repository.UserRepository
+	sessionRepo      *repository.SessionRepository
+	auditService     *AuditService
 	teacherFactory   *factory.TeacherFactory
 	teacherDTOFactory *factory.TeacherDTOFactory
 }
 
-func NewTeacherService(teacherRepo *repository.TeacherRepository, userRepo *repository.UserRepository) *TeacherService {
+func NewTeacherService(teacherRepo *repository.TeacherRepository, departmentRepo *repository.DepartmentRepository, userRepo *repository.UserRepository, sessionRepo *repository.SessionRepository, auditService *AuditService) *TeacherService {
 	return &TeacherService{
 		teacherRepo:      teacherRepo,
+		departmentRepo:   departmentRepo,
 		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		auditService:     auditService,
 		teacherFactory:   factory.NewTeacherFactory(),
 		teacherDTOFactory: factory.NewTeacherDTOFactory(),
 	}
 }
 
-func (s *TeacherService) Create(req *dto.TeacherCreateDTO) (*dto.TeacherResponseDTO, error) {
+func (s *TeacherService) Create(actor domain.Actor, req *dto.TeacherCreateDTO) (*dto.TeacherResponseDTO, error) {
 	// Check if email already exists
 	existingUser, _ := s.userRepo.FindByEmail(req.Email)
 	if existingUser != nil {
@@ -39,6 +45,12 @@ func (s *TeacherService) Create(req *dto.TeacherCreateDTO) (*dto.TeacherResponse
 		return nil, errors.New("teacher with this employee ID already exists")
 	}
 
+	// The employee ID starts with the code of the teacher's department
+	department, err := s.departmentRepo.FindByCode(domain.CodePrefix(req.EmployeeID))
+	if err != nil {
+		return nil, errors.New("no department matches the employee ID")
+	}
+
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
@@ -54,24 +66,32 @@ func (s *TeacherService) Create(req *dto.TeacherCreateDTO) (*dto.TeacherResponse
 		Role:      domain.RoleTeacher,
 	}
 
-	if err := s.userRepo.Create(user); err != nil {
-		return nil, err
-	}
+	var response *dto.TeacherResponseDTO
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Create(user); err != nil {
+			return err
+		}
+
+		// Create teacher using factory
+		teacher := s.teacherFactory.CreateFromDTO(req, user.ID)
+		teacher.DepartmentID = department.ID
 
-	// Create teacher using factory
-	teacher := s.teacherFactory.CreateFromDTO(req, user.ID)
+		if err := repository.NewTeacherRepository(tx).Create(teacher); err != nil {
+			return err
+		}
 
-	if err := s.teacherRepo.Create(teacher); err != nil {
-		// Rollback user creation if teacher creation fails
-		_ = s.userRepo.Delete(user.ID)
+		// Setting the User and Department fields for the DTO conversion
+		teacher.User = *user
+		teacher.Department = *department
+
+		// Using factory to create response DTO
+		response = s.teacherDTOFactory.CreateFromEntity(teacher)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityTeacher, teacher.ID, nil, response)
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	// Setting the User field for the DTO conversion
-	teacher.User = *user
-	
-	// Using factory to create response DTO
-	return s.teacherDTOFactory.CreateFromEntity(teacher), nil
+	return response, nil
 }
 
 func (s *TeacherService) GetAll() ([]dto.TeacherResponseDTO, error) {
@@ -97,11 +117,12 @@ func (s *TeacherService) GetByID(id uint) (*dto.TeacherResponseDTO, error) {
 	return s.teacherDTOFactory.CreateFromEntity(teacher), nil
 }
 
-func (s *TeacherService) Update(id uint, req *dto.TeacherUpdateDTO) (*dto.TeacherResponseDTO, error) {
+func (s *TeacherService) Update(actor domain.Actor, id uint, req *dto.TeacherUpdateDTO) (*dto.TeacherResponseDTO, error) {
 	teacher, err := s.teacherRepo.FindByID(id)
 	if err != nil {
 		return nil, err
 	}
+	before := s.teacherDTOFactory.CreateFromEntity(teacher)
 
 	user, err := s.userRepo.FindByID(teacher.UserID)
 	if err != nil {
@@ -115,13 +136,16 @@ func (s *TeacherService) Update(id uint, req *dto.TeacherUpdateDTO) (*dto.Teache
 	if req.LastName != "" {
 		user.LastName = req.LastName
 	}
-	if err := s.userRepo.Update(user); err != nil {
-		return nil, err
-	}
 
 	// Update teacher info
-	if req.Department != "" {
-		teacher.Department = req.Department
+	movesDepartment := req.DepartmentID != 0 && req.DepartmentID != teacher.DepartmentID
+	if movesDepartment {
+		department, err := s.departmentRepo.FindByID(req.DepartmentID)
+		if err != nil {
+			return nil, errors.New("department not found")
+		}
+		teacher.DepartmentID = department.ID
+		teacher.Department = *department
 	}
 	if req.Speciality != "" {
 		teacher.Speciality = req.Speciality
@@ -129,27 +153,56 @@ func (s *TeacherService) Update(id uint, req *dto.TeacherUpdateDTO) (*dto.Teache
 	if !req.JoiningDate.IsZero() {
 		teacher.JoiningDate = req.JoiningDate
 	}
-	if err := s.teacherRepo.Update(teacher); err != nil {
-		return nil, err
-	}
-
 	// Update the User field for the DTO conversion
 	teacher.User = *user
-	
-	return s.teacherDTOFactory.CreateFromEntity(teacher), nil
+
+	response := s.teacherDTOFactory.CreateFromEntity(teacher)
+	err = s.teacherRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return err
+		}
+		if err := repository.NewTeacherRepository(tx).Update(teacher); err != nil {
+			return err
+		}
+		// A teacher leaving a department no longer heads it
+		if movesDepartment {
+			if err := repository.NewDepartmentRepository(tx).ClearHead(teacher.ID); err != nil {
+				return err
+			}
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityTeacher, teacher.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
 }
 
-func (s *TeacherService) Delete(id uint) error {
+func (s *TeacherService) Delete(actor domain.Actor, id uint) error {
 	teacher, err := s.teacherRepo.FindByID(id)
 	if err != nil {
 		return err
 	}
 
-	// First delete teacher
-	if err := s.teacherRepo.Delete(id); err != nil {
+	err = s.teacherRepo.Transaction(func(tx *repository.Repository) error {
+		// First delete teacher
+		if err := repository.NewTeacherRepository(tx).Delete(id); err != nil {
+			return err
+		}
+		if err := repository.NewDepartmentRepository(tx).ClearHead(teacher.ID); err != nil {
+			return err
+		}
+
+		// Then delete user
+		if err := repository.NewUserRepository(tx).Delete(teacher.UserID); err != nil {
+			return err
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityTeacher, teacher.ID, s.teacherDTOFactory.CreateFromEntity(teacher), nil)
+	})
+	if err != nil {
 		return err
 	}
 
-	// Then delete user
-	return s.userRepo.Delete(teacher.UserID)
+	// Sign the deleted user out everywhere
+	return s.sessionRepo.RevokeAllByUserID(teacher.UserID)
 }
\ No newline at end of file
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+)
+
+type TermService struct {
+	termRepo       *repository.TermRepository
+	courseRepo     *repository.CourseRepository
+	auditService   *AuditService
+	termFactory    *factory.TermFactory
+	termDTOFactory *factory.TermResponseDTOFactory
+}
+
+func NewTermService(termRepo *repository.TermRepository, courseRepo *repository.CourseRepository, auditService *AuditService) *TermService {
+	return &TermService{
+		termRepo:       termRepo,
+		courseRepo:     courseRepo,
+		auditService:   auditService,
+		termFactory:    factory.NewTermFactory(),
+		termDTOFactory: factory.NewTermResponseDTOFactory(),
+	}
+}
+
+func (s *TermService) Create(actor domain.Actor, req *dto.TermCreateDTO) (*dto.TermResponseDTO, error) {
+	// Check if term name already exists
+	existingTerm, _ := s.termRepo.FindByName(req.Name)
+	if existingTerm != nil {
+		return nil, errors.Conflict("Term with this name already exists", nil)
+	}
+
+	// Create term using factory
+	term := s.termFactory.CreateFromDTO(req)
+
+	if err := validateTermDates(term); err != nil {
+		return nil, err
+	}
+
+	var response *dto.TermResponseDTO
+	err := s.termRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewTermRepository(tx).Create(term); err != nil {
+			return errors.InternalServerError("Failed to create term", err)
+		}
+		response = s.termDTOFactory.CreateFromEntity(term)
+		return s.auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityTerm, term.ID, nil, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *TermService) GetAll() ([]dto.TermResponseDTO, error) {
+	terms, err := s.termRepo.FindAll()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve terms", err)
+	}
+
+	var dtos []dto.TermResponseDTO
+	for _, term := range terms {
+		dtos = append(dtos, *s.termDTOFactory.CreateFromEntity(&term))
+	}
+
+	return dtos, nil
+}
+
+func (s *TermService) GetByID(id uint) (*dto.TermResponseDTO, error) {
+	term, err := s.termRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	return s.termDTOFactory.CreateFromEntity(term), nil
+}
+
+// Update changes a term. Its dates cannot move so that a course of the term
+// falls outside them.
+func (s *TermService) Update(actor domain.Actor, id uint, req *dto.TermUpdateDTO) (*dto.TermResponseDTO, error) {
+	term, err := s.termRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+	before := s.termDTOFactory.CreateFromEntity(term)
+
+	// Update term info
+	if req.Name != "" && req.Name != term.Name {
+		existingTerm, _ := s.termRepo.FindByName(req.Name)
+		if existingTerm != nil {
+			return nil, errors.Conflict("Term with this name already exists", nil)
+		}
+		term.Name = req.Name
+	}
+	if !req.StartDate.IsZero() {
+		term.StartDate = req.StartDate
+	}
+	if !req.EndDate.IsZero() {
+		term.EndDate = req.EndDate
+	}
+	if !req.AddDropDeadline.IsZero() {
+		term.AddDropDeadline = req.AddDropDeadline
+	}
+	if !req.GradingDeadline.IsZero() {
+		term.GradingDeadline = req.GradingDeadline
+	}
+
+	if err := validateTermDates(term); err != nil {
+		return nil, err
+	}
+
+	var response *dto.TermResponseDTO
+	err = s.termRepo.Transaction(func(tx *repository.Repository) error {
+		courses, err := repository.NewCourseRepository(tx).FindByTermID(term.ID)
+		if err != nil {
+			return errors.InternalServerError("Failed to retrieve term courses", err)
+		}
+		var outside []uint
+		for _, course := range courses {
+			if !term.Contains(course.StartDate, course.EndDate) {
+				outside = append(outside, course.ID)
+			}
+		}
+		if len(outside) > 0 {
+			return errors.Conflict("Courses of the term would fall outside its dates", nil).
+				WithDetails(map[string]interface{}{"courseIds": outside})
+		}
+
+		if err := repository.NewTermRepository(tx).Update(term); err != nil {
+			return errors.InternalServerError("Failed to update term", err)
+		}
+		response = s.termDTOFactory.CreateFromEntity(term)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityTerm, term.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *TermService) Delete(actor domain.Actor, id uint) error {
+	// Check if term exists
+	term, err := s.termRepo.FindByID(id)
+	if err != nil {
+		return errors.NotFound("Term not found", err)
+	}
+
+	// Refuse to orphan courses that are still scheduled in this term
+	count, err := s.courseRepo.CountByTermID(id)
+	if err != nil {
+		return errors.InternalServerError("Failed to count term courses", err)
+	}
+	if count > 0 {
+		return errors.Conflict("Term still has courses assigned to it", nil)
+	}
+
+	return s.termRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewTermRepository(tx).Delete(id); err != nil {
+			return errors.InternalServerError("Failed to delete term", err)
+		}
+		return s.auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityTerm, term.ID, s.termDTOFactory.CreateFromEntity(term), nil)
+	})
+}
+
+// MatchCourses links every course without a term whose dates fall inside this
+// term. It is used to migrate courses created before terms existed.
+func (s *TermService) MatchCourses(id uint) (*dto.TermCourseMatchResponseDTO, error) {
+	term, err := s.termRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.NotFound("Term not found", err)
+	}
+
+	matched, err := s.courseRepo.AssignUnscheduledToTerm(term)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to assign courses to term", err)
+	}
+
+	return &dto.TermCourseMatchResponseDTO{
+		TermID:         term.ID,
+		CoursesMatched: matched,
+	}, nil
+}
+
+func validateTermDates(term *domain.Term) error {
+	if !term.EndDate.After(term.StartDate) {
+		return errors.BadRequest("Term end date must be after its start date", nil)
+	}
+	if term.AddDropDeadline.Before(term.StartDate) || term.AddDropDeadline.After(term.EndDate) {
+		return errors.BadRequest("Add/drop deadline must fall within the term", nil)
+	}
+	if term.GradingDeadline.Before(term.EndDate) {
+		return errors.BadRequest("Grading deadline cannot be before the end of the term", nil)
+	}
+	return nil
+}
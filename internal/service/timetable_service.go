@@ -0,0 +1,377 @@
+package service
+
+import (
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/policy"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+)
+
+// TimetableService manages the weekly meeting pattern of courses and builds the
+// timetables of students and teachers from it
+type TimetableService struct {
+	meetingRepo       *repository.MeetingRepository
+	roomRepo          *repository.RoomRepository
+	courseRepo        *repository.CourseRepository
+	enrollmentRepo    *repository.EnrollmentRepository
+	studentRepo       *repository.StudentRepository
+	teacherRepo       *repository.TeacherRepository
+	auditService      *AuditService
+	meetingDTOFactory *factory.CourseMeetingResponseDTOFactory
+}
+
+func NewTimetableService(meetingRepo *repository.MeetingRepository, roomRepo *repository.RoomRepository, courseRepo *repository.CourseRepository, enrollmentRepo *repository.EnrollmentRepository, studentRepo *repository.StudentRepository, teacherRepo *repository.TeacherRepository, auditService *AuditService) *TimetableService {
+	return &TimetableService{
+		meetingRepo:       meetingRepo,
+		roomRepo:          roomRepo,
+		courseRepo:        courseRepo,
+		enrollmentRepo:    enrollmentRepo,
+		studentRepo:       studentRepo,
+		teacherRepo:       teacherRepo,
+		auditService:      auditService,
+		meetingDTOFactory: factory.NewCourseMeetingResponseDTOFactory(),
+	}
+}
+
+func (s *TimetableService) GetMeetings(courseID uint) ([]dto.CourseMeetingResponseDTO, error) {
+	if _, err := s.courseRepo.FindByID(courseID); err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+
+	meetings, err := s.meetingRepo.FindByCourseID(courseID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve meetings", err)
+	}
+
+	return s.meetingDTOs(meetings), nil
+}
+
+// UpdateMeetings replaces the meeting pattern of a course. Meetings of the same
+// course may not overlap each other, and a booked room must seat the course and
+// be free during the course dates.
+func (s *TimetableService) UpdateMeetings(subject policy.Subject, actor domain.Actor, courseID uint, req *dto.CourseMeetingsUpdateDTO) ([]dto.CourseMeetingResponseDTO, error) {
+	course, err := s.courseRepo.FindByID(courseID)
+	if err != nil {
+		return nil, errors.NotFound("Course not found", err)
+	}
+	if !policy.CanManageCourse(subject, course) {
+		return nil, errors.Forbidden("You can only schedule your own courses", nil)
+	}
+
+	var meetings []domain.CourseMeeting
+	var slots []schedule.Slot
+	for i, entry := range req.Meetings {
+		slot, err := parseMeeting(&entry)
+		if err != nil {
+			return nil, errors.BadRequest(err.Error(), err).WithDetails(map[string]interface{}{"meeting": i})
+		}
+		for j, other := range slots {
+			if slot.Overlaps(other) {
+				return nil, errors.BadRequest("Meetings of a course cannot overlap", nil).
+					WithDetails(map[string]interface{}{"meetings": []int{j, i}})
+			}
+		}
+		slots = append(slots, slot)
+		meeting := domain.CourseMeeting{
+			CourseID:    course.ID,
+			Days:        uint8(slot.Days),
+			StartMinute: slot.Start,
+			EndMinute:   slot.End,
+			Room:        entry.Room,
+		}
+		if entry.RoomID != nil {
+			room, err := s.roomRepo.FindByID(*entry.RoomID)
+			if err != nil {
+				return nil, errors.NotFound("Room not found", err).WithDetails(map[string]interface{}{"meeting": i})
+			}
+			if course.Capacity > room.Capacity {
+				return nil, errors.BadRequest("Room is too small for the course", nil).
+					WithDetails(map[string]interface{}{"meeting": i, "roomCapacity": room.Capacity, "courseCapacity": course.Capacity})
+			}
+			meeting.RoomID = &room.ID
+			meeting.Location = room
+		}
+		meetings = append(meetings, meeting)
+	}
+
+	existing, err := s.meetingRepo.FindByCourseID(course.ID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve meetings", err)
+	}
+	before := s.meetingDTOs(existing)
+
+	var response []dto.CourseMeetingResponseDTO
+	err = s.meetingRepo.Transaction(func(tx *repository.Repository) error {
+		meetingRepo := repository.NewMeetingRepository(tx)
+		if err := checkRoomBookings(repository.NewRoomRepository(tx), meetingRepo, course, meetings); err != nil {
+			return err
+		}
+		if err := meetingRepo.Replace(course.ID, meetings); err != nil {
+			return errors.InternalServerError("Failed to save meetings", err)
+		}
+		response = s.meetingDTOs(meetings)
+		return s.auditService.Record(tx, actor, domain.AuditActionUpdate, domain.AuditEntityMeetings, course.ID, before, response)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetStudentTimetable builds the weekly grid of the courses a student holds a
+// seat in. Without a term only courses that have not ended yet are included.
+func (s *TimetableService) GetStudentTimetable(studentID, termID uint) (*dto.TimetableDTO, error) {
+	if _, err := s.studentRepo.FindByID(studentID); err != nil {
+		return nil, errors.NotFound("Student not found", err)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByStudentID(studentID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	var courses []domain.Course
+	for _, enrollment := range enrollments {
+		if enrollment.HoldsSeat() && inTimetable(&enrollment.Course, termID) {
+			courses = append(courses, enrollment.Course)
+		}
+	}
+
+	return s.buildTimetable(courses)
+}
+
+// GetTeacherTimetable builds the weekly grid of the courses a teacher teaches.
+// Without a term only courses that have not ended yet are included.
+func (s *TimetableService) GetTeacherTimetable(teacherID, termID uint) (*dto.TimetableDTO, error) {
+	if _, err := s.teacherRepo.FindByID(teacherID); err != nil {
+		return nil, errors.NotFound("Teacher not found", err)
+	}
+
+	taught, err := s.courseRepo.FindByTeacherID(teacherID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve courses", err)
+	}
+
+	var courses []domain.Course
+	for _, course := range taught {
+		if inTimetable(&course, termID) {
+			courses = append(courses, course)
+		}
+	}
+
+	return s.buildTimetable(courses)
+}
+
+func (s *TimetableService) buildTimetable(courses []domain.Course) (*dto.TimetableDTO, error) {
+	byID := make(map[uint]*domain.Course)
+	var ids []uint
+	for i := range courses {
+		byID[courses[i].ID] = &courses[i]
+		ids = append(ids, courses[i].ID)
+	}
+
+	meetings, err := s.meetingRepo.FindByCourseIDs(ids)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve meetings", err)
+	}
+
+	type slotEntry struct {
+		entry      dto.TimetableEntryDTO
+		start, end int
+	}
+	days := schedule.AllDays.Split()
+	grid := make([][]slotEntry, len(days))
+	for _, meeting := range meetings {
+		course := byID[meeting.CourseID]
+		entry := dto.TimetableEntryDTO{
+			CourseID:    course.ID,
+			CourseCode:  course.Code,
+			CourseName:  course.Name,
+			TeacherName: course.Teacher.User.FirstName + " " + course.Teacher.User.LastName,
+			StartTime:   schedule.FormatClock(meeting.StartMinute),
+			EndTime:     schedule.FormatClock(meeting.EndMinute),
+			Room:        meeting.RoomLabel(),
+		}
+		for _, day := range schedule.Days(meeting.Days).Split() {
+			index := bits.TrailingZeros8(uint8(day))
+			grid[index] = append(grid[index], slotEntry{entry: entry, start: meeting.StartMinute, end: meeting.EndMinute})
+		}
+	}
+
+	timetable := &dto.TimetableDTO{}
+	for i, day := range days {
+		entries := grid[i]
+		sort.SliceStable(entries, func(a, b int) bool {
+			if entries[a].start != entries[b].start {
+				return entries[a].start < entries[b].start
+			}
+			return entries[a].entry.CourseCode < entries[b].entry.CourseCode
+		})
+		// Entries overlap when one starts before an earlier one ends. Course dates are
+		// ignored here, so courses in different halves of a term are flagged as well.
+		for a := range entries {
+			for b := a + 1; b < len(entries) && entries[b].start < entries[a].end; b++ {
+				entries[a].entry.Conflict = true
+				entries[b].entry.Conflict = true
+			}
+		}
+
+		dayDTO := dto.TimetableDayDTO{Day: day.Names()[0], Entries: []dto.TimetableEntryDTO{}}
+		for _, entry := range entries {
+			dayDTO.Entries = append(dayDTO.Entries, entry.entry)
+		}
+		timetable.Days = append(timetable.Days, dayDTO)
+	}
+
+	return timetable, nil
+}
+
+func (s *TimetableService) meetingDTOs(meetings []domain.CourseMeeting) []dto.CourseMeetingResponseDTO {
+	var dtos []dto.CourseMeetingResponseDTO
+	for _, meeting := range meetings {
+		dtos = append(dtos, *s.meetingDTOFactory.CreateFromEntity(&meeting))
+	}
+	return dtos
+}
+
+// checkRoomBookings rejects meetings whose room is used by another course or a
+// booking at the same time. The rooms are locked in ID order until the
+// transaction ends so concurrent requests cannot book them twice.
+func checkRoomBookings(roomRepo *repository.RoomRepository, meetingRepo *repository.MeetingRepository, course *domain.Course, meetings []domain.CourseMeeting) error {
+	seen := make(map[uint]bool)
+	var roomIDs []uint
+	for _, meeting := range meetings {
+		if meeting.RoomID != nil && !seen[*meeting.RoomID] {
+			seen[*meeting.RoomID] = true
+			roomIDs = append(roomIDs, *meeting.RoomID)
+		}
+	}
+	if len(roomIDs) == 0 {
+		return nil
+	}
+	sort.Slice(roomIDs, func(i, j int) bool { return roomIDs[i] < roomIDs[j] })
+	for _, id := range roomIDs {
+		if _, err := roomRepo.FindByIDForUpdate(id); err != nil {
+			return errors.NotFound("Room not found", err)
+		}
+	}
+
+	occupancy, err := loadRoomOccupancy(roomRepo, meetingRepo, roomIDs, course.StartDate, course.EndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return errors.InternalServerError("Failed to retrieve room usage", err)
+	}
+
+	var conflicts []dto.RoomConflictDTO
+	for _, meeting := range meetings {
+		if meeting.RoomID == nil {
+			continue
+		}
+		slot := schedule.Recurring{Slot: meetingSlot(&meeting), From: course.StartDate, To: course.EndDate}
+		conflicts = append(conflicts, occupancy.recurringConflicts(*meeting.RoomID, slot, course.ID)...)
+	}
+	if len(conflicts) > 0 {
+		return errors.Conflict("Room is already booked at that time", nil).
+			WithDetails(map[string]interface{}{"conflicts": conflicts})
+	}
+	return nil
+}
+
+// findScheduleConflicts returns the meetings of other courses that take place at
+// the same time as a meeting of the course. Only courses whose dates overlap
+// with the course are considered.
+func findScheduleConflicts(meetingRepo *repository.MeetingRepository, course *domain.Course, others []domain.Course) ([]dto.ScheduleConflictDTO, error) {
+	concurrent := make(map[uint]*domain.Course)
+	ids := []uint{course.ID}
+	for i := range others {
+		other := &others[i]
+		if other.ID == course.ID || !coursesConcurrent(course, other) {
+			continue
+		}
+		concurrent[other.ID] = other
+		ids = append(ids, other.ID)
+	}
+	if len(concurrent) == 0 {
+		return nil, nil
+	}
+
+	meetings, err := meetingRepo.FindByCourseIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var own []schedule.Slot
+	for _, meeting := range meetings {
+		if meeting.CourseID == course.ID {
+			own = append(own, meetingSlot(&meeting))
+		}
+	}
+
+	var conflicts []dto.ScheduleConflictDTO
+	for _, meeting := range meetings {
+		other := concurrent[meeting.CourseID]
+		if other == nil {
+			continue
+		}
+		slot := meetingSlot(&meeting)
+		for _, mine := range own {
+			if !mine.Overlaps(slot) {
+				continue
+			}
+			conflicts = append(conflicts, dto.ScheduleConflictDTO{
+				CourseID:   other.ID,
+				CourseCode: other.Code,
+				CourseName: other.Name,
+				Days:       (mine.Days & slot.Days).Names(),
+				StartTime:  schedule.FormatClock(slot.Start),
+				EndTime:    schedule.FormatClock(slot.End),
+			})
+		}
+	}
+
+	return conflicts, nil
+}
+
+func parseMeeting(entry *dto.CourseMeetingDTO) (schedule.Slot, error) {
+	return parseSlot(entry.Days, entry.StartTime, entry.EndTime)
+}
+
+// parseSlot builds a weekly slot from day names and "HH:MM" times
+func parseSlot(days []string, startTime, endTime string) (schedule.Slot, error) {
+	var slot schedule.Slot
+	var err error
+	if slot.Days, err = schedule.ParseDays(days); err != nil {
+		return slot, err
+	}
+	if slot.Start, err = schedule.ParseClock(startTime); err != nil {
+		return slot, err
+	}
+	if slot.End, err = schedule.ParseClock(endTime); err != nil {
+		return slot, err
+	}
+	return slot, slot.Validate()
+}
+
+func meetingSlot(meeting *domain.CourseMeeting) schedule.Slot {
+	return schedule.Slot{Days: schedule.Days(meeting.Days), Start: meeting.StartMinute, End: meeting.EndMinute}
+}
+
+// coursesConcurrent reports whether the dates of two courses overlap
+func coursesConcurrent(a, b *domain.Course) bool {
+	return !a.StartDate.After(b.EndDate) && !b.StartDate.After(a.EndDate)
+}
+
+// inTimetable selects the courses of a term, or the courses that have not
+// ended yet when termID is zero
+func inTimetable(course *domain.Course, termID uint) bool {
+	if termID != 0 {
+		return course.TermID != nil && *course.TermID == termID
+	}
+	return !course.EndDate.Before(time.Now().Truncate(24 * time.Hour))
+}
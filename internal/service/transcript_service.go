@@ -0,0 +1,144 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/grading"
+)
+
+const (
+	letterWithdrawn  = "W"
+	letterInProgress = "IP"
+)
+
+type TranscriptService struct {
+	studentRepo    *repository.StudentRepository
+	enrollmentRepo *repository.EnrollmentRepository
+	scale          *grading.Scale
+}
+
+func NewTranscriptService(studentRepo *repository.StudentRepository, enrollmentRepo *repository.EnrollmentRepository, scale *grading.Scale) *TranscriptService {
+	return &TranscriptService{
+		studentRepo:    studentRepo,
+		enrollmentRepo: enrollmentRepo,
+		scale:          scale,
+	}
+}
+
+// GetTranscript builds the student's academic record grouped by term, with letter
+// grades, attempted and earned credits, and credit-weighted term and cumulative GPA
+func (s *TranscriptService) GetTranscript(studentID uint) (*dto.TranscriptDTO, error) {
+	student, err := s.studentRepo.FindByID(studentID)
+	if err != nil {
+		return nil, errors.NotFound("Student not found", err)
+	}
+
+	enrollments, err := s.enrollmentRepo.FindByStudentID(studentID)
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to retrieve enrollments", err)
+	}
+
+	// Terms are listed chronologically, courses without a term come last
+	sort.SliceStable(enrollments, func(i, j int) bool {
+		ti, tj := enrollments[i].Course.Term, enrollments[j].Course.Term
+		if ti == nil || tj == nil {
+			return ti != nil && tj == nil
+		}
+		return ti.StartDate.Before(tj.StartDate)
+	})
+
+	transcript := &dto.TranscriptDTO{
+		StudentID:     student.ID,
+		StudentNumber: student.StudentID,
+		StudentName:   student.User.FirstName + " " + student.User.LastName,
+		Major:         student.Major,
+		Terms:         []dto.TranscriptTermDTO{},
+	}
+
+	var cumulative grading.GPA
+	termIndex := make(map[uint]int)
+	termGPAs := []*grading.GPA{}
+
+	for _, enrollment := range enrollments {
+		var key uint
+		if enrollment.Course.TermID != nil {
+			key = *enrollment.Course.TermID
+		}
+
+		idx, ok := termIndex[key]
+		if !ok {
+			term := dto.TranscriptTermDTO{TermName: "Unassigned", Courses: []dto.TranscriptCourseDTO{}}
+			if enrollment.Course.Term != nil {
+				term.TermID = enrollment.Course.TermID
+				term.TermName = enrollment.Course.Term.Name
+			}
+			transcript.Terms = append(transcript.Terms, term)
+			termGPAs = append(termGPAs, &grading.GPA{})
+			idx = len(transcript.Terms) - 1
+			termIndex[key] = idx
+		}
+
+		term := &transcript.Terms[idx]
+		course := s.transcriptCourse(&enrollment)
+		term.Courses = append(term.Courses, course)
+
+		if course.GradePoints == nil {
+			continue
+		}
+
+		credits := enrollment.Course.Credits
+		term.AttemptedCredits += credits
+		transcript.AttemptedCredits += credits
+		if passedCourse(s.scale, &enrollment) {
+			term.EarnedCredits += credits
+			transcript.EarnedCredits += credits
+		}
+		termGPAs[idx].Add(*course.GradePoints, credits)
+		cumulative.Add(*course.GradePoints, credits)
+	}
+
+	for i := range transcript.Terms {
+		transcript.Terms[i].GPA = termGPAs[i].Value()
+	}
+	transcript.CumulativeGPA = cumulative.Value()
+
+	return transcript, nil
+}
+
+// passedCourse reports whether an enrollment was completed with a grade the
+// scale counts as passing. Transcripts, degree audits and retake checks share
+// this rule so they never disagree about a course.
+func passedCourse(scale *grading.Scale, enrollment *domain.Enrollment) bool {
+	return enrollment.Status == domain.EnrollmentStatusCompleted && enrollment.Grade != nil && scale.IsPassing(*enrollment.Grade)
+}
+
+// transcriptCourse converts an enrollment into a transcript line. Only completed
+// enrollments with a grade carry grade points and count towards GPA.
+func (s *TranscriptService) transcriptCourse(enrollment *domain.Enrollment) dto.TranscriptCourseDTO {
+	course := dto.TranscriptCourseDTO{
+		CourseID:   enrollment.CourseID,
+		CourseCode: enrollment.Course.Code,
+		CourseName: enrollment.Course.Name,
+		Credits:    enrollment.Course.Credits,
+		Status:     string(enrollment.Status),
+		Grade:      enrollment.Grade,
+	}
+
+	switch {
+	case enrollment.Status == domain.EnrollmentStatusWithdrawn:
+		course.LetterGrade = letterWithdrawn
+	case enrollment.Status == domain.EnrollmentStatusCompleted && enrollment.Grade != nil:
+		band := s.scale.Lookup(*enrollment.Grade)
+		points := band.Points
+		course.LetterGrade = band.Letter
+		course.GradePoints = &points
+	default:
+		course.LetterGrade = letterInProgress
+	}
+
+	return course
+}
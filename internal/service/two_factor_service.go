@@ -0,0 +1,236 @@
+package service
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/dto"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/pkg/auth"
+	"github.com/Tretorhate/university-management-system/pkg/errors"
+	"github.com/Tretorhate/university-management-system/pkg/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const recoveryCodeCount = 10
+
+// TwoFactorService manages TOTP enrollment, verification and recovery codes
+type TwoFactorService struct {
+	userRepo         *repository.UserRepository
+	recoveryCodeRepo *repository.RecoveryCodeRepository
+	loginGuard       *LoginGuard
+	issuer           string
+	requiredRoles    map[domain.Role]bool
+}
+
+func NewTwoFactorService(
+	userRepo *repository.UserRepository,
+	recoveryCodeRepo *repository.RecoveryCodeRepository,
+	loginGuard *LoginGuard,
+	issuer string,
+	requiredRoles []domain.Role,
+) *TwoFactorService {
+	required := make(map[domain.Role]bool)
+	for _, role := range requiredRoles {
+		required[role] = true
+	}
+	return &TwoFactorService{
+		userRepo:         userRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+		loginGuard:       loginGuard,
+		issuer:           issuer,
+		requiredRoles:    required,
+	}
+}
+
+// IsRequired reports whether users with the role must use two-factor authentication
+func (s *TwoFactorService) IsRequired(role domain.Role) bool {
+	return s.requiredRoles[role]
+}
+
+// Setup generates a new secret for the user. It only takes effect after Activate
+// confirms that the authenticator app produces matching codes.
+func (s *TwoFactorService) Setup(userID uint) (*dto.TwoFactorSetupDTO, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.NotFound("User not found", err)
+	}
+	return s.setup(user)
+}
+
+// Activate enables two-factor authentication once the user proves the app is set
+// up, and returns fresh recovery codes. The codes are only shown this once.
+func (s *TwoFactorService) Activate(userID uint, code string) (*dto.TwoFactorActivatedDTO, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, errors.NotFound("User not found", err)
+	}
+
+	codes, err := s.activate(user, code)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TwoFactorActivatedDTO{RecoveryCodes: codes}, nil
+}
+
+// Disable turns off two-factor authentication after re-authenticating the user
+// with their password and a current code. Wrong passwords and codes count as
+// failed logins, so a stolen access token cannot be used to guess them.
+func (s *TwoFactorService) Disable(userID uint, clientIP string, req *dto.TwoFactorDisableRequest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return errors.NotFound("User not found", err)
+	}
+
+	if s.IsRequired(user.Role) {
+		return errors.Forbidden("Two-factor authentication is mandatory for this role", nil)
+	}
+
+	if err := s.loginGuard.Check(user.Email, clientIP); err != nil {
+		return err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		s.loginGuard.Failure(user.Email, clientIP, user)
+		return errors.Unauthorized("Password is incorrect", nil)
+	}
+	if err := s.verify(user, req.Code, ""); err != nil {
+		if appErr, ok := errors.IsAppError(err); ok && appErr.Code == http.StatusUnauthorized {
+			s.loginGuard.Failure(user.Email, clientIP, user)
+		}
+		return err
+	}
+	s.loginGuard.Success(user.Email)
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorEnabledAt = nil
+	user.TwoFactorLastStep = 0
+
+	return s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return errors.InternalServerError("Failed to disable two-factor authentication", err)
+		}
+		if err := repository.NewRecoveryCodeRepository(tx).Replace(user.ID, nil); err != nil {
+			return errors.InternalServerError("Failed to remove recovery codes", err)
+		}
+		return nil
+	})
+}
+
+func (s *TwoFactorService) setup(user *domain.User) (*dto.TwoFactorSetupDTO, error) {
+	if user.TwoFactorEnabled() {
+		return nil, errors.Conflict("Two-factor authentication is already enabled", nil)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.InternalServerError("Failed to generate secret", err)
+	}
+
+	user.TwoFactorSecret = secret
+	user.TwoFactorLastStep = 0
+	if err := s.userRepo.Update(user); err != nil {
+		return nil, errors.InternalServerError("Failed to save two-factor secret", err)
+	}
+
+	return &dto.TwoFactorSetupDTO{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+func (s *TwoFactorService) activate(user *domain.User, code string) ([]string, error) {
+	if user.TwoFactorEnabled() {
+		return nil, errors.Conflict("Two-factor authentication is already enabled", nil)
+	}
+	if user.TwoFactorSecret == "" {
+		return nil, errors.BadRequest("Two-factor setup has not been started", nil)
+	}
+
+	step, ok := totp.Validate(user.TwoFactorSecret, code, time.Now())
+	if !ok {
+		return nil, errors.BadRequest("Invalid authentication code", nil)
+	}
+
+	codes, records, err := newRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.TwoFactorEnabledAt = &now
+	user.TwoFactorLastStep = step
+
+	err = s.userRepo.Transaction(func(tx *repository.Repository) error {
+		if err := repository.NewUserRepository(tx).Update(user); err != nil {
+			return errors.InternalServerError("Failed to enable two-factor authentication", err)
+		}
+		if err := repository.NewRecoveryCodeRepository(tx).Replace(user.ID, records); err != nil {
+			return errors.InternalServerError("Failed to store recovery codes", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// verify checks an authenticator code, or a recovery code when no code is given.
+// Each code is accepted only once.
+func (s *TwoFactorService) verify(user *domain.User, code, recoveryCode string) error {
+	if !user.TwoFactorEnabled() {
+		return errors.BadRequest("Two-factor authentication is not enabled", nil)
+	}
+
+	if code != "" {
+		step, ok := totp.Validate(user.TwoFactorSecret, code, time.Now())
+		if !ok {
+			return errors.Unauthorized("Invalid authentication code", nil)
+		}
+
+		// The step only moves forward in a single conditional update, so a code
+		// replayed by a concurrent login loses the race instead of passing twice
+		recorded, err := s.userRepo.RecordTwoFactorStep(user.ID, step)
+		if err != nil {
+			return errors.InternalServerError("Failed to record authentication code", err)
+		}
+		if !recorded {
+			return errors.Unauthorized("Invalid authentication code", nil)
+		}
+		user.TwoFactorLastStep = step
+		return nil
+	}
+
+	redeemed, err := s.recoveryCodeRepo.Redeem(user.ID, hashRecoveryCode(recoveryCode), time.Now())
+	if err != nil {
+		return errors.InternalServerError("Failed to redeem recovery code", err)
+	}
+	if !redeemed {
+		return errors.Unauthorized("Invalid recovery code", nil)
+	}
+	return nil
+}
+
+// newRecoveryCodes returns the codes to show the user and the hashed records to store
+func newRecoveryCodes(userID uint) ([]string, []domain.RecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	records := make([]domain.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newVerificationCode()
+		if err != nil {
+			return nil, nil, errors.InternalServerError("Failed to generate recovery codes", err)
+		}
+		codes = append(codes, code)
+		records = append(records, domain.RecoveryCode{UserID: userID, CodeHash: hashRecoveryCode(code)})
+	}
+	return codes, records, nil
+}
+
+// hashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return auth.HashToken(normalized)
+}
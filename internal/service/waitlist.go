@@ -0,0 +1,129 @@
+package service
+
+import (
+	stdErrors "errors"
+	"time"
+
+	"github.com/Tretorhate/university-management-system/internal/domain"
+	"github.com/Tretorhate/university-management-system/internal/repository"
+	"github.com/Tretorhate/university-management-system/internal/service/factory"
+	"gorm.io/gorm"
+)
+
+// waitlistPromotion lists the enrollments created from a waitlist and the
+// entries removed from it because the student no longer qualifies
+type waitlistPromotion struct {
+	promoted []domain.Enrollment
+	skipped  []domain.WaitlistEntry
+}
+
+// promoteFromWaitlist fills every free seat of a course with students from the
+// head of its waitlist. Students who no longer meet the prerequisites or whose
+// timetable now clashes with the course are taken off the waitlist instead. It
+// must run inside a transaction so that the seat count, the new enrollments and
+// the waitlist reordering are committed together.
+func promoteFromWaitlist(tx *repository.Repository, courseID uint) (*waitlistPromotion, error) {
+	courseRepo := repository.NewCourseRepository(tx)
+	enrollmentRepo := repository.NewEnrollmentRepository(tx)
+	waitlistRepo := repository.NewWaitlistRepository(tx)
+
+	course, err := courseRepo.FindByIDForUpdate(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &waitlistPromotion{}
+	for {
+		enrolled, err := enrollmentRepo.CountSeatedByCourseID(courseID)
+		if err != nil {
+			return nil, err
+		}
+		if course.IsFull(enrolled) {
+			return result, nil
+		}
+
+		head, err := waitlistRepo.FindHead(courseID)
+		if stdErrors.Is(err, gorm.ErrRecordNotFound) {
+			// Waitlist is empty
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := waitlistRepo.Remove(head); err != nil {
+			return nil, err
+		}
+
+		eligible, err := canPromote(tx, course, head)
+		if err != nil {
+			return nil, err
+		}
+		if !eligible {
+			result.skipped = append(result.skipped, *head)
+			continue
+		}
+
+		enrollment := domain.Enrollment{
+			StudentID:  head.StudentID,
+			CourseID:   courseID,
+			Status:     domain.EnrollmentStatusEnrolled,
+			EnrollDate: time.Now(),
+		}
+		if err := enrollmentRepo.Create(&enrollment); err != nil {
+			return nil, err
+		}
+		result.promoted = append(result.promoted, enrollment)
+	}
+}
+
+// canPromote checks a waitlisted student against the prerequisites of the
+// course and, unless they overrode conflicts when joining, against their
+// timetable. Both may have changed while the student was waiting.
+func canPromote(tx *repository.Repository, course *domain.Course, entry *domain.WaitlistEntry) (bool, error) {
+	history, err := repository.NewEnrollmentRepository(tx).FindByStudentID(entry.StudentID)
+	if err != nil {
+		return false, err
+	}
+
+	unmet, err := findUnmetPrerequisites(repository.NewCoursePrerequisiteRepository(tx), course.ID, history)
+	if err != nil {
+		return false, err
+	}
+	if len(unmet) > 0 {
+		return false, nil
+	}
+	if entry.OverrideConflicts {
+		return true, nil
+	}
+
+	var current []domain.Course
+	for _, e := range history {
+		if e.Status == domain.EnrollmentStatusEnrolled {
+			current = append(current, e.Course)
+		}
+	}
+	conflicts, err := findScheduleConflicts(repository.NewMeetingRepository(tx), course, current)
+	if err != nil {
+		return false, err
+	}
+	return len(conflicts) == 0, nil
+}
+
+// recordPromotions adds the enrollments created from the waitlist and the
+// skipped waitlist entries to the audit log, in the transaction that promoted them
+func recordPromotions(tx *repository.Repository, auditService *AuditService, actor domain.Actor, result *waitlistPromotion) error {
+	enrollmentDTOFactory := factory.NewEnrollmentResponseDTOFactory()
+	for _, enrollment := range result.promoted {
+		if err := auditService.Record(tx, actor, domain.AuditActionCreate, domain.AuditEntityEnrollment, enrollment.ID, nil, enrollmentDTOFactory.CreateFromEntity(&enrollment)); err != nil {
+			return err
+		}
+	}
+	waitlistDTOFactory := factory.NewWaitlistEntryResponseDTOFactory()
+	for _, entry := range result.skipped {
+		if err := auditService.Record(tx, actor, domain.AuditActionDelete, domain.AuditEntityWaitlist, entry.ID, waitlistDTOFactory.CreateFromEntity(&entry), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
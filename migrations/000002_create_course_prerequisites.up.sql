@@ -0,0 +1,15 @@
+-- Create course prerequisites table
+CREATE TABLE IF NOT EXISTS public.course_prerequisites (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    prerequisite_id INTEGER NOT NULL,
+    min_grade DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_course_prerequisites_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_course_prerequisites_prerequisite FOREIGN KEY (prerequisite_id) REFERENCES public.courses(id) ON DELETE RESTRICT,
+    CONSTRAINT unique_course_prerequisite UNIQUE (course_id, prerequisite_id),
+    CONSTRAINT check_course_prerequisite_not_self CHECK (course_id <> prerequisite_id),
+    CONSTRAINT check_course_prerequisite_min_grade CHECK (min_grade IS NULL OR (min_grade >= 0 AND min_grade <= 100))
+);
+
+CREATE INDEX IF NOT EXISTS idx_course_prerequisites_prerequisite_id ON public.course_prerequisites(prerequisite_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS public.waitlist_entries;
+
+ALTER TABLE public.courses DROP CONSTRAINT IF EXISTS check_courses_capacity;
+ALTER TABLE public.courses DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,18 @@
+-- Add seat limit to courses (0 means unlimited)
+ALTER TABLE public.courses ADD COLUMN IF NOT EXISTS capacity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE public.courses ADD CONSTRAINT check_courses_capacity CHECK (capacity >= 0);
+
+-- Create waitlist entries table
+CREATE TABLE IF NOT EXISTS public.waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL,
+    course_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_waitlist_entries_student FOREIGN KEY (student_id) REFERENCES public.students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_waitlist_entries_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT unique_waitlist_student_course UNIQUE (student_id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_course_position ON public.waitlist_entries(course_id, position);
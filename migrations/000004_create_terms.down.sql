@@ -0,0 +1,5 @@
+ALTER TABLE public.courses DROP CONSTRAINT IF EXISTS fk_courses_term;
+DROP INDEX IF EXISTS idx_courses_term_id;
+ALTER TABLE public.courses DROP COLUMN IF EXISTS term_id;
+
+DROP TABLE IF EXISTS public.terms;
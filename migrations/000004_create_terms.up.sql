@@ -0,0 +1,25 @@
+-- Create terms table
+CREATE TABLE IF NOT EXISTS public.terms (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    add_drop_deadline TIMESTAMP WITH TIME ZONE NOT NULL,
+    grading_deadline TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT check_terms_date_range CHECK (end_date > start_date),
+    CONSTRAINT check_terms_add_drop_deadline CHECK (add_drop_deadline BETWEEN start_date AND end_date),
+    CONSTRAINT check_terms_grading_deadline CHECK (grading_deadline >= end_date)
+);
+
+-- Link courses to terms
+ALTER TABLE public.courses ADD COLUMN IF NOT EXISTS term_id INTEGER;
+ALTER TABLE public.courses ADD CONSTRAINT fk_courses_term FOREIGN KEY (term_id) REFERENCES public.terms(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_courses_term_id ON public.courses(term_id);
+
+-- The terms table is empty at this point, so existing courses keep a NULL term_id.
+-- Once terms are created, link their courses with POST /api/terms/:id/match-courses
+-- (TermService.MatchCourses), which assigns every course without a term whose
+-- dates fall inside the term.
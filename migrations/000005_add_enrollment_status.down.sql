@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS unique_active_student_course;
+ALTER TABLE public.enrollments ADD CONSTRAINT unique_student_course UNIQUE (student_id, course_id);
+
+ALTER TABLE public.enrollments DROP CONSTRAINT IF EXISTS fk_enrollments_late_drop_approver;
+ALTER TABLE public.enrollments DROP CONSTRAINT IF EXISTS check_enrollments_status;
+ALTER TABLE public.enrollments DROP COLUMN IF EXISTS late_drop_reason;
+ALTER TABLE public.enrollments DROP COLUMN IF EXISTS late_drop_approved_by;
+ALTER TABLE public.enrollments DROP COLUMN IF EXISTS dropped_at;
+ALTER TABLE public.enrollments DROP COLUMN IF EXISTS status;
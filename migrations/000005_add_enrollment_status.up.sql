@@ -0,0 +1,16 @@
+-- Track the lifecycle of an enrollment
+ALTER TABLE public.enrollments ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'ENROLLED';
+ALTER TABLE public.enrollments ADD COLUMN IF NOT EXISTS dropped_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE public.enrollments ADD COLUMN IF NOT EXISTS late_drop_approved_by INTEGER;
+ALTER TABLE public.enrollments ADD COLUMN IF NOT EXISTS late_drop_reason TEXT;
+ALTER TABLE public.enrollments ADD CONSTRAINT check_enrollments_status CHECK (status IN ('ENROLLED', 'DROPPED', 'WITHDRAWN', 'COMPLETED'));
+ALTER TABLE public.enrollments ADD CONSTRAINT fk_enrollments_late_drop_approver FOREIGN KEY (late_drop_approved_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+-- Graded enrollments are considered completed
+UPDATE public.enrollments SET status = 'COMPLETED' WHERE grade IS NOT NULL AND deleted_at IS NULL;
+
+-- Withdrawals are retained, so a student may enroll in the same course again later.
+-- Only one active enrollment per student and course is allowed.
+ALTER TABLE public.enrollments DROP CONSTRAINT IF EXISTS unique_student_course;
+CREATE UNIQUE INDEX IF NOT EXISTS unique_active_student_course ON public.enrollments(student_id, course_id)
+    WHERE status = 'ENROLLED' AND deleted_at IS NULL;
@@ -0,0 +1,15 @@
+-- Create issued documents table for verifying official transcripts and certificates
+CREATE TABLE IF NOT EXISTS public.issued_documents (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(32) UNIQUE NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    student_id INTEGER NOT NULL,
+    issued_by INTEGER NOT NULL,
+    content_hash VARCHAR(64) NOT NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT fk_issued_documents_student FOREIGN KEY (student_id) REFERENCES public.students(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_issued_documents_issuer FOREIGN KEY (issued_by) REFERENCES public.users(id) ON DELETE RESTRICT,
+    CONSTRAINT check_issued_documents_type CHECK (type IN ('TRANSCRIPT', 'ENROLLMENT_CERTIFICATE'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_issued_documents_student_id ON public.issued_documents(student_id);
@@ -0,0 +1,19 @@
+-- Create invitations table for admin-issued TEACHER and ADMIN sign-ups
+CREATE TABLE IF NOT EXISTS public.invitations (
+    id SERIAL PRIMARY KEY,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    user_id INTEGER,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_invitations_user FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_invitations_creator FOREIGN KEY (created_by) REFERENCES public.users(id) ON DELETE RESTRICT,
+    CONSTRAINT check_invitations_role CHECK (role IN ('ADMIN', 'TEACHER'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitations_email ON public.invitations(email);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.user_tokens;
+ALTER TABLE public.users DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.security_events;
+DROP TABLE IF EXISTS public.login_attempts;
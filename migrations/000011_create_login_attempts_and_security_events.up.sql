@@ -0,0 +1,23 @@
+-- Failed login tracking shared between application instances
+CREATE TABLE IF NOT EXISTS public.login_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INTEGER NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE
+);
+
+-- Lockouts and unlocks for security review
+CREATE TABLE IF NOT EXISTS public.security_events (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(30) NOT NULL,
+    user_id INTEGER,
+    email VARCHAR(255),
+    ip VARCHAR(64),
+    actor_id INTEGER,
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_security_events_user FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_security_events_actor FOREIGN KEY (actor_id) REFERENCES public.users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_security_events_created_at ON public.security_events(created_at);
@@ -0,0 +1,8 @@
+ALTER TABLE public.invitations DROP CONSTRAINT IF EXISTS fk_invitations_role;
+ALTER TABLE public.users DROP CONSTRAINT IF EXISTS fk_users_role;
+
+ALTER TABLE public.invitations
+    ADD CONSTRAINT check_invitations_role CHECK (role IN ('ADMIN', 'TEACHER'));
+
+DROP TABLE IF EXISTS public.role_permissions;
+DROP TABLE IF EXISTS public.roles;
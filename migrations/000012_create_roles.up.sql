@@ -0,0 +1,52 @@
+-- Roles are named permission sets; users reference them by name
+CREATE TABLE IF NOT EXISTS public.roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    built_in BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS public.role_permissions (
+    role_name VARCHAR(50) NOT NULL,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_name, permission),
+    CONSTRAINT fk_role_permissions_role FOREIGN KEY (role_name) REFERENCES public.roles(name) ON DELETE CASCADE
+);
+
+-- ADMIN is granted every permission in code and has no rows of its own
+INSERT INTO public.roles (name, description, built_in) VALUES
+    ('ADMIN', 'Full access to the system', TRUE),
+    ('TEACHER', 'Manages and grades own courses', TRUE),
+    ('STUDENT', 'Reads own records', TRUE),
+    ('REGISTRAR', 'Manages student records, terms and enrollments', TRUE),
+    ('DEPARTMENT_HEAD', 'Oversees all courses and grades', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('TEACHER', 'student:read-all'),
+    ('TEACHER', 'course:create'),
+    ('TEACHER', 'course:update-own'),
+    ('TEACHER', 'enrollment:manage-own'),
+    ('TEACHER', 'grade:write'),
+    ('REGISTRAR', 'student:create'),
+    ('REGISTRAR', 'student:read-all'),
+    ('REGISTRAR', 'student:update'),
+    ('REGISTRAR', 'term:manage'),
+    ('REGISTRAR', 'enrollment:manage-all'),
+    ('REGISTRAR', 'enrollment:late-drop'),
+    ('REGISTRAR', 'waitlist:read'),
+    ('DEPARTMENT_HEAD', 'student:read-all'),
+    ('DEPARTMENT_HEAD', 'course:update-all'),
+    ('DEPARTMENT_HEAD', 'grade:write-all'),
+    ('DEPARTMENT_HEAD', 'waitlist:read')
+ON CONFLICT DO NOTHING;
+
+-- Users and invitations may now reference any role
+ALTER TABLE public.invitations DROP CONSTRAINT IF EXISTS check_invitations_role;
+
+ALTER TABLE public.users
+    ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES public.roles(name) ON UPDATE CASCADE;
+
+ALTER TABLE public.invitations
+    ADD CONSTRAINT fk_invitations_role FOREIGN KEY (role) REFERENCES public.roles(name) ON UPDATE CASCADE;
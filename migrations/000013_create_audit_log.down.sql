@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_audit_log_append_only ON public.audit_log;
+DROP FUNCTION IF EXISTS public.audit_log_append_only();
+DROP TABLE IF EXISTS public.audit_log;
@@ -0,0 +1,6 @@
+DELETE FROM public.role_permissions WHERE permission = 'grade:approve-change';
+DROP TABLE IF EXISTS public.grade_history;
+DROP TABLE IF EXISTS public.grade_change_requests;
+ALTER TABLE public.courses
+    DROP COLUMN IF EXISTS grades_finalized_by,
+    DROP COLUMN IF EXISTS grades_finalized_at;
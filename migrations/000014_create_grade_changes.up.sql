@@ -0,0 +1,52 @@
+-- Grades are locked once a course is finalized
+ALTER TABLE public.courses
+    ADD COLUMN IF NOT EXISTS grades_finalized_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS grades_finalized_by INTEGER REFERENCES public.users(id) ON DELETE SET NULL;
+
+-- Requests to change a locked grade, reviewed by the head of the course's department or an admin
+CREATE TABLE IF NOT EXISTS public.grade_change_requests (
+    id SERIAL PRIMARY KEY,
+    enrollment_id INTEGER NOT NULL,
+    old_grade DOUBLE PRECISION,
+    new_grade DOUBLE PRECISION NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    requested_by INTEGER NOT NULL,
+    reviewed_by INTEGER,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_grade_change_requests_enrollment FOREIGN KEY (enrollment_id) REFERENCES public.enrollments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_grade_change_requests_requester FOREIGN KEY (requested_by) REFERENCES public.users(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_grade_change_requests_reviewer FOREIGN KEY (reviewed_by) REFERENCES public.users(id) ON DELETE SET NULL,
+    CONSTRAINT check_grade_change_requests_status CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    CONSTRAINT check_grade_change_requests_grade CHECK (new_grade >= 0 AND new_grade <= 100)
+);
+
+-- At most one open request per enrollment
+CREATE UNIQUE INDEX IF NOT EXISTS idx_grade_change_requests_pending
+    ON public.grade_change_requests(enrollment_id) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_grade_change_requests_status ON public.grade_change_requests(status);
+
+-- Every change of a grade
+CREATE TABLE IF NOT EXISTS public.grade_history (
+    id SERIAL PRIMARY KEY,
+    enrollment_id INTEGER NOT NULL,
+    old_grade DOUBLE PRECISION,
+    new_grade DOUBLE PRECISION,
+    source VARCHAR(20) NOT NULL,
+    change_request_id INTEGER,
+    changed_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_grade_history_enrollment FOREIGN KEY (enrollment_id) REFERENCES public.enrollments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_grade_history_request FOREIGN KEY (change_request_id) REFERENCES public.grade_change_requests(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_grade_history_enrollment ON public.grade_history(enrollment_id, created_at);
+
+-- Department heads review the requests in courses of the department they head;
+-- ADMIN holds grade:approve-change-all and reviews requests of any department
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('DEPARTMENT_HEAD', 'grade:approve-change')
+ON CONFLICT DO NOTHING;
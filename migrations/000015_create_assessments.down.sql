@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS public.assessment_scores;
+DROP TABLE IF EXISTS public.assessment_components;
+ALTER TABLE public.courses
+    DROP CONSTRAINT IF EXISTS check_courses_grade_decimals,
+    DROP CONSTRAINT IF EXISTS check_courses_grade_rounding,
+    DROP COLUMN IF EXISTS grade_decimals,
+    DROP COLUMN IF EXISTS grade_rounding;
@@ -0,0 +1,45 @@
+-- Rounding of grades computed from assessment scores
+ALTER TABLE public.courses
+    ADD COLUMN IF NOT EXISTS grade_rounding VARCHAR(10) NOT NULL DEFAULT 'HALF_UP',
+    ADD COLUMN IF NOT EXISTS grade_decimals INTEGER NOT NULL DEFAULT 2,
+    ADD CONSTRAINT check_courses_grade_rounding CHECK (grade_rounding IN ('HALF_UP', 'HALF_EVEN', 'UP', 'DOWN')),
+    ADD CONSTRAINT check_courses_grade_decimals CHECK (grade_decimals BETWEEN 0 AND 2);
+
+-- Weighted parts of a course grade
+CREATE TABLE IF NOT EXISTS public.assessment_components (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    weight DOUBLE PRECISION NOT NULL,
+    max_score DOUBLE PRECISION NOT NULL DEFAULT 100,
+    item_count INTEGER NOT NULL DEFAULT 1,
+    drop_lowest INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_assessment_components_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT uq_assessment_components_name UNIQUE (course_id, name),
+    CONSTRAINT check_assessment_components_weight CHECK (weight > 0 AND weight <= 100),
+    CONSTRAINT check_assessment_components_max_score CHECK (max_score > 0),
+    CONSTRAINT check_assessment_components_items CHECK (item_count >= 1 AND drop_lowest >= 0 AND drop_lowest < item_count)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_components_course ON public.assessment_components(course_id);
+
+-- Scores per student per component item
+CREATE TABLE IF NOT EXISTS public.assessment_scores (
+    id SERIAL PRIMARY KEY,
+    component_id INTEGER NOT NULL,
+    enrollment_id INTEGER NOT NULL,
+    item INTEGER NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    graded_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_assessment_scores_component FOREIGN KEY (component_id) REFERENCES public.assessment_components(id) ON DELETE CASCADE,
+    CONSTRAINT fk_assessment_scores_enrollment FOREIGN KEY (enrollment_id) REFERENCES public.enrollments(id) ON DELETE CASCADE,
+    CONSTRAINT uq_assessment_scores_item UNIQUE (component_id, enrollment_id, item),
+    CONSTRAINT check_assessment_scores_item CHECK (item >= 1),
+    CONSTRAINT check_assessment_scores_score CHECK (score >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assessment_scores_enrollment ON public.assessment_scores(enrollment_id);
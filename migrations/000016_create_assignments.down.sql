@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS public.submissions;
+DROP TABLE IF EXISTS public.assignments;
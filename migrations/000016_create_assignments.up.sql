@@ -0,0 +1,51 @@
+CREATE TABLE IF NOT EXISTS public.assignments (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    max_points DOUBLE PRECISION NOT NULL,
+    late_policy VARCHAR(10) NOT NULL DEFAULT 'REJECT',
+    late_penalty_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_late_days INTEGER NOT NULL DEFAULT 0,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_assignments_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_assignments_creator FOREIGN KEY (created_by) REFERENCES public.users(id) ON DELETE RESTRICT,
+    CONSTRAINT check_assignments_max_points CHECK (max_points > 0),
+    CONSTRAINT check_assignments_late_policy CHECK (late_policy IN ('REJECT', 'ACCEPT', 'PENALTY')),
+    CONSTRAINT check_assignments_late_penalty CHECK (late_penalty_percent >= 0 AND late_penalty_percent <= 100),
+    CONSTRAINT check_assignments_max_late_days CHECK (max_late_days >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignments_course ON public.assignments(course_id);
+CREATE INDEX IF NOT EXISTS idx_assignments_deleted_at ON public.assignments(deleted_at);
+
+CREATE TABLE IF NOT EXISTS public.submissions (
+    id SERIAL PRIMARY KEY,
+    assignment_id INTEGER NOT NULL,
+    student_id INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    days_late INTEGER NOT NULL DEFAULT 0,
+    penalty_percent DOUBLE PRECISION NOT NULL DEFAULT 0,
+    score DOUBLE PRECISION,
+    final_score DOUBLE PRECISION,
+    feedback TEXT,
+    graded_by INTEGER,
+    graded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_submissions_assignment FOREIGN KEY (assignment_id) REFERENCES public.assignments(id) ON DELETE CASCADE,
+    CONSTRAINT fk_submissions_student FOREIGN KEY (student_id) REFERENCES public.students(id) ON DELETE CASCADE,
+    CONSTRAINT fk_submissions_grader FOREIGN KEY (graded_by) REFERENCES public.users(id) ON DELETE SET NULL,
+    CONSTRAINT uq_submissions_student UNIQUE (assignment_id, student_id),
+    CONSTRAINT check_submissions_score CHECK (score IS NULL OR score >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_submissions_student ON public.submissions(student_id);
@@ -0,0 +1,4 @@
+DELETE FROM public.role_permissions WHERE permission IN ('attendance:write', 'attendance:write-all');
+DROP TABLE IF EXISTS public.attendance_alerts;
+DROP TABLE IF EXISTS public.attendance_records;
+DROP TABLE IF EXISTS public.class_sessions;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS public.class_sessions (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    room VARCHAR(100),
+    topic VARCHAR(255),
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_class_sessions_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT check_class_sessions_time CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_class_sessions_course ON public.class_sessions(course_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS public.attendance_records (
+    id SERIAL PRIMARY KEY,
+    session_id INTEGER NOT NULL,
+    enrollment_id INTEGER NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    note VARCHAR(500),
+    marked_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_attendance_records_session FOREIGN KEY (session_id) REFERENCES public.class_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_attendance_records_enrollment FOREIGN KEY (enrollment_id) REFERENCES public.enrollments(id) ON DELETE CASCADE,
+    CONSTRAINT uq_attendance_records_enrollment UNIQUE (session_id, enrollment_id),
+    CONSTRAINT check_attendance_records_status CHECK (status IN ('PRESENT', 'ABSENT', 'LATE', 'EXCUSED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_records_enrollment ON public.attendance_records(enrollment_id);
+
+CREATE TABLE IF NOT EXISTS public.attendance_alerts (
+    id SERIAL PRIMARY KEY,
+    enrollment_id INTEGER NOT NULL UNIQUE,
+    absences INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_attendance_alerts_enrollment FOREIGN KEY (enrollment_id) REFERENCES public.enrollments(id) ON DELETE CASCADE
+);
+
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('TEACHER', 'attendance:write'),
+    ('REGISTRAR', 'attendance:write-all'),
+    ('DEPARTMENT_HEAD', 'attendance:write-all')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS public.course_meetings (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    days SMALLINT NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+    room VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_course_meetings_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT check_course_meetings_days CHECK (days > 0 AND days < 128),
+    CONSTRAINT check_course_meetings_times CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX IF NOT EXISTS idx_course_meetings_course ON public.course_meetings(course_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS public.calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_calendar_feeds_user FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE
+);
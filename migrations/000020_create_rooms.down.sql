@@ -0,0 +1,7 @@
+DELETE FROM public.role_permissions WHERE permission IN ('room:manage', 'room:book');
+ALTER TABLE public.course_meetings DROP CONSTRAINT IF EXISTS fk_course_meetings_room;
+DROP INDEX IF EXISTS idx_course_meetings_room;
+ALTER TABLE public.course_meetings DROP COLUMN IF EXISTS room_id;
+DROP TABLE IF EXISTS public.room_bookings;
+DROP TABLE IF EXISTS public.room_features;
+DROP TABLE IF EXISTS public.rooms;
@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS public.rooms (
+    id SERIAL PRIMARY KEY,
+    building VARCHAR(100) NOT NULL,
+    number VARCHAR(20) NOT NULL,
+    capacity INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT uq_rooms_building_number UNIQUE (building, number),
+    CONSTRAINT check_rooms_capacity CHECK (capacity > 0)
+);
+
+CREATE TABLE IF NOT EXISTS public.room_features (
+    room_id INTEGER NOT NULL,
+    feature VARCHAR(50) NOT NULL,
+    PRIMARY KEY (room_id, feature),
+    CONSTRAINT fk_room_features_room FOREIGN KEY (room_id) REFERENCES public.rooms(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.room_bookings (
+    id SERIAL PRIMARY KEY,
+    room_id INTEGER NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    purpose VARCHAR(10) NOT NULL,
+    course_id INTEGER,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    booked_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_room_bookings_room FOREIGN KEY (room_id) REFERENCES public.rooms(id) ON DELETE RESTRICT,
+    CONSTRAINT fk_room_bookings_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room_bookings_user FOREIGN KEY (booked_by) REFERENCES public.users(id) ON DELETE RESTRICT,
+    CONSTRAINT check_room_bookings_purpose CHECK (purpose IN ('EXAM', 'EVENT', 'OTHER')),
+    CONSTRAINT check_room_bookings_times CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_room_bookings_room_time ON public.room_bookings(room_id, starts_at);
+
+ALTER TABLE public.course_meetings ADD COLUMN IF NOT EXISTS room_id INTEGER;
+ALTER TABLE public.course_meetings ADD CONSTRAINT fk_course_meetings_room FOREIGN KEY (room_id) REFERENCES public.rooms(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_course_meetings_room ON public.course_meetings(room_id);
+
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('REGISTRAR', 'room:manage'),
+    ('REGISTRAR', 'room:book'),
+    ('TEACHER', 'room:book'),
+    ('DEPARTMENT_HEAD', 'room:book')
+ON CONFLICT DO NOTHING;
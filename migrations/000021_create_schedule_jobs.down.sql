@@ -0,0 +1,4 @@
+DELETE FROM public.role_permissions WHERE permission = 'schedule:generate';
+DROP TABLE IF EXISTS public.teacher_preferred_slots;
+DROP INDEX IF EXISTS public.idx_schedule_jobs_active_term;
+DROP TABLE IF EXISTS public.schedule_jobs;
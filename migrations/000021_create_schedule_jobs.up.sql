@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS public.schedule_jobs (
+    id SERIAL PRIMARY KEY,
+    term_id INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    options JSONB,
+    result JSONB,
+    error TEXT,
+    created_by INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    applied_at TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT fk_schedule_jobs_term FOREIGN KEY (term_id) REFERENCES public.terms(id) ON DELETE CASCADE,
+    CONSTRAINT check_schedule_jobs_status CHECK (status IN ('PENDING', 'RUNNING', 'SUCCEEDED', 'FAILED', 'APPLIED')),
+    CONSTRAINT check_schedule_jobs_progress CHECK (progress >= 0 AND progress <= 100)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_jobs_term ON public.schedule_jobs(term_id, created_at);
+
+-- At most one pending or running job per term
+CREATE UNIQUE INDEX IF NOT EXISTS idx_schedule_jobs_active_term
+    ON public.schedule_jobs(term_id) WHERE status IN ('PENDING', 'RUNNING');
+
+CREATE TABLE IF NOT EXISTS public.teacher_preferred_slots (
+    id SERIAL PRIMARY KEY,
+    teacher_id INTEGER NOT NULL,
+    days SMALLINT NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_teacher_preferred_slots_teacher FOREIGN KEY (teacher_id) REFERENCES public.teachers(id) ON DELETE CASCADE,
+    CONSTRAINT check_teacher_preferred_slots_days CHECK (days > 0 AND days < 128),
+    CONSTRAINT check_teacher_preferred_slots_times CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+
+CREATE INDEX IF NOT EXISTS idx_teacher_preferred_slots_teacher ON public.teacher_preferred_slots(teacher_id);
+
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('REGISTRAR', 'schedule:generate')
+ON CONFLICT DO NOTHING;
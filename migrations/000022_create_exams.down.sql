@@ -0,0 +1,2 @@
+DELETE FROM public.role_permissions WHERE permission = 'exam:manage';
+DROP TABLE IF EXISTS public.exams;
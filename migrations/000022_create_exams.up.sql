@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS public.exams (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    duration INTEGER NOT NULL,
+    starts_at TIMESTAMP WITH TIME ZONE,
+    locked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_exams_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE,
+    CONSTRAINT check_exams_duration CHECK (duration > 0 AND duration <= 1440)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exams_course ON public.exams(course_id);
+CREATE INDEX IF NOT EXISTS idx_exams_starts_at ON public.exams(starts_at);
+
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('REGISTRAR', 'exam:manage')
+ON CONFLICT DO NOTHING;
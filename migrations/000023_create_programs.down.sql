@@ -0,0 +1,7 @@
+DELETE FROM public.role_permissions WHERE permission = 'program:manage';
+ALTER TABLE public.students DROP CONSTRAINT IF EXISTS fk_students_program;
+DROP INDEX IF EXISTS idx_students_program;
+ALTER TABLE public.students DROP COLUMN IF EXISTS program_id;
+DROP TABLE IF EXISTS public.requirement_courses;
+DROP TABLE IF EXISTS public.requirement_groups;
+DROP TABLE IF EXISTS public.programs;
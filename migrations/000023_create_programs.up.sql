@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS public.programs (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    catalog_year INTEGER NOT NULL,
+    total_credits INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_program_catalog UNIQUE (code, catalog_year),
+    CONSTRAINT check_programs_total_credits CHECK (total_credits > 0)
+);
+
+CREATE TABLE IF NOT EXISTS public.requirement_groups (
+    id SERIAL PRIMARY KEY,
+    program_id INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    min_credits INTEGER NOT NULL DEFAULT 0,
+    position INTEGER NOT NULL,
+    CONSTRAINT fk_requirement_groups_program FOREIGN KEY (program_id) REFERENCES public.programs(id) ON DELETE CASCADE,
+    CONSTRAINT check_requirement_groups_type CHECK (type IN ('CORE', 'ELECTIVE')),
+    CONSTRAINT check_requirement_groups_min_credits CHECK (min_credits >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_requirement_groups_program ON public.requirement_groups(program_id);
+
+CREATE TABLE IF NOT EXISTS public.requirement_courses (
+    group_id INTEGER NOT NULL,
+    course_id INTEGER NOT NULL,
+    PRIMARY KEY (group_id, course_id),
+    CONSTRAINT fk_requirement_courses_group FOREIGN KEY (group_id) REFERENCES public.requirement_groups(id) ON DELETE CASCADE,
+    CONSTRAINT fk_requirement_courses_course FOREIGN KEY (course_id) REFERENCES public.courses(id) ON DELETE CASCADE
+);
+
+ALTER TABLE public.students ADD COLUMN IF NOT EXISTS program_id INTEGER;
+ALTER TABLE public.students ADD CONSTRAINT fk_students_program FOREIGN KEY (program_id) REFERENCES public.programs(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_students_program ON public.students(program_id);
+
+INSERT INTO public.role_permissions (role_name, permission) VALUES
+    ('REGISTRAR', 'program:manage'),
+    ('DEPARTMENT_HEAD', 'program:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,20 @@
+DELETE FROM public.role_permissions WHERE permission = 'department:manage';
+
+ALTER TABLE public.programs DROP CONSTRAINT IF EXISTS fk_programs_department;
+DROP INDEX IF EXISTS idx_programs_department;
+ALTER TABLE public.programs DROP COLUMN IF EXISTS department_id;
+
+ALTER TABLE public.courses DROP CONSTRAINT IF EXISTS fk_courses_department;
+DROP INDEX IF EXISTS idx_courses_department;
+ALTER TABLE public.courses DROP COLUMN IF EXISTS department_id;
+
+ALTER TABLE public.teachers ADD COLUMN IF NOT EXISTS department VARCHAR(255);
+UPDATE public.teachers t SET department = d.name
+FROM public.departments d
+WHERE d.id = t.department_id;
+ALTER TABLE public.teachers ALTER COLUMN department SET NOT NULL;
+ALTER TABLE public.teachers DROP CONSTRAINT IF EXISTS fk_teachers_department;
+DROP INDEX IF EXISTS idx_teachers_department;
+ALTER TABLE public.teachers DROP COLUMN IF EXISTS department_id;
+
+DROP TABLE IF EXISTS public.departments;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS public.departments (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(3) NOT NULL,
+    subject_code VARCHAR(4),
+    name VARCHAR(100) NOT NULL,
+    head_teacher_id INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT unique_departments_code UNIQUE (code),
+    CONSTRAINT unique_departments_subject_code UNIQUE (subject_code),
+    CONSTRAINT check_departments_code CHECK (code ~ '^[A-Z]{3}$'),
+    CONSTRAINT check_departments_subject_code CHECK (subject_code IS NULL OR subject_code ~ '^[A-Z]{4}$'),
+    CONSTRAINT fk_departments_head_teacher FOREIGN KEY (head_teacher_id) REFERENCES public.teachers(id) ON DELETE SET NULL
+);
+
+-- Employee IDs start with the department code, so every prefix in use becomes
+-- a department named after the department its teachers entered
+INSERT INTO public.departments (code, name)
+SELECT SPLIT_PART(employee_id, '-', 1), LEFT(MIN(department), 100)
+FROM public.teachers
+GROUP BY SPLIT_PART(employee_id, '-', 1)
+ON CONFLICT (code) DO NOTHING;
+
+ALTER TABLE public.teachers ADD COLUMN IF NOT EXISTS department_id INTEGER;
+UPDATE public.teachers t SET department_id = d.id
+FROM public.departments d
+WHERE d.code = SPLIT_PART(t.employee_id, '-', 1);
+ALTER TABLE public.teachers ALTER COLUMN department_id SET NOT NULL;
+ALTER TABLE public.teachers ADD CONSTRAINT fk_teachers_department FOREIGN KEY (department_id) REFERENCES public.departments(id) ON DELETE RESTRICT;
+ALTER TABLE public.teachers DROP COLUMN IF EXISTS department;
+CREATE INDEX IF NOT EXISTS idx_teachers_department ON public.teachers(department_id);
+
+-- Existing courses belong to the department of their teacher
+ALTER TABLE public.courses ADD COLUMN IF NOT EXISTS department_id INTEGER;
+ALTER TABLE public.courses ADD CONSTRAINT fk_courses_department FOREIGN KEY (department_id) REFERENCES public.departments(id) ON DELETE RESTRICT;
+UPDATE public.courses c SET department_id = t.department_id
+FROM public.teachers t
+WHERE t.id = c.teacher_id;
+CREATE INDEX IF NOT EXISTS idx_courses_department ON public.courses(department_id);
+
+ALTER TABLE public.programs ADD COLUMN IF NOT EXISTS department_id INTEGER;
+ALTER TABLE public.programs ADD CONSTRAINT fk_programs_department FOREIGN KEY (department_id) REFERENCES public.departments(id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_programs_department ON public.programs(department_id);
@@ -0,0 +1 @@
+ALTER TABLE public.waitlist_entries DROP COLUMN IF EXISTS override_conflicts;
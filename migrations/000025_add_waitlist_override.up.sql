@@ -0,0 +1,3 @@
+-- Remember whether timetable conflicts were overridden when a student joined
+-- the waitlist, so the promotion honours the same decision
+ALTER TABLE public.waitlist_entries ADD COLUMN IF NOT EXISTS override_conflicts BOOLEAN NOT NULL DEFAULT FALSE;
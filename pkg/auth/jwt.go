@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"time"
@@ -9,32 +11,48 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// DefaultAccessTokenTTL keeps access tokens short-lived; clients renew them with a refresh token
+const DefaultAccessTokenTTL = 15 * time.Minute
+
 type JWTService struct {
 	secretKey string
 	issuer    string
+	ttl       time.Duration
 }
 
-func NewJWTService(secretKey string) *JWTService {
+func NewJWTService(secretKey string, ttl time.Duration) *JWTService {
+	if ttl <= 0 {
+		ttl = DefaultAccessTokenTTL
+	}
 	return &JWTService{
 		secretKey: secretKey,
 		issuer:    "university-management-system",
+		ttl:       ttl,
 	}
 }
 
 type JWTClaim struct {
-	UserID uint
-	Email  string
-	Role   domain.Role
+	UserID    uint
+	Email     string
+	Role      domain.Role
+	SessionID string `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-func (j *JWTService) GenerateToken(user *domain.User) (string, error) {
+// TTL returns how long generated access tokens are valid
+func (j *JWTService) TTL() time.Duration {
+	return j.ttl
+}
+
+// GenerateToken issues an access token bound to a session
+func (j *JWTService) GenerateToken(user *domain.User, sessionID string) (string, error) {
 	claims := &JWTClaim{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Role:      user.Role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    j.issuer,
@@ -68,3 +86,62 @@ func (j *JWTService) ValidateToken(signedToken string) (*JWTClaim, error) {
 
 	return claims, nil
 }
+
+// ChallengeTokenTTL limits how long a user has to enter their second factor after the password
+const ChallengeTokenTTL = 5 * time.Minute
+
+// ChallengeClaim identifies a user who passed the password step of a login but
+// still has to present a second factor. Setup is set when the user must enroll first.
+type ChallengeClaim struct {
+	UserID uint
+	Setup  bool `json:"setup"`
+	jwt.RegisteredClaims
+}
+
+// GenerateChallengeToken issues a short-lived token for the second login step.
+// It is signed with a key derived from the secret, so it is never accepted as an access token.
+func (j *JWTService) GenerateChallengeToken(user *domain.User, setup bool) (string, error) {
+	claims := &ChallengeClaim{
+		UserID: user.ID,
+		Setup:  setup,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ChallengeTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    j.issuer,
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(j.challengeKey())
+}
+
+func (j *JWTService) ValidateChallengeToken(signedToken string) (*ChallengeClaim, error) {
+	token, err := jwt.ParseWithClaims(
+		signedToken,
+		&ChallengeClaim{},
+		func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return j.challengeKey(), nil
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*ChallengeClaim)
+	if !ok {
+		return nil, errors.New("invalid token claims")
+	}
+
+	return claims, nil
+}
+
+func (j *JWTService) challengeKey() []byte {
+	mac := hmac.New(sha256.New, []byte(j.secretKey))
+	mac.Write([]byte("2fa-challenge"))
+	return mac.Sum(nil)
+}
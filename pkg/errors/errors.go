@@ -8,9 +8,10 @@ import (
 
 // AppError represents an application error with HTTP status code and message
 type AppError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Err     error  `json:"-"`
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+	Err     error       `json:"-"`
 }
 
 // Error implements the error interface
@@ -26,6 +27,12 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// WithDetails attaches structured details that are returned to the client
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	e.Details = details
+	return e
+}
+
 // New creates a new AppError
 func New(code int, message string, err error) *AppError {
 	return &AppError{
@@ -48,10 +55,18 @@ func Forbidden(message string, err error) *AppError {
 	return New(http.StatusForbidden, message, err)
 }
 
+func Conflict(message string, err error) *AppError {
+	return New(http.StatusConflict, message, err)
+}
+
 func NotFound(message string, err error) *AppError {
 	return New(http.StatusNotFound, message, err)
 }
 
+func TooManyRequests(message string, err error) *AppError {
+	return New(http.StatusTooManyRequests, message, err)
+}
+
 func InternalServerError(message string, err error) *AppError {
 	return New(http.StatusInternalServerError, message, err)
 }
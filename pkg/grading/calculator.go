@@ -0,0 +1,143 @@
+package grading
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// RoundingMode selects how computed grades are rounded
+type RoundingMode string
+
+const (
+	RoundHalfUp   RoundingMode = "HALF_UP"   // 84.5 becomes 85
+	RoundHalfEven RoundingMode = "HALF_EVEN" // 84.5 becomes 84, 85.5 becomes 86
+	RoundUp       RoundingMode = "UP"        // Always towards the higher grade
+	RoundDown     RoundingMode = "DOWN"      // Always towards the lower grade
+)
+
+// MaxDecimals bounds the precision of computed grades
+const MaxDecimals = 2
+
+// Rounding rounds computed grades to a number of decimals
+type Rounding struct {
+	Mode     RoundingMode
+	Decimals int
+}
+
+// Validate checks that the mode is known and the precision is supported
+func (r Rounding) Validate() error {
+	switch r.Mode {
+	case RoundHalfUp, RoundHalfEven, RoundUp, RoundDown:
+	default:
+		return fmt.Errorf("unknown rounding mode %q", r.Mode)
+	}
+	if r.Decimals < 0 || r.Decimals > MaxDecimals {
+		return fmt.Errorf("rounding must use between 0 and %d decimals", MaxDecimals)
+	}
+	return nil
+}
+
+// Apply rounds the value
+func (r Rounding) Apply(value float64) float64 {
+	factor := math.Pow(10, float64(r.Decimals))
+	// Remove floating point noise first so that 84.45 is not treated as 84.4499...
+	scaled := math.Round(value*factor*1e6) / 1e6
+
+	switch r.Mode {
+	case RoundHalfEven:
+		scaled = math.RoundToEven(scaled)
+	case RoundUp:
+		scaled = math.Ceil(scaled)
+	case RoundDown:
+		scaled = math.Floor(scaled)
+	default:
+		scaled = math.Floor(scaled + 0.5)
+	}
+	return scaled / factor
+}
+
+// Component is a weighted part of a course grade, such as "Midterm" or "Labs".
+// A component consists of one or more equally weighted items of which the
+// DropLowest lowest scores are ignored.
+type Component struct {
+	Weight     float64   // Share of the course grade in percent
+	MaxScore   float64   // Score that counts as 100%
+	Items      int       // Number of scored items, at least 1
+	DropLowest int       // Lowest item scores ignored, less than Items
+	Scores     []float64 // Recorded item scores, at most Items
+}
+
+// Complete reports whether every item of the component has been scored
+func (c Component) Complete() bool {
+	return len(c.Scores) >= c.Items
+}
+
+// Percentage returns the component result in percent of MaxScore, or false if
+// nothing was scored yet. Until the component is complete it is based on the
+// recorded scores, dropping the lowest ones as long as one score remains.
+func (c Component) Percentage() (float64, bool) {
+	if len(c.Scores) == 0 || c.MaxScore <= 0 {
+		return 0, false
+	}
+
+	scores := make([]float64, len(c.Scores))
+	copy(scores, c.Scores)
+	sort.Float64s(scores)
+
+	drop := c.DropLowest
+	if drop > len(scores)-1 {
+		drop = len(scores) - 1
+	}
+	if drop > 0 {
+		scores = scores[drop:]
+	}
+
+	var total float64
+	for _, score := range scores {
+		total += score
+	}
+	return total / float64(len(scores)) / c.MaxScore * 100, true
+}
+
+// Result is the outcome of weighting the components of a course
+type Result struct {
+	Grade       *float64   // Final grade, nil until every component is complete and the weights total 100
+	Current     *float64   // Weighted average over the components scored so far, nil if nothing was scored
+	Percentages []*float64 // Result of each component in percent, nil if not scored
+}
+
+// Calculate weights the component percentages into a course grade and rounds
+// it. Both the final and the current grade are rounded.
+func Calculate(components []Component, rounding Rounding) Result {
+	result := Result{Percentages: make([]*float64, len(components))}
+
+	var totalWeight, scoredWeight, weighted float64
+	complete := len(components) > 0
+	for i, component := range components {
+		totalWeight += component.Weight
+		if !component.Complete() {
+			complete = false
+		}
+
+		percentage, ok := component.Percentage()
+		if !ok {
+			continue
+		}
+		rounded := rounding.Apply(percentage)
+		result.Percentages[i] = &rounded
+		scoredWeight += component.Weight
+		weighted += percentage * component.Weight
+	}
+
+	if scoredWeight > 0 {
+		current := rounding.Apply(weighted / scoredWeight)
+		result.Current = &current
+	}
+	if complete && math.Abs(totalWeight-100) < 1e-9 {
+		grade := rounding.Apply(weighted / 100)
+		result.Grade = &grade
+	}
+
+	return result
+}
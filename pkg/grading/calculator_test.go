@@ -0,0 +1,191 @@
+package grading
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRoundingApply(t *testing.T) {
+	tests := []struct {
+		mode     RoundingMode
+		decimals int
+		value    float64
+		want     float64
+	}{
+		{RoundHalfUp, 0, 84.5, 85},
+		{RoundHalfUp, 0, 84.49, 84},
+		{RoundHalfUp, 1, 84.45, 84.5},
+		{RoundHalfUp, 2, 1.005, 1.01},
+		{RoundHalfEven, 0, 84.5, 84},
+		{RoundHalfEven, 0, 85.5, 86},
+		{RoundHalfEven, 0, 84.51, 85},
+		{RoundHalfEven, 1, 84.25, 84.2},
+		{RoundUp, 0, 84.01, 85},
+		{RoundUp, 0, 84, 84},
+		{RoundUp, 1, 84.3, 84.3},
+		{RoundDown, 0, 84.99, 84},
+		{RoundDown, 2, 84.999, 84.99},
+		{RoundDown, 1, 84.3, 84.3},
+	}
+	for _, tt := range tests {
+		r := Rounding{Mode: tt.mode, Decimals: tt.decimals}
+		if got := r.Apply(tt.value); got != tt.want {
+			t.Errorf("%s with %d decimals: Apply(%v) = %v, want %v", tt.mode, tt.decimals, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRoundingValidate(t *testing.T) {
+	tests := []struct {
+		rounding Rounding
+		valid    bool
+	}{
+		{Rounding{Mode: RoundHalfUp}, true},
+		{Rounding{Mode: RoundDown, Decimals: MaxDecimals}, true},
+		{Rounding{Mode: "NEAREST"}, false},
+		{Rounding{}, false},
+		{Rounding{Mode: RoundUp, Decimals: -1}, false},
+		{Rounding{Mode: RoundUp, Decimals: MaxDecimals + 1}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.rounding.Validate(); (err == nil) != tt.valid {
+			t.Errorf("Validate(%+v) = %v, want valid %v", tt.rounding, err, tt.valid)
+		}
+	}
+}
+
+func TestComponentPercentage(t *testing.T) {
+	tests := []struct {
+		name      string
+		component Component
+		want      float64
+		ok        bool
+	}{
+		{"nothing scored", Component{MaxScore: 10, Items: 1}, 0, false},
+		{"no maximum score", Component{Items: 1, Scores: []float64{5}}, 0, false},
+		{"average of the scores", Component{MaxScore: 10, Items: 2, Scores: []float64{6, 8}}, 70, true},
+		{"drops the lowest", Component{MaxScore: 10, Items: 3, DropLowest: 1, Scores: []float64{8, 6, 10}}, 90, true},
+		{"keeps one score while incomplete", Component{MaxScore: 10, Items: 4, DropLowest: 2, Scores: []float64{6, 8}}, 80, true},
+		{"single score is never dropped", Component{MaxScore: 10, Items: 3, DropLowest: 2, Scores: []float64{4}}, 40, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := append([]float64(nil), tt.component.Scores...)
+			got, ok := tt.component.Percentage()
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Percentage() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+			if !reflect.DeepEqual(tt.component.Scores, scores) {
+				t.Errorf("Percentage() reordered the scores to %v", tt.component.Scores)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	midterm := Component{Weight: 30, MaxScore: 50, Items: 1, Scores: []float64{40}}
+	final := Component{Weight: 40, MaxScore: 100, Items: 1, Scores: []float64{90}}
+	labs := Component{Weight: 30, MaxScore: 10, Items: 4, DropLowest: 1, Scores: []float64{10, 5, 8, 9}}
+	halfLabs := Component{Weight: 30, MaxScore: 10, Items: 4, DropLowest: 1, Scores: []float64{10, 5}}
+	unscored := Component{Weight: 40, MaxScore: 100, Items: 1}
+	halfUp := Rounding{Mode: RoundHalfUp}
+
+	tests := []struct {
+		name        string
+		components  []Component
+		rounding    Rounding
+		grade       *float64
+		current     *float64
+		percentages []*float64
+	}{
+		{
+			name:        "every component complete",
+			components:  []Component{midterm, final, labs},
+			rounding:    halfUp,
+			grade:       ptr(87),
+			current:     ptr(87),
+			percentages: []*float64{ptr(80), ptr(90), ptr(90)},
+		},
+		{
+			name:        "incomplete component has no final grade",
+			components:  []Component{midterm, final, halfLabs},
+			rounding:    halfUp,
+			current:     ptr(90),
+			percentages: []*float64{ptr(80), ptr(90), ptr(100)},
+		},
+		{
+			name:        "current grade over the scored components",
+			components:  []Component{midterm, unscored, labs},
+			rounding:    halfUp,
+			current:     ptr(85),
+			percentages: []*float64{ptr(80), nil, ptr(90)},
+		},
+		{
+			name:        "weights not totalling 100 have no final grade",
+			components:  []Component{midterm, final},
+			rounding:    halfUp,
+			current:     ptr(86),
+			percentages: []*float64{ptr(80), ptr(90)},
+		},
+		{
+			name:        "nothing scored",
+			components:  []Component{unscored},
+			rounding:    halfUp,
+			percentages: []*float64{nil},
+		},
+		{
+			name:        "no components",
+			rounding:    halfUp,
+			percentages: []*float64{},
+		},
+		{
+			name: "grades are rounded",
+			components: []Component{
+				{Weight: 50, MaxScore: 3, Items: 1, Scores: []float64{2}},
+				{Weight: 50, MaxScore: 100, Items: 1, Scores: []float64{70}},
+			},
+			rounding:    Rounding{Mode: RoundDown, Decimals: 1},
+			grade:       ptr(68.3),
+			current:     ptr(68.3),
+			percentages: []*float64{ptr(66.6), ptr(70)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Calculate(tt.components, tt.rounding)
+			if !equal(result.Grade, tt.grade) {
+				t.Errorf("Grade = %s, want %s", format(result.Grade), format(tt.grade))
+			}
+			if !equal(result.Current, tt.current) {
+				t.Errorf("Current = %s, want %s", format(result.Current), format(tt.current))
+			}
+			if len(result.Percentages) != len(tt.percentages) {
+				t.Fatalf("got %d percentages, want %d", len(result.Percentages), len(tt.percentages))
+			}
+			for i := range tt.percentages {
+				if !equal(result.Percentages[i], tt.percentages[i]) {
+					t.Errorf("Percentages[%d] = %s, want %s", i, format(result.Percentages[i]), format(tt.percentages[i]))
+				}
+			}
+		})
+	}
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func equal(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func format(v *float64) string {
+	if v == nil {
+		return "nil"
+	}
+	return fmt.Sprint(*v)
+}
@@ -0,0 +1,29 @@
+package grading
+
+import (
+	"math"
+	"time"
+)
+
+// DaysLate returns the number of started days between the due time and the
+// submission, 0 for submissions on time
+func DaysLate(due, submitted time.Time) int {
+	if !submitted.After(due) {
+		return 0
+	}
+	return int(math.Ceil(submitted.Sub(due).Hours() / 24))
+}
+
+// LatePenalty returns the percentage deducted for a submission that is the
+// given number of days late, capped at 100
+func LatePenalty(daysLate int, percentPerDay float64) float64 {
+	if daysLate <= 0 || percentPerDay <= 0 {
+		return 0
+	}
+	return math.Min(float64(daysLate)*percentPerDay, 100)
+}
+
+// ApplyPenalty deducts a percentage from a score, rounded to two decimals
+func ApplyPenalty(score, penaltyPercent float64) float64 {
+	return Rounding{Mode: RoundHalfUp, Decimals: 2}.Apply(score * (100 - penaltyPercent) / 100)
+}
@@ -0,0 +1,69 @@
+package grading
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDaysLate(t *testing.T) {
+	due := time.Date(2026, time.March, 2, 23, 59, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		submitted time.Time
+		want      int
+	}{
+		{"early", due.Add(-48 * time.Hour), 0},
+		{"exactly on time", due, 0},
+		{"a second late", due.Add(time.Second), 1},
+		{"a full day late", due.Add(24 * time.Hour), 1},
+		{"just over a day late", due.Add(24*time.Hour + time.Minute), 2},
+		{"a week late", due.Add(7 * 24 * time.Hour), 7},
+		{"another time zone", time.Date(2026, time.March, 3, 5, 0, 0, 0, time.FixedZone("UTC+6", 6*3600)), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DaysLate(due, tt.submitted); got != tt.want {
+				t.Errorf("DaysLate = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLatePenalty(t *testing.T) {
+	tests := []struct {
+		daysLate      int
+		percentPerDay float64
+		want          float64
+	}{
+		{0, 10, 0},
+		{-1, 10, 0},
+		{3, 0, 0},
+		{3, -5, 0},
+		{1, 10, 10},
+		{3, 12.5, 37.5},
+		{10, 10, 100},
+		{30, 10, 100},
+	}
+	for _, tt := range tests {
+		if got := LatePenalty(tt.daysLate, tt.percentPerDay); got != tt.want {
+			t.Errorf("LatePenalty(%d, %v) = %v, want %v", tt.daysLate, tt.percentPerDay, got, tt.want)
+		}
+	}
+}
+
+func TestApplyPenalty(t *testing.T) {
+	tests := []struct {
+		score, penalty, want float64
+	}{
+		{80, 0, 80},
+		{80, 25, 60},
+		{80, 100, 0},
+		{9.5, 10, 8.55},
+		{33.33, 33.33, 22.22},
+	}
+	for _, tt := range tests {
+		if got := ApplyPenalty(tt.score, tt.penalty); got != tt.want {
+			t.Errorf("ApplyPenalty(%v, %v) = %v, want %v", tt.score, tt.penalty, got, tt.want)
+		}
+	}
+}
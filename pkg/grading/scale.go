@@ -0,0 +1,133 @@
+package grading
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Band maps every grade at or above MinGrade to a letter grade and grade points
+type Band struct {
+	Letter   string
+	MinGrade float64
+	Points   float64
+}
+
+// Scale converts numeric 0-100 grades to letter grades and grade points
+type Scale struct {
+	bands []Band // Sorted by MinGrade, highest first
+}
+
+// NewScale creates a scale from the given bands. One band must start at 0 so
+// that every grade maps to a letter.
+func NewScale(bands []Band) (*Scale, error) {
+	if len(bands) == 0 {
+		return nil, fmt.Errorf("grade scale must have at least one band")
+	}
+
+	sorted := make([]Band, len(bands))
+	copy(sorted, bands)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinGrade > sorted[j].MinGrade
+	})
+
+	for i, band := range sorted {
+		if band.Letter == "" {
+			return nil, fmt.Errorf("grade scale band %d has no letter", i+1)
+		}
+		if band.MinGrade < 0 || band.MinGrade > 100 {
+			return nil, fmt.Errorf("grade scale band %s has minimum grade outside 0-100", band.Letter)
+		}
+		if band.Points < 0 {
+			return nil, fmt.Errorf("grade scale band %s has negative grade points", band.Letter)
+		}
+		if i > 0 && band.MinGrade == sorted[i-1].MinGrade {
+			return nil, fmt.Errorf("grade scale bands %s and %s have the same minimum grade", sorted[i-1].Letter, band.Letter)
+		}
+	}
+	if sorted[len(sorted)-1].MinGrade != 0 {
+		return nil, fmt.Errorf("grade scale must have a band starting at 0")
+	}
+
+	return &Scale{bands: sorted}, nil
+}
+
+// DefaultScale returns the standard 4.0 letter grade scale
+func DefaultScale() *Scale {
+	scale, _ := NewScale([]Band{
+		{Letter: "A", MinGrade: 95, Points: 4.0},
+		{Letter: "A-", MinGrade: 90, Points: 3.67},
+		{Letter: "B+", MinGrade: 85, Points: 3.33},
+		{Letter: "B", MinGrade: 80, Points: 3.0},
+		{Letter: "B-", MinGrade: 75, Points: 2.67},
+		{Letter: "C+", MinGrade: 70, Points: 2.33},
+		{Letter: "C", MinGrade: 65, Points: 2.0},
+		{Letter: "C-", MinGrade: 60, Points: 1.67},
+		{Letter: "D+", MinGrade: 55, Points: 1.33},
+		{Letter: "D", MinGrade: 50, Points: 1.0},
+		{Letter: "F", MinGrade: 0, Points: 0},
+	})
+	return scale
+}
+
+// ParseScale parses a scale definition of the form "A:95:4.0,A-:90:3.67,...,F:0:0"
+// where each band is letter:minimum grade:grade points
+func ParseScale(spec string) (*Scale, error) {
+	var bands []Band
+	for _, part := range strings.Split(spec, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ":")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid grade scale band %q, expected letter:min:points", part)
+		}
+
+		minGrade, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum grade in band %q: %w", part, err)
+		}
+		points, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid grade points in band %q: %w", part, err)
+		}
+
+		bands = append(bands, Band{Letter: fields[0], MinGrade: minGrade, Points: points})
+	}
+
+	return NewScale(bands)
+}
+
+// Lookup returns the band the numeric grade falls into
+func (s *Scale) Lookup(grade float64) Band {
+	for _, band := range s.bands {
+		if grade >= band.MinGrade {
+			return band
+		}
+	}
+	return s.bands[len(s.bands)-1]
+}
+
+// IsPassing reports whether the grade earns credit, i.e. maps to non-zero grade points
+func (s *Scale) IsPassing(grade float64) bool {
+	return s.Lookup(grade).Points > 0
+}
+
+// GPA accumulates credit-weighted grade points
+type GPA struct {
+	qualityPoints float64
+	credits       int
+}
+
+// Add records a graded course worth the given number of credits
+func (g *GPA) Add(points float64, credits int) {
+	g.qualityPoints += points * float64(credits)
+	g.credits += credits
+}
+
+// Value returns the GPA rounded to two decimals, or 0 if nothing was graded
+func (g *GPA) Value() float64 {
+	if g.credits == 0 {
+		return 0
+	}
+	return math.Round(g.qualityPoints/float64(g.credits)*100) / 100
+}
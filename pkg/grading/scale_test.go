@@ -0,0 +1,115 @@
+package grading
+
+import "testing"
+
+func TestDefaultScaleLookup(t *testing.T) {
+	tests := []struct {
+		grade   float64
+		letter  string
+		points  float64
+		passing bool
+	}{
+		{100, "A", 4.0, true},
+		{95, "A", 4.0, true},
+		{94.99, "A-", 3.67, true},
+		{90, "A-", 3.67, true},
+		{85, "B+", 3.33, true},
+		{80, "B", 3.0, true},
+		{75, "B-", 2.67, true},
+		{70, "C+", 2.33, true},
+		{65, "C", 2.0, true},
+		{60, "C-", 1.67, true},
+		{55, "D+", 1.33, true},
+		{50, "D", 1.0, true},
+		{49.99, "F", 0, false},
+		{0, "F", 0, false},
+		{-5, "F", 0, false},
+	}
+	scale := DefaultScale()
+	for _, tt := range tests {
+		band := scale.Lookup(tt.grade)
+		if band.Letter != tt.letter || band.Points != tt.points {
+			t.Errorf("Lookup(%v) = %s %v, want %s %v", tt.grade, band.Letter, band.Points, tt.letter, tt.points)
+		}
+		if got := scale.IsPassing(tt.grade); got != tt.passing {
+			t.Errorf("IsPassing(%v) = %v, want %v", tt.grade, got, tt.passing)
+		}
+	}
+}
+
+func TestParseScale(t *testing.T) {
+	scale, err := ParseScale("F:0:0, P:60:1, H:85:2")
+	if err != nil {
+		t.Fatalf("ParseScale: %v", err)
+	}
+	tests := []struct {
+		grade  float64
+		letter string
+	}{
+		{90, "H"},
+		{85, "H"},
+		{84.9, "P"},
+		{60, "P"},
+		{59.9, "F"},
+	}
+	for _, tt := range tests {
+		if got := scale.Lookup(tt.grade).Letter; got != tt.letter {
+			t.Errorf("Lookup(%v) = %s, want %s", tt.grade, got, tt.letter)
+		}
+	}
+}
+
+func TestParseScaleRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", ""},
+		{"missing points", "A:90,F:0:0"},
+		{"minimum grade not a number", "A:x:4,F:0:0"},
+		{"points not a number", "A:90:x,F:0:0"},
+		{"no letter", ":90:4,F:0:0"},
+		{"minimum grade above 100", "A:101:4,F:0:0"},
+		{"negative minimum grade", "A:90:4,F:-1:0"},
+		{"negative points", "A:90:-4,F:0:0"},
+		{"duplicate minimum grade", "A:90:4,B:90:3,F:0:0"},
+		{"no band starting at 0", "A:90:4,B:80:3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseScale(tt.spec); err == nil {
+				t.Errorf("ParseScale(%q) succeeded, want an error", tt.spec)
+			}
+		})
+	}
+}
+
+func TestGPA(t *testing.T) {
+	type course struct {
+		points  float64
+		credits int
+	}
+	tests := []struct {
+		name    string
+		courses []course
+		want    float64
+	}{
+		{"nothing graded", nil, 0},
+		{"no credits", []course{{4.0, 0}}, 0},
+		{"single course", []course{{3.67, 3}}, 3.67},
+		{"weighted by credits", []course{{4.0, 4}, {2.0, 2}}, 3.33},
+		{"failed course counts", []course{{4.0, 3}, {0, 3}}, 2.0},
+		{"rounded to two decimals", []course{{3.67, 3}, {3.33, 4}, {2.0, 2}}, 3.15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gpa GPA
+			for _, c := range tt.courses {
+				gpa.Add(c.points, c.credits)
+			}
+			if got := gpa.Value(); got != tt.want {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Package ical writes iCalendar (RFC 5545) files that calendar applications
+// can import or subscribe to.
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateFormat     = "20060102"
+	floatingFormat = "20060102T150405"
+	utcFormat      = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Calendar is a VCALENDAR holding events
+type Calendar struct {
+	ProdID    string // Identifies the producing application, e.g. "-//Example//Timetable//EN"
+	Name      string // Shown by calendar applications when subscribing
+	Generated time.Time
+	Events    []Event
+}
+
+// Event is a VEVENT. Its times are written in UTC unless AllDay or Floating is set.
+type Event struct {
+	UID         string // Globally unique and stable across feed refreshes
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool    // Start and End are dates, End is exclusive
+	Floating    bool    // Start and End are wall clock times in the time zone of the reader
+	Repeat      *Weekly // Nil for a single occurrence
+}
+
+// Weekly repeats an event every week on the given days until a last day. The
+// event Start must fall on one of the days.
+type Weekly struct {
+	Days  []time.Weekday
+	Until time.Time // Last occurrence may start on or before this time
+}
+
+// Encode writes the calendar with CRLF line endings and folded long lines
+func (c *Calendar) Encode() []byte {
+	w := &writer{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + c.ProdID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escape(c.Name))
+	}
+
+	stamp := c.Generated.UTC().Format(utcFormat)
+	for _, event := range c.Events {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + escape(event.UID))
+		w.line("DTSTAMP:" + stamp)
+		w.line("DTSTART" + event.formatTime(event.Start))
+		w.line("DTEND" + event.formatTime(event.End))
+		if event.Repeat != nil {
+			w.line("RRULE:" + event.rule())
+		}
+		w.line("SUMMARY:" + escape(event.Summary))
+		if event.Description != "" {
+			w.line("DESCRIPTION:" + escape(event.Description))
+		}
+		if event.Location != "" {
+			w.line("LOCATION:" + escape(event.Location))
+		}
+		w.line("END:VEVENT")
+	}
+
+	w.line("END:VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// formatTime returns the parameters and value of a DTSTART or DTEND property
+func (e *Event) formatTime(t time.Time) string {
+	switch {
+	case e.AllDay:
+		return ";VALUE=DATE:" + t.Format(dateFormat)
+	case e.Floating:
+		return ":" + t.Format(floatingFormat)
+	default:
+		return ":" + t.UTC().Format(utcFormat)
+	}
+}
+
+func (e *Event) rule() string {
+	var days []string
+	for _, day := range e.Repeat.Days {
+		days = append(days, weekdayCodes[day])
+	}
+
+	// UNTIL must have the same value type as DTSTART
+	var until string
+	switch {
+	case e.AllDay:
+		until = e.Repeat.Until.Format(dateFormat)
+	case e.Floating:
+		until = e.Repeat.Until.Format(floatingFormat)
+	default:
+		until = e.Repeat.Until.UTC().Format(utcFormat)
+	}
+	return "FREQ=WEEKLY;BYDAY=" + strings.Join(days, ",") + ";UNTIL=" + until
+}
+
+// escape quotes the characters with a special meaning in TEXT values. Any
+// line break, including a bare CR, becomes \n.
+func escape(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\r", `\n`,
+		"\n", `\n`,
+	).Replace(value)
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line, folding it after 75 octets without splitting
+// multi-byte characters
+func (w *writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with the folding space
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
@@ -0,0 +1,118 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Room 101", "Room 101"},
+		{`C:\temp`, `C:\\temp`},
+		{"Labs; bring a laptop", `Labs\; bring a laptop`},
+		{"Mon, Wed", `Mon\, Wed`},
+		{"first\nsecond", `first\nsecond`},
+		{"first\r\nsecond", `first\nsecond`},
+		{"first\rsecond", `first\nsecond`},
+		{"first\n\rsecond", `first\n\nsecond`},
+		{`a\;b`, `a\\\;b`},
+	}
+	for _, tt := range tests {
+		if got := escape(tt.value); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"short", "SUMMARY:Algorithms"},
+		{"exactly 75 octets", "SUMMARY:" + strings.Repeat("a", 67)},
+		{"ascii", "SUMMARY:" + strings.Repeat("a", 200)},
+		{"two byte runes", "SUMMARY:" + strings.Repeat("é", 100)},
+		{"three byte runes", "SUMMARY:" + strings.Repeat("€", 100)},
+		{"four byte runes", "SUMMARY:x" + strings.Repeat("😀", 50)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &writer{}
+			w.line(tt.content)
+			out := w.buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line %q does not end with CRLF", out)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for i, line := range lines {
+				if len(line) > maxLineOctets {
+					t.Errorf("line %d has %d octets", i, len(line))
+				}
+				if i > 0 {
+					if !strings.HasPrefix(line, " ") {
+						t.Errorf("continuation line %d does not start with a space", i)
+					}
+					line = line[1:]
+				}
+				if !utf8.ValidString(line) {
+					t.Errorf("line %d splits a character: %q", i, line)
+				}
+			}
+			if len(tt.content) <= maxLineOctets && len(lines) != 1 {
+				t.Errorf("folded a line of %d octets", len(tt.content))
+			}
+			if got := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); got != tt.content {
+				t.Errorf("unfolded line = %q, want %q", got, tt.content)
+			}
+		})
+	}
+}
+
+func TestRepeatUntil(t *testing.T) {
+	almaty := time.FixedZone("UTC+5", 5*3600)
+	start := time.Date(2026, time.February, 2, 9, 0, 0, 0, almaty)
+	until := time.Date(2026, time.May, 29, 9, 0, 0, 0, almaty)
+	tests := []struct {
+		name      string
+		allDay    bool
+		floating  bool
+		wantStart string
+		wantUntil string
+	}{
+		{"utc", false, false, "DTSTART:20260202T040000Z", "UNTIL=20260529T040000Z"},
+		{"floating", false, true, "DTSTART:20260202T090000", "UNTIL=20260529T090000"},
+		{"all day", true, false, "DTSTART;VALUE=DATE:20260202", "UNTIL=20260529"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendar := &Calendar{
+				ProdID:    "-//Test//Timetable//EN",
+				Generated: start,
+				Events: []Event{{
+					UID:      "course-1@test",
+					Summary:  "Algorithms",
+					Start:    start,
+					End:      start.Add(90 * time.Minute),
+					AllDay:   tt.allDay,
+					Floating: tt.floating,
+					Repeat:   &Weekly{Days: []time.Weekday{time.Monday, time.Wednesday}, Until: until},
+				}},
+			}
+			out := string(calendar.Encode())
+			if !strings.Contains(out, "\r\n"+tt.wantStart+"\r\n") {
+				t.Errorf("missing %s in\n%s", tt.wantStart, out)
+			}
+			wantRule := "\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE;" + tt.wantUntil + "\r\n"
+			if !strings.Contains(out, wantRule) {
+				t.Errorf("missing %q in\n%s", strings.TrimSpace(wantRule), out)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package pdf
+
+// Column is a piece of text placed at a fixed horizontal offset within a row
+type Column struct {
+	X    float64 // Offset from the left margin
+	Text string
+}
+
+// Flow lays out text top to bottom and starts a new page when the current one is full
+type Flow struct {
+	doc    *Document
+	page   *Page
+	margin float64
+	y      float64
+}
+
+func NewFlow(doc *Document, margin float64) *Flow {
+	f := &Flow{doc: doc, margin: margin}
+	f.NewPage()
+	return f
+}
+
+// NewPage continues the flow at the top of a fresh page
+func (f *Flow) NewPage() {
+	f.page = f.doc.AddPage()
+	f.y = PageHeight - f.margin
+}
+
+// Page returns the page currently being written
+func (f *Flow) Page() *Page {
+	return f.page
+}
+
+// Line writes a single line of text at the left margin
+func (f *Flow) Line(font Font, size float64, text string) {
+	f.Row(font, size, []Column{{X: 0, Text: text}})
+}
+
+// Row writes one line made of several columns
+func (f *Flow) Row(font Font, size float64, columns []Column) {
+	lineHeight := size * 1.4
+	f.ensureSpace(lineHeight)
+	f.y -= lineHeight
+	for _, column := range columns {
+		f.page.Text(f.margin+column.X, f.y, font, size, column.Text)
+	}
+}
+
+// Rule draws a horizontal line across the content area
+func (f *Flow) Rule() {
+	f.ensureSpace(8)
+	f.y -= 4
+	f.page.Line(f.margin, f.y, PageWidth-f.margin, f.y, 0.5)
+	f.y -= 4
+}
+
+// Gap adds vertical space
+func (f *Flow) Gap(height float64) {
+	f.y -= height
+}
+
+// ensureSpace starts a new page unless at least height points remain on the current one
+func (f *Flow) ensureSpace(height float64) {
+	if f.y-height < f.margin {
+		f.NewPage()
+	}
+}
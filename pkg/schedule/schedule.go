@@ -0,0 +1,178 @@
+// Package schedule describes weekly recurring time slots such as course meetings
+// and detects when they overlap.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Days is a set of weekdays, stored as a bitmask with Monday as the lowest bit
+type Days uint8
+
+const (
+	Monday Days = 1 << iota
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+	Sunday
+)
+
+// AllDays contains every weekday
+const AllDays = Monday | Tuesday | Wednesday | Thursday | Friday | Saturday | Sunday
+
+var dayNames = [...]string{"MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+
+// ParseDays builds a set from day names such as "MON" or "fri"
+func ParseDays(names []string) (Days, error) {
+	var days Days
+	for _, name := range names {
+		day, ok := dayByName(name)
+		if !ok {
+			return 0, fmt.Errorf("unknown day %q, expected one of %s", name, strings.Join(dayNames[:], ", "))
+		}
+		days |= day
+	}
+	return days, nil
+}
+
+func dayByName(name string) (Days, bool) {
+	for i, known := range dayNames {
+		if strings.EqualFold(name, known) {
+			return 1 << i, true
+		}
+	}
+	return 0, false
+}
+
+// DayOf returns the set containing only the given weekday
+func DayOf(weekday time.Weekday) Days {
+	// time.Weekday starts the week on Sunday
+	return 1 << ((int(weekday) + 6) % 7)
+}
+
+// Has reports whether the set contains the weekday
+func (d Days) Has(weekday time.Weekday) bool {
+	return d&DayOf(weekday) != 0
+}
+
+// Names lists the days of the set from Monday to Sunday
+func (d Days) Names() []string {
+	var names []string
+	for i, name := range dayNames {
+		if d&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Split returns every day of the set as a set of its own, from Monday to Sunday
+func (d Days) Split() []Days {
+	var days []Days
+	for i := range dayNames {
+		if day := Days(1 << i); d&day != 0 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// MinutesPerDay bounds clock times, which are counted in minutes from midnight
+const MinutesPerDay = 24 * 60
+
+// ParseClock parses a time of day written as "HH:MM" into minutes from midnight
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock writes minutes from midnight as "HH:MM"
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// Slot is a weekly recurring time span, e.g. Monday and Wednesday 09:00-10:30
+type Slot struct {
+	Days  Days
+	Start int // Minutes from midnight
+	End   int // Minutes from midnight, after Start
+}
+
+// Validate checks that the slot falls on at least one day and within a day
+func (s Slot) Validate() error {
+	if s.Days == 0 || s.Days&^AllDays != 0 {
+		return fmt.Errorf("at least one valid day is required")
+	}
+	if s.Start < 0 || s.End > MinutesPerDay || s.Start >= s.End {
+		return fmt.Errorf("start time must be before end time")
+	}
+	return nil
+}
+
+// Overlaps reports whether both slots take place at the same time on some day.
+// Slots that only touch, such as 09:00-10:00 and 10:00-11:00, do not overlap.
+func (s Slot) Overlaps(other Slot) bool {
+	return s.Days&other.Days != 0 && s.Start < other.End && other.Start < s.End
+}
+
+func (s Slot) String() string {
+	return fmt.Sprintf("%s %s-%s", strings.Join(s.Days.Names(), ","), FormatClock(s.Start), FormatClock(s.End))
+}
+
+// Recurring is a slot that repeats every week between two dates, such as the
+// meetings of a course during its term. From and To are inclusive days.
+type Recurring struct {
+	Slot
+	From time.Time
+	To   time.Time
+}
+
+// Overlaps reports whether both recurring slots take place at the same time
+// while their date ranges overlap
+func (r Recurring) Overlaps(other Recurring) bool {
+	return r.Slot.Overlaps(other.Slot) &&
+		!dateIn(r.From, time.UTC).After(dateIn(other.To, time.UTC)) &&
+		!dateIn(other.From, time.UTC).After(dateIn(r.To, time.UTC))
+}
+
+// OverlapsInterval reports whether an occurrence of the recurring slot falls
+// within the interval [start, end). Clock times are compared in the location
+// of start.
+func (r Recurring) OverlapsInterval(start, end time.Time) bool {
+	loc := start.Location()
+	end = end.In(loc)
+	from, to := dateIn(r.From, loc), dateIn(r.To, loc)
+	for d := dateIn(start, loc); d.Before(end); d = d.AddDate(0, 0, 1) {
+		if d.Before(from) || d.After(to) || !r.Days.Has(d.Weekday()) {
+			continue
+		}
+		// Minutes of the interval that fall on day d
+		lo, hi := 0, MinutesPerDay
+		if sameDay(d, start) {
+			lo = start.Hour()*60 + start.Minute()
+		}
+		if sameDay(d, end) {
+			hi = end.Hour()*60 + end.Minute()
+		}
+		if r.Start < hi && lo < r.End {
+			return true
+		}
+	}
+	return false
+}
+
+// dateIn returns midnight in loc of the calendar day of t
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,32 @@
+// Package storage keeps uploaded files such as assignment submissions.
+package storage
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+// ErrNotFound is returned when no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore stores opaque blobs under slash separated keys such as
+// "submissions/12/4/3f9a". Implementations must be safe for concurrent use.
+type BlobStore interface {
+	// Put stores the content under the key, replacing any existing blob, and
+	// returns the number of bytes written
+	Put(key string, content io.Reader) (int64, error)
+	// Open returns the content stored under the key, or ErrNotFound
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the blob. Deleting a missing blob is not an error.
+	Delete(key string) error
+}
+
+// ValidKey reports whether a key is relative and cannot escape the store
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	return path.Clean(key) == key && !strings.HasPrefix(key, "../") && key != ".."
+}
@@ -0,0 +1,89 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore looks for entries it can forget
+const sweepInterval = time.Minute
+
+// MemoryStore keeps entries in process memory. It is only suitable for a
+// single application instance.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+// memoryEntry remembers the window of the last failure so the entry can be
+// dropped once it no longer affects any check
+type memoryEntry struct {
+	Entry
+	window time.Duration
+}
+
+// expired reports whether the failures are outside the window and no lockout is active
+func (e memoryEntry) expired(at time.Time) bool {
+	return at.Sub(e.LastFailureAt) > e.window && !e.LockedUntil.After(at)
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]memoryEntry)}
+}
+
+func (s *MemoryStore) Get(key string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key].Entry, nil
+}
+
+func (s *MemoryStore) Fail(key string, at time.Time, window time.Duration) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(at)
+
+	entry := s.entries[key]
+	entry.Key = key
+	if at.Sub(entry.LastFailureAt) > window {
+		entry.Failures = 0
+	}
+	entry.Failures++
+	entry.LastFailureAt = at
+	entry.window = window
+	s.entries[key] = entry
+	return entry.Entry, nil
+}
+
+func (s *MemoryStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.entries[key]
+	entry.Key = key
+	entry.LockedUntil = until
+	s.entries[key] = entry
+	return nil
+}
+
+func (s *MemoryStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep drops expired entries, at most once per sweepInterval, so failures for
+// many distinct keys do not grow the store without bound. The caller holds mu.
+func (s *MemoryStore) sweep(at time.Time) {
+	if at.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = at
+	for key, entry := range s.entries {
+		if entry.expired(at) {
+			delete(s.entries, key)
+		}
+	}
+}
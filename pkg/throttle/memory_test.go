@@ -0,0 +1,64 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreEvictsExpiredEntries(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	window := time.Hour
+
+	if _, err := store.Fail("account:stale", start, window); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Fail("account:locked", start, window); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Lock("account:locked", start.Add(3*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Past the window of both entries, but the lockout is still active
+	later := start.Add(2 * time.Hour)
+	if _, err := store.Fail("account:fresh", later, window); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := store.entries["account:stale"]; ok {
+		t.Error("entry outside its window was not evicted")
+	}
+	if _, ok := store.entries["account:locked"]; !ok {
+		t.Error("locked entry was evicted before its lockout ended")
+	}
+	if _, ok := store.entries["account:fresh"]; !ok {
+		t.Error("entry just recorded is missing")
+	}
+
+	// Once the lockout has passed the entry goes as well
+	if _, err := store.Fail("account:fresh", start.Add(4*time.Hour), window); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.entries["account:locked"]; ok {
+		t.Error("entry was not evicted after its lockout ended")
+	}
+}
+
+func TestMemoryStoreKeepsEntriesInsideWindow(t *testing.T) {
+	store := NewMemoryStore()
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if _, err := store.Fail("ip:192.0.2.1", start.Add(time.Duration(i)*10*time.Minute), time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entry, err := store.Get("ip:192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Failures != 3 {
+		t.Errorf("Failures = %d, want 3", entry.Failures)
+	}
+}
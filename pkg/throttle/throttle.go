@@ -0,0 +1,124 @@
+// Package throttle tracks failed attempts per key (such as an account or an IP
+// address) and slows them down with exponential backoff and temporary lockouts.
+package throttle
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entry is the failure state of one key
+type Entry struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   time.Time
+}
+
+// Store keeps entries. Implementations must apply Fail atomically so several
+// application instances can share one store.
+type Store interface {
+	// Get returns the entry for key, or a zero Entry if there is none
+	Get(key string) (Entry, error)
+	// Fail records a failure at the given time and returns the updated entry.
+	// Failures older than window are forgotten before counting.
+	Fail(key string, at time.Time, window time.Duration) (Entry, error)
+	// Lock blocks the key until the given time
+	Lock(key string, until time.Time) error
+	// Reset forgets all failures and lockouts of the key
+	Reset(key string) error
+}
+
+// Policy configures how quickly a key is slowed down and locked
+type Policy struct {
+	FreeAttempts    int           // Failures allowed before delays start
+	BaseDelay       time.Duration // Delay after the first failure past FreeAttempts, doubled each time
+	MaxDelay        time.Duration
+	LockoutAfter    int // Failures that trigger a lockout, 0 disables lockouts
+	LockoutDuration time.Duration
+	Window          time.Duration // Failures older than this are forgotten
+}
+
+// Delay returns the backoff that applies after the given number of failures
+func (p Policy) Delay(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// BlockedError is returned while a key has to wait before the next attempt
+type BlockedError struct {
+	Key        string
+	RetryAfter time.Duration
+	Locked     bool // True for a lockout, false for a backoff delay
+}
+
+func (e *BlockedError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("%s is locked for %s", e.Key, e.RetryAfter)
+	}
+	return fmt.Sprintf("%s must wait %s", e.Key, e.RetryAfter)
+}
+
+// Limiter applies a policy to keys in a store
+type Limiter struct {
+	store  Store
+	policy Policy
+}
+
+func NewLimiter(store Store, policy Policy) *Limiter {
+	return &Limiter{store: store, policy: policy}
+}
+
+func (l *Limiter) Policy() Policy {
+	return l.policy
+}
+
+// Check returns a *BlockedError if the key may not make an attempt at the given time
+func (l *Limiter) Check(key string, at time.Time) error {
+	entry, err := l.store.Get(key)
+	if err != nil {
+		return err
+	}
+
+	if at.Before(entry.LockedUntil) {
+		return &BlockedError{Key: key, RetryAfter: entry.LockedUntil.Sub(at), Locked: true}
+	}
+
+	if entry.Failures > 0 && at.Sub(entry.LastFailureAt) < l.policy.Window {
+		next := entry.LastFailureAt.Add(l.policy.Delay(entry.Failures))
+		if at.Before(next) {
+			return &BlockedError{Key: key, RetryAfter: next.Sub(at)}
+		}
+	}
+	return nil
+}
+
+// Fail records a failed attempt. It reports whether the failure locked the key.
+func (l *Limiter) Fail(key string, at time.Time) (bool, error) {
+	entry, err := l.store.Fail(key, at, l.policy.Window)
+	if err != nil {
+		return false, err
+	}
+
+	if l.policy.LockoutAfter > 0 && entry.Failures >= l.policy.LockoutAfter && !at.Before(entry.LockedUntil) {
+		if err := l.store.Lock(key, at.Add(l.policy.LockoutDuration)); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Reset clears the key after a successful attempt or a manual unlock
+func (l *Limiter) Reset(key string) error {
+	return l.store.Reset(key)
+}
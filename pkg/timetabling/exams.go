@@ -0,0 +1,189 @@
+package timetabling
+
+import (
+	"sort"
+	"time"
+)
+
+// Reasons an exam cannot be placed without affecting students
+const (
+	ReasonClash      = "CLASH"       // Students would sit two exams at once
+	ReasonDailyLimit = "DAILY_LIMIT" // Students would exceed their exams per day
+	ReasonNoSlot     = "NO_SLOT"     // No slot is long enough for the exam
+)
+
+// ExamSlot is a period an exam can be held in. Day groups slots by calendar
+// day for the per-day limit.
+type ExamSlot struct {
+	Start    time.Time
+	End      time.Time
+	Day      int
+	Reserved bool // Only holds the exams fixed to it
+}
+
+func (s ExamSlot) overlaps(other ExamSlot) bool {
+	return s.Start.Before(other.End) && other.Start.Before(s.End)
+}
+
+type Exam struct {
+	ID       uint
+	Duration time.Duration
+	Students []uint
+	Fixed    int // Index of the slot the exam is fixed to, -1 to let the planner choose
+}
+
+// UnplacedExam is an exam every slot of which would affect students. Slot is
+// the slot affecting the fewest, or -1 if none is long enough.
+type UnplacedExam struct {
+	ExamID   uint
+	Reason   string
+	Slot     int
+	Students []uint
+}
+
+type ExamPlan struct {
+	Slots    map[uint]int // Slot index per placed exam
+	Unplaced []UnplacedExam
+}
+
+// PlanExams gives every exam a slot so that no student sits two exams at once
+// or more than maxPerDay exams on a day (0 for no limit). Fixed exams keep
+// their slot. The others are placed like colouring a graph in which exams
+// sharing students are adjacent: the exams sharing students with the most
+// other exams go first, each into the valid slot that puts the fewest of its
+// students' exams on the same day, earlier slots first.
+func PlanExams(exams []Exam, slots []ExamSlot, maxPerDay int) *ExamPlan {
+	plan := &ExamPlan{Slots: make(map[uint]int)}
+	p := &examPlanner{
+		slots:     slots,
+		maxPerDay: maxPerDay,
+		taken:     make(map[uint][]int),
+	}
+
+	order := make([]Exam, 0, len(exams))
+	for _, exam := range exams {
+		if exam.Fixed >= 0 && exam.Fixed < len(slots) {
+			plan.Slots[exam.ID] = exam.Fixed
+			p.take(exam, exam.Fixed)
+			continue
+		}
+		order = append(order, exam)
+	}
+
+	degree := examDegrees(order)
+	sort.SliceStable(order, func(i, j int) bool {
+		if degree[order[i].ID] != degree[order[j].ID] {
+			return degree[order[i].ID] > degree[order[j].ID]
+		}
+		return len(order[i].Students) > len(order[j].Students)
+	})
+
+	for _, exam := range order {
+		best, bestLoad := -1, 0
+		fewest := UnplacedExam{ExamID: exam.ID, Reason: ReasonNoSlot, Slot: -1}
+		for i, slot := range slots {
+			if slot.Reserved || slot.End.Sub(slot.Start) < exam.Duration {
+				continue
+			}
+			affected, reason := p.affected(exam, i)
+			if len(affected) > 0 {
+				if fewest.Slot < 0 || len(affected) < len(fewest.Students) {
+					fewest = UnplacedExam{ExamID: exam.ID, Reason: reason, Slot: i, Students: affected}
+				}
+				continue
+			}
+			if load := p.dayLoad(exam, slot.Day); best < 0 || load < bestLoad {
+				best, bestLoad = i, load
+			}
+		}
+		if best < 0 {
+			plan.Unplaced = append(plan.Unplaced, fewest)
+			continue
+		}
+		plan.Slots[exam.ID] = best
+		p.take(exam, best)
+	}
+
+	return plan
+}
+
+type examPlanner struct {
+	slots     []ExamSlot
+	maxPerDay int
+	taken     map[uint][]int // Slots of each student's placed exams
+}
+
+func (p *examPlanner) take(exam Exam, slot int) {
+	for _, student := range exam.Students {
+		p.taken[student] = append(p.taken[student], slot)
+	}
+}
+
+// affected lists the students the exam would clash for in the slot, or failing
+// that the students it would push over the daily limit
+func (p *examPlanner) affected(exam Exam, slot int) ([]uint, string) {
+	var clashes, overLimit []uint
+	for _, student := range exam.Students {
+		sameDay := 0
+		clash := false
+		for _, other := range p.taken[student] {
+			if p.slots[other].overlaps(p.slots[slot]) {
+				clash = true
+			}
+			if p.slots[other].Day == p.slots[slot].Day {
+				sameDay++
+			}
+		}
+		if clash {
+			clashes = append(clashes, student)
+		} else if p.maxPerDay > 0 && sameDay >= p.maxPerDay {
+			overLimit = append(overLimit, student)
+		}
+	}
+	if len(clashes) > 0 {
+		return append(clashes, overLimit...), ReasonClash
+	}
+	return overLimit, ReasonDailyLimit
+}
+
+// dayLoad counts the exams the exam's students already have on the day
+func (p *examPlanner) dayLoad(exam Exam, day int) int {
+	load := 0
+	for _, student := range exam.Students {
+		for _, other := range p.taken[student] {
+			if p.slots[other].Day == day {
+				load++
+			}
+		}
+	}
+	return load
+}
+
+// examDegrees counts for every exam the other exams sharing a student with it
+func examDegrees(exams []Exam) map[uint]int {
+	byStudent := make(map[uint][]uint)
+	for _, exam := range exams {
+		for _, student := range exam.Students {
+			byStudent[student] = append(byStudent[student], exam.ID)
+		}
+	}
+	neighbours := make(map[uint]map[uint]bool)
+	for _, ids := range byStudent {
+		for _, a := range ids {
+			for _, b := range ids {
+				if a == b {
+					continue
+				}
+				if neighbours[a] == nil {
+					neighbours[a] = make(map[uint]bool)
+				}
+				neighbours[a][b] = true
+			}
+		}
+	}
+	degree := make(map[uint]int)
+	for id, set := range neighbours {
+		degree[id] = len(set)
+	}
+	return degree
+}
@@ -0,0 +1,138 @@
+package timetabling
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// examSlot returns a three hour slot starting at the hour of the given day
+func examSlot(day, hour int) ExamSlot {
+	start := time.Date(2026, time.January, 5+day, hour, 0, 0, 0, time.UTC)
+	return ExamSlot{Start: start, End: start.Add(3 * time.Hour), Day: day}
+}
+
+func reserved(slot ExamSlot) ExamSlot {
+	slot.Reserved = true
+	return slot
+}
+
+func exam(id uint, fixed int, students ...uint) Exam {
+	return Exam{ID: id, Duration: 2 * time.Hour, Students: students, Fixed: fixed}
+}
+
+func TestPlanExams(t *testing.T) {
+	tests := []struct {
+		name         string
+		exams        []Exam
+		slots        []ExamSlot
+		maxPerDay    int
+		wantSlots    map[uint]int
+		wantUnplaced []UnplacedExam
+	}{
+		{
+			name:      "exams sharing students go to different days",
+			exams:     []Exam{exam(1, -1, 1, 2), exam(2, -1, 1)},
+			slots:     []ExamSlot{examSlot(0, 9), examSlot(1, 9)},
+			wantSlots: map[uint]int{1: 0, 2: 1},
+		},
+		{
+			name:      "exams without shared students share a slot",
+			exams:     []Exam{exam(1, -1, 1), exam(2, -1, 2)},
+			slots:     []ExamSlot{examSlot(0, 9), examSlot(1, 9)},
+			wantSlots: map[uint]int{1: 0, 2: 0},
+		},
+		{
+			name:      "prefers the day with fewer exams",
+			exams:     []Exam{exam(1, -1, 1), exam(2, -1, 1)},
+			slots:     []ExamSlot{examSlot(0, 9), examSlot(0, 14), examSlot(1, 9)},
+			maxPerDay: 2,
+			wantSlots: map[uint]int{1: 0, 2: 2},
+		},
+		{
+			name:         "clash",
+			exams:        []Exam{exam(1, -1, 1, 2), exam(2, -1, 1)},
+			slots:        []ExamSlot{examSlot(0, 9)},
+			wantSlots:    map[uint]int{1: 0},
+			wantUnplaced: []UnplacedExam{{ExamID: 2, Reason: ReasonClash, Slot: 0, Students: []uint{1}}},
+		},
+		{
+			name:         "daily limit",
+			exams:        []Exam{exam(1, 0, 1), exam(2, -1, 1)},
+			slots:        []ExamSlot{reserved(examSlot(0, 9)), examSlot(0, 14)},
+			maxPerDay:    1,
+			wantSlots:    map[uint]int{1: 0},
+			wantUnplaced: []UnplacedExam{{ExamID: 2, Reason: ReasonDailyLimit, Slot: 1, Students: []uint{1}}},
+		},
+		{
+			name:      "no daily limit",
+			exams:     []Exam{exam(1, 0, 1), exam(2, -1, 1)},
+			slots:     []ExamSlot{reserved(examSlot(0, 9)), examSlot(0, 14)},
+			wantSlots: map[uint]int{1: 0, 2: 1},
+		},
+		{
+			name:      "fixed exam keeps its slot",
+			exams:     []Exam{exam(1, 1, 1), exam(2, -1, 1)},
+			slots:     []ExamSlot{examSlot(0, 9), examSlot(1, 9)},
+			wantSlots: map[uint]int{1: 1, 2: 0},
+		},
+		{
+			name:      "fixed exams are not checked against each other",
+			exams:     []Exam{exam(1, 0, 1), exam(2, 0, 1)},
+			slots:     []ExamSlot{examSlot(0, 9)},
+			wantSlots: map[uint]int{1: 0, 2: 0},
+		},
+		{
+			name:         "reserved slot only holds fixed exams",
+			exams:        []Exam{exam(1, -1, 1)},
+			slots:        []ExamSlot{reserved(examSlot(0, 9))},
+			wantSlots:    map[uint]int{},
+			wantUnplaced: []UnplacedExam{{ExamID: 1, Reason: ReasonNoSlot, Slot: -1}},
+		},
+		{
+			name:         "no slot is long enough",
+			exams:        []Exam{{ID: 1, Duration: 4 * time.Hour, Students: []uint{1}, Fixed: -1}},
+			slots:        []ExamSlot{examSlot(0, 9), examSlot(1, 9)},
+			wantSlots:    map[uint]int{},
+			wantUnplaced: []UnplacedExam{{ExamID: 1, Reason: ReasonNoSlot, Slot: -1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanExams(tt.exams, tt.slots, tt.maxPerDay)
+			if !reflect.DeepEqual(plan.Slots, tt.wantSlots) {
+				t.Errorf("Slots = %v, want %v", plan.Slots, tt.wantSlots)
+			}
+			if !reflect.DeepEqual(plan.Unplaced, tt.wantUnplaced) {
+				t.Errorf("Unplaced = %+v, want %+v", plan.Unplaced, tt.wantUnplaced)
+			}
+		})
+	}
+}
+
+func TestPlanExamsNeverClashes(t *testing.T) {
+	// Six exams in a ring of shared students over three days with two slots each
+	var exams []Exam
+	for i := uint(1); i <= 6; i++ {
+		exams = append(exams, exam(i, -1, i, i%6+1))
+	}
+	slots := []ExamSlot{examSlot(0, 9), examSlot(0, 14), examSlot(1, 9), examSlot(1, 14), examSlot(2, 9), examSlot(2, 14)}
+
+	plan := PlanExams(exams, slots, 1)
+	if len(plan.Unplaced) > 0 {
+		t.Fatalf("Unplaced = %+v, want none", plan.Unplaced)
+	}
+	perDay := make(map[uint]map[int]int)
+	for _, e := range exams {
+		day := slots[plan.Slots[e.ID]].Day
+		for _, student := range e.Students {
+			if perDay[student] == nil {
+				perDay[student] = make(map[int]int)
+			}
+			perDay[student][day]++
+			if perDay[student][day] > 1 {
+				t.Errorf("student %d has more than one exam on day %d", student, day)
+			}
+		}
+	}
+}
@@ -0,0 +1,320 @@
+// Package timetabling places courses into weekly time slots and rooms. Hard
+// constraints (a teacher or room is never used twice at the same time, rooms
+// seat the course and offer the features it needs) are never broken; soft
+// constraints (teacher preferred times, meetings spread across the week) are
+// weighed by a cost that the solver keeps low. It also plans exam periods so
+// that no student sits two exams at once.
+package timetabling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+)
+
+// Costs of the soft constraints, added up per meeting
+const (
+	CostOutsidePreference = 10 // Meeting outside the teacher's preferred times
+	CostAdjacentDay       = 4  // Meeting on the day before or after another meeting of the course
+	CostTeacherDayLoad    = 2  // Per meeting the teacher already has that day
+	CostDayLoad           = 1  // Per meeting already placed that day
+	CostOtherStartTime    = 1  // Meeting starts at another time than the course's first one
+)
+
+type Room struct {
+	ID       uint
+	Capacity int
+	Features []string
+}
+
+type Course struct {
+	ID        uint
+	TeacherID uint
+	Size      int // Seats needed, 0 fits any room
+	Meetings  int // Meetings per week, each on a different day
+	Duration  int // Minutes per meeting
+	Features  []string
+}
+
+// Problem is a set of courses to place. TeacherBusy and RoomBusy hold times
+// already taken by meetings and bookings outside the problem; Preferred holds
+// the times teachers would like to teach at.
+type Problem struct {
+	Courses     []Course
+	Rooms       []Room
+	Days        schedule.Days
+	DayStart    int // Earliest start, minutes from midnight
+	DayEnd      int // Latest end, minutes from midnight
+	Step        int // Start times are multiples of Step minutes after DayStart
+	TeacherBusy map[uint][]schedule.Slot
+	RoomBusy    map[uint][]schedule.Slot
+	Preferred   map[uint][]schedule.Slot
+}
+
+// Placement is one meeting of a course on a single day
+type Placement struct {
+	CourseID uint
+	RoomID   uint
+	Slot     schedule.Slot
+}
+
+// Unplaced is a course that could not be placed, with the reason
+type Unplaced struct {
+	CourseID uint
+	Reason   string
+}
+
+type Solution struct {
+	Placements []Placement
+	Unplaced   []Unplaced
+	Cost       int // Total cost of the soft constraints
+}
+
+func (p *Problem) validate() error {
+	if p.Days == 0 || p.Days&^schedule.AllDays != 0 {
+		return fmt.Errorf("at least one valid day is required")
+	}
+	if p.DayStart < 0 || p.DayEnd > schedule.MinutesPerDay || p.DayStart >= p.DayEnd {
+		return fmt.Errorf("day start must be before day end")
+	}
+	if p.Step <= 0 {
+		return fmt.Errorf("step must be positive")
+	}
+	for _, course := range p.Courses {
+		if course.Meetings <= 0 || course.Duration <= 0 {
+			return fmt.Errorf("course %d needs a positive number of meetings and duration", course.ID)
+		}
+	}
+	return nil
+}
+
+// Solve places the courses one at a time, the most constrained first, giving
+// each meeting the cheapest free time and the smallest free room that fits.
+// Courses that cannot be placed completely are reported as unplaced and take
+// no time. progress is called after every course.
+func Solve(ctx context.Context, p *Problem, progress func(done, total int)) (*Solution, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	s := newSolver(p)
+	courses := make([]Course, len(p.Courses))
+	copy(courses, p.Courses)
+	sort.SliceStable(courses, func(i, j int) bool {
+		a, b := len(s.suitableRooms(&courses[i])), len(s.suitableRooms(&courses[j]))
+		if a != b {
+			return a < b
+		}
+		return courses[i].Meetings*courses[i].Duration > courses[j].Meetings*courses[j].Duration
+	})
+
+	solution := &Solution{}
+	for i := range courses {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		placements, cost, reason := s.place(&courses[i])
+		if reason != "" {
+			solution.Unplaced = append(solution.Unplaced, Unplaced{CourseID: courses[i].ID, Reason: reason})
+		} else {
+			solution.Placements = append(solution.Placements, placements...)
+			solution.Cost += cost
+		}
+		if progress != nil {
+			progress(i+1, len(courses))
+		}
+	}
+
+	return solution, nil
+}
+
+type solver struct {
+	problem  *Problem
+	teachers map[uint][]schedule.Slot
+	rooms    map[uint][]schedule.Slot
+	dayLoad  map[schedule.Days]int
+}
+
+func newSolver(p *Problem) *solver {
+	s := &solver{
+		problem:  p,
+		teachers: make(map[uint][]schedule.Slot),
+		rooms:    make(map[uint][]schedule.Slot),
+		dayLoad:  make(map[schedule.Days]int),
+	}
+	for id, slots := range p.TeacherBusy {
+		s.teachers[id] = append([]schedule.Slot(nil), slots...)
+	}
+	for id, slots := range p.RoomBusy {
+		s.rooms[id] = append([]schedule.Slot(nil), slots...)
+	}
+	return s
+}
+
+// suitableRooms lists the rooms that seat the course and offer its features,
+// smallest first
+func (s *solver) suitableRooms(course *Course) []Room {
+	var rooms []Room
+	for _, room := range s.problem.Rooms {
+		if room.Capacity >= course.Size && hasFeatures(room.Features, course.Features) {
+			rooms = append(rooms, room)
+		}
+	}
+	sort.SliceStable(rooms, func(i, j int) bool { return rooms[i].Capacity < rooms[j].Capacity })
+	return rooms
+}
+
+// place gives every meeting of the course a day, time and room. On failure
+// nothing is kept and the reason is returned.
+func (s *solver) place(course *Course) ([]Placement, int, string) {
+	rooms := s.suitableRooms(course)
+	if len(rooms) == 0 {
+		return nil, 0, "no room has enough seats and the required features"
+	}
+	if course.Meetings > len(s.problem.Days.Split()) {
+		return nil, 0, "more meetings per week than available days"
+	}
+
+	var placements []Placement
+	total := 0
+	for k := 0; k < course.Meetings; k++ {
+		best, cost, ok := s.cheapest(course, rooms, placements)
+		if !ok {
+			for _, placement := range placements {
+				s.release(course, placement)
+			}
+			return nil, 0, "the teacher or the suitable rooms are not free at any remaining time"
+		}
+		s.take(course, best)
+		placements = append(placements, best)
+		total += cost
+	}
+	return placements, total, ""
+}
+
+func (s *solver) cheapest(course *Course, rooms []Room, placed []Placement) (Placement, int, bool) {
+	var used schedule.Days
+	for _, placement := range placed {
+		used |= placement.Slot.Days
+	}
+
+	var best Placement
+	bestCost, found := 0, false
+	for _, day := range s.problem.Days.Split() {
+		if used&day != 0 {
+			continue
+		}
+		for start := s.problem.DayStart; start+course.Duration <= s.problem.DayEnd; start += s.problem.Step {
+			slot := schedule.Slot{Days: day, Start: start, End: start + course.Duration}
+			if overlapsAny(s.teachers[course.TeacherID], slot) {
+				continue
+			}
+			roomID, ok := s.freeRoom(rooms, slot)
+			if !ok {
+				continue
+			}
+			cost := s.cost(course, slot, placed)
+			if !found || cost < bestCost {
+				best = Placement{CourseID: course.ID, RoomID: roomID, Slot: slot}
+				bestCost, found = cost, true
+			}
+		}
+	}
+	return best, bestCost, found
+}
+
+func (s *solver) freeRoom(rooms []Room, slot schedule.Slot) (uint, bool) {
+	for _, room := range rooms {
+		if !overlapsAny(s.rooms[room.ID], slot) {
+			return room.ID, true
+		}
+	}
+	return 0, false
+}
+
+func (s *solver) cost(course *Course, slot schedule.Slot, placed []Placement) int {
+	cost := s.dayLoad[slot.Days] * CostDayLoad
+
+	if preferred := s.problem.Preferred[course.TeacherID]; len(preferred) > 0 && !withinAny(preferred, slot) {
+		cost += CostOutsidePreference
+	}
+	for _, taken := range s.teachers[course.TeacherID] {
+		if taken.Days&slot.Days != 0 {
+			cost += CostTeacherDayLoad
+		}
+	}
+	for _, placement := range placed {
+		if adjacentDays(placement.Slot.Days, slot.Days) {
+			cost += CostAdjacentDay
+		}
+	}
+	if len(placed) > 0 && placed[0].Slot.Start != slot.Start {
+		cost += CostOtherStartTime
+	}
+	return cost
+}
+
+func (s *solver) take(course *Course, placement Placement) {
+	s.teachers[course.TeacherID] = append(s.teachers[course.TeacherID], placement.Slot)
+	s.rooms[placement.RoomID] = append(s.rooms[placement.RoomID], placement.Slot)
+	s.dayLoad[placement.Slot.Days]++
+}
+
+func (s *solver) release(course *Course, placement Placement) {
+	s.teachers[course.TeacherID] = without(s.teachers[course.TeacherID], placement.Slot)
+	s.rooms[placement.RoomID] = without(s.rooms[placement.RoomID], placement.Slot)
+	s.dayLoad[placement.Slot.Days]--
+}
+
+func overlapsAny(slots []schedule.Slot, slot schedule.Slot) bool {
+	for _, other := range slots {
+		if other.Overlaps(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinAny reports whether the slot lies entirely within one of the slots
+func withinAny(slots []schedule.Slot, slot schedule.Slot) bool {
+	for _, other := range slots {
+		if other.Days&slot.Days == slot.Days && other.Start <= slot.Start && slot.End <= other.End {
+			return true
+		}
+	}
+	return false
+}
+
+// adjacentDays reports whether two single days follow each other. Sunday and
+// the next Monday are a week apart in a timetable and do not count.
+func adjacentDays(a, b schedule.Days) bool {
+	return a<<1 == b || b<<1 == a
+}
+
+// without removes the last occurrence of the slot
+func without(slots []schedule.Slot, slot schedule.Slot) []schedule.Slot {
+	for i := len(slots) - 1; i >= 0; i-- {
+		if slots[i] == slot {
+			return append(slots[:i], slots[i+1:]...)
+		}
+	}
+	return slots
+}
+
+func hasFeatures(offered, required []string) bool {
+	for _, feature := range required {
+		found := false
+		for _, candidate := range offered {
+			if candidate == feature {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
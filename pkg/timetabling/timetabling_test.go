@@ -0,0 +1,257 @@
+package timetabling
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Tretorhate/university-management-system/pkg/schedule"
+)
+
+const (
+	nine   = 9 * 60
+	ten    = 10 * 60
+	twelve = 12 * 60
+)
+
+// week returns a problem with hourly start times between 9:00 and 12:00
+func week(days schedule.Days, courses []Course, rooms []Room) *Problem {
+	return &Problem{
+		Courses:  courses,
+		Rooms:    rooms,
+		Days:     days,
+		DayStart: nine,
+		DayEnd:   twelve,
+		Step:     60,
+	}
+}
+
+func solve(t *testing.T, p *Problem) *Solution {
+	t.Helper()
+	solution, err := Solve(context.Background(), p, nil)
+	if err != nil {
+		t.Fatalf("Solve: %v", err)
+	}
+	return solution
+}
+
+func TestSolveNeverDoubleBooks(t *testing.T) {
+	days := schedule.Monday | schedule.Tuesday | schedule.Wednesday
+	var courses []Course
+	for i := uint(1); i <= 8; i++ {
+		courses = append(courses, Course{ID: i, TeacherID: i%3 + 1, Meetings: 2, Duration: 90})
+	}
+	p := week(days, courses, []Room{{ID: 1, Capacity: 30}, {ID: 2, Capacity: 30}})
+	p.TeacherBusy = map[uint][]schedule.Slot{1: {{Days: schedule.Monday, Start: nine, End: twelve}}}
+	p.RoomBusy = map[uint][]schedule.Slot{2: {{Days: schedule.Tuesday, Start: nine, End: ten}}}
+
+	solution := solve(t, p)
+
+	teacherOf := make(map[uint]uint)
+	for _, course := range courses {
+		teacherOf[course.ID] = course.TeacherID
+	}
+	teachers := make(map[uint][]schedule.Slot)
+	for id, slots := range p.TeacherBusy {
+		teachers[id] = append(teachers[id], slots...)
+	}
+	rooms := make(map[uint][]schedule.Slot)
+	for id, slots := range p.RoomBusy {
+		rooms[id] = append(rooms[id], slots...)
+	}
+	daysOf := make(map[uint]schedule.Days)
+	for _, placement := range solution.Placements {
+		teacher := teacherOf[placement.CourseID]
+		if overlapsAny(teachers[teacher], placement.Slot) {
+			t.Errorf("teacher %d is double-booked at %v", teacher, placement.Slot)
+		}
+		if overlapsAny(rooms[placement.RoomID], placement.Slot) {
+			t.Errorf("room %d is double-booked at %v", placement.RoomID, placement.Slot)
+		}
+		if daysOf[placement.CourseID]&placement.Slot.Days != 0 {
+			t.Errorf("course %d meets twice on %v", placement.CourseID, placement.Slot.Days)
+		}
+		teachers[teacher] = append(teachers[teacher], placement.Slot)
+		rooms[placement.RoomID] = append(rooms[placement.RoomID], placement.Slot)
+		daysOf[placement.CourseID] |= placement.Slot.Days
+	}
+
+	placed := 0
+	for _, course := range courses {
+		if days := daysOf[course.ID]; days != 0 {
+			placed++
+			if got := len(days.Split()); got != course.Meetings {
+				t.Errorf("course %d has %d meetings, want %d", course.ID, got, course.Meetings)
+			}
+		}
+	}
+	if placed+len(solution.Unplaced) != len(courses) {
+		t.Errorf("%d courses placed and %d unplaced, want %d in total", placed, len(solution.Unplaced), len(courses))
+	}
+}
+
+func TestSolveRoomRequirements(t *testing.T) {
+	rooms := []Room{
+		{ID: 1, Capacity: 10},
+		{ID: 2, Capacity: 50},
+		{ID: 3, Capacity: 40},
+		{ID: 4, Capacity: 20, Features: []string{"lab", "projector"}},
+	}
+	tests := []struct {
+		name     string
+		course   Course
+		wantRoom uint
+		unplaced bool
+	}{
+		{"any room fits", Course{ID: 1, TeacherID: 1, Meetings: 1, Duration: 60}, 1, false},
+		{"smallest room with enough seats", Course{ID: 1, TeacherID: 1, Size: 30, Meetings: 1, Duration: 60}, 3, false},
+		{"room with the features", Course{ID: 1, TeacherID: 1, Size: 5, Meetings: 1, Duration: 60, Features: []string{"lab"}}, 4, false},
+		{"no room seats the course", Course{ID: 1, TeacherID: 1, Size: 60, Meetings: 1, Duration: 60}, 0, true},
+		{"no room with enough seats has the features", Course{ID: 1, TeacherID: 1, Size: 30, Meetings: 1, Duration: 60, Features: []string{"lab"}}, 0, true},
+		{"more meetings than days", Course{ID: 1, TeacherID: 1, Meetings: 2, Duration: 60}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution := solve(t, week(schedule.Monday, []Course{tt.course}, rooms))
+			if tt.unplaced {
+				if len(solution.Unplaced) != 1 || len(solution.Placements) != 0 {
+					t.Fatalf("got placements %v and unplaced %v, want the course unplaced", solution.Placements, solution.Unplaced)
+				}
+				return
+			}
+			if len(solution.Placements) != 1 {
+				t.Fatalf("got placements %v and unplaced %v, want one placement", solution.Placements, solution.Unplaced)
+			}
+			if got := solution.Placements[0].RoomID; got != tt.wantRoom {
+				t.Errorf("room = %d, want %d", got, tt.wantRoom)
+			}
+		})
+	}
+}
+
+func TestSolveSoftCosts(t *testing.T) {
+	monToFri := schedule.Monday | schedule.Tuesday | schedule.Wednesday | schedule.Thursday | schedule.Friday
+	room := []Room{{ID: 1, Capacity: 30}, {ID: 2, Capacity: 30}}
+	tests := []struct {
+		name      string
+		problem   *Problem
+		preferred map[uint][]schedule.Slot
+		want      []Placement
+		wantCost  int
+	}{
+		{
+			name:    "earliest slot when everything costs the same",
+			problem: week(monToFri, []Course{{ID: 1, TeacherID: 1, Meetings: 1, Duration: 60}}, room),
+			want:    []Placement{{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Monday, Start: nine, End: ten}}},
+		},
+		{
+			name:      "teacher's preferred time",
+			problem:   week(monToFri, []Course{{ID: 1, TeacherID: 1, Meetings: 1, Duration: 60}}, room),
+			preferred: map[uint][]schedule.Slot{1: {{Days: schedule.Tuesday, Start: ten, End: twelve}}},
+			want:      []Placement{{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Tuesday, Start: ten, End: ten + 60}}},
+		},
+		{
+			name:    "meetings skip a day and keep their start time",
+			problem: week(monToFri, []Course{{ID: 1, TeacherID: 1, Meetings: 2, Duration: 60}}, room),
+			want: []Placement{
+				{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Monday, Start: nine, End: ten}},
+				{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Wednesday, Start: nine, End: ten}},
+			},
+		},
+		{
+			name: "courses spread over the week",
+			problem: week(schedule.Monday|schedule.Tuesday, []Course{
+				{ID: 1, TeacherID: 1, Meetings: 1, Duration: 60},
+				{ID: 2, TeacherID: 2, Meetings: 1, Duration: 60},
+			}, room),
+			want: []Placement{
+				{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Monday, Start: nine, End: ten}},
+				{CourseID: 2, RoomID: 1, Slot: schedule.Slot{Days: schedule.Tuesday, Start: nine, End: ten}},
+			},
+		},
+		{
+			name: "adjacent day is cheaper than a preferred time missed",
+			problem: week(schedule.Monday|schedule.Tuesday, []Course{
+				{ID: 1, TeacherID: 1, Meetings: 2, Duration: 60},
+			}, room),
+			preferred: map[uint][]schedule.Slot{1: {{Days: schedule.Monday | schedule.Tuesday, Start: ten, End: twelve}}},
+			want: []Placement{
+				{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Monday, Start: ten, End: ten + 60}},
+				{CourseID: 1, RoomID: 1, Slot: schedule.Slot{Days: schedule.Tuesday, Start: ten, End: ten + 60}},
+			},
+			wantCost: CostAdjacentDay,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.problem.Preferred = tt.preferred
+			solution := solve(t, tt.problem)
+			if len(solution.Unplaced) > 0 {
+				t.Fatalf("Unplaced = %v, want none", solution.Unplaced)
+			}
+			if !reflect.DeepEqual(solution.Placements, tt.want) {
+				t.Errorf("Placements = %v, want %v", solution.Placements, tt.want)
+			}
+			if solution.Cost != tt.wantCost {
+				t.Errorf("Cost = %d, want %d", solution.Cost, tt.wantCost)
+			}
+		})
+	}
+}
+
+func TestSolveFreesPartialPlacements(t *testing.T) {
+	// One room and one hour a day. The first course fits on Monday and Tuesday
+	// but its teacher is busy on Wednesday; the second course needs all three days.
+	p := week(schedule.Monday|schedule.Tuesday|schedule.Wednesday, []Course{
+		{ID: 1, TeacherID: 1, Meetings: 3, Duration: 60},
+		{ID: 2, TeacherID: 2, Meetings: 3, Duration: 60},
+	}, []Room{{ID: 1, Capacity: 30}})
+	p.DayEnd = ten
+	p.TeacherBusy = map[uint][]schedule.Slot{1: {{Days: schedule.Wednesday, Start: nine, End: ten}}}
+
+	solution := solve(t, p)
+
+	if len(solution.Unplaced) != 1 || solution.Unplaced[0].CourseID != 1 {
+		t.Fatalf("Unplaced = %v, want course 1", solution.Unplaced)
+	}
+	if solution.Unplaced[0].Reason == "" {
+		t.Error("unplaced course has no reason")
+	}
+	var days schedule.Days
+	for _, placement := range solution.Placements {
+		if placement.CourseID != 2 {
+			t.Errorf("placement %v of an unplaced course", placement)
+		}
+		days |= placement.Slot.Days
+	}
+	if want := schedule.Monday | schedule.Tuesday | schedule.Wednesday; days != want {
+		t.Errorf("course 2 meets on %v, want %v", days.Names(), want.Names())
+	}
+	// Monday, then Wednesday, then Tuesday next to both. The day loads of the
+	// freed placements must not count.
+	if want := 2 * CostAdjacentDay; solution.Cost != want {
+		t.Errorf("Cost = %d, want %d", solution.Cost, want)
+	}
+}
+
+func TestSolveRejectsInvalidProblems(t *testing.T) {
+	course := []Course{{ID: 1, TeacherID: 1, Meetings: 1, Duration: 60}}
+	tests := []struct {
+		name   string
+		modify func(p *Problem)
+	}{
+		{"no days", func(p *Problem) { p.Days = 0 }},
+		{"day ends before it starts", func(p *Problem) { p.DayEnd = p.DayStart }},
+		{"no step", func(p *Problem) { p.Step = 0 }},
+		{"course without meetings", func(p *Problem) { p.Courses = []Course{{ID: 1, TeacherID: 1, Duration: 60}} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := week(schedule.Monday, course, []Room{{ID: 1}})
+			tt.modify(p)
+			if _, err := Solve(context.Background(), p, nil); err == nil {
+				t.Error("Solve accepted an invalid problem")
+			}
+		})
+	}
+}